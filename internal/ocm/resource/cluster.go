@@ -146,7 +146,8 @@ func (c *Cluster) CreateAWSBuilder(clusterTopology rosaTypes.ClusterTopology,
 	additionalComputeSecurityGroupIds []string,
 	additionalInfraSecurityGroupIds []string,
 	additionalControlPlaneSecurityGroupIds []string,
-	additionalAllowedPrincipals []string) error {
+	additionalAllowedPrincipals []string,
+	auditLogRoleArn *string) error {
 
 	if clusterTopology == rosaTypes.Hcp && awsSubnetIDs == nil {
 		return errors.New("Hosted Control Plane clusters must have a pre-configure VPC. Make sure to specify the subnet ids.")
@@ -239,6 +240,17 @@ func (c *Cluster) CreateAWSBuilder(clusterTopology rosaTypes.ClusterTopology,
 		awsBuilder.AdditionalAllowedPrincipals(additionalAllowedPrincipals...)
 	}
 
+	if auditLogRoleArn != nil {
+		if clusterTopology != rosaTypes.Hcp {
+			return errors.New("Audit log forwarding is only supported on Hosted Control Plane clusters")
+		}
+		if !rosaTypes.IAMRoleArnRE.MatchString(*auditLogRoleArn) {
+			return errors.New(fmt.Sprintf("Expected a valid value for AuditLogRoleArn matching %s. Got %s",
+				rosaTypes.IAMRoleArnRE, *auditLogRoleArn))
+		}
+		awsBuilder.AuditLog(cmv1.NewAuditLog().RoleArn(*auditLogRoleArn))
+	}
+
 	c.clusterBuilder.AWS(awsBuilder)
 
 	return nil
@@ -20,10 +20,12 @@ import (
 	"context"
 	"flag"
 	"log"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider"
+	"github.com/terraform-redhat/terraform-provider-rhcs/tracing"
 )
 
 // Generate the Terraform provider documentation using `tfplugindocs`:
@@ -37,12 +39,28 @@ func main() {
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := providerserver.ServeOpts{
-		Address: rhcsProviderAddress,
-		Debug:   debug,
+	// Tracing is only enabled when configured with the OpenTelemetry environment variables:
+	shutdownTracing, err := tracing.Setup(context.Background())
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	var opts []tf6server.ServeOpt
+	if debug {
+		opts = append(opts, tf6server.WithManagedDebug())
+	}
+
+	err = tf6server.Serve(rhcsProviderAddress, provider.NewServer(), opts...)
+
+	// Terraform kills the provider shortly after asking it to stop, so the pending spans need
+	// to be flushed quickly:
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if shutdownErr := shutdownTracing(ctx); shutdownErr != nil {
+		log.Printf("[WARN] Failed to flush traces: %v", shutdownErr)
 	}
 
-	if err := providerserver.Serve(context.Background(), provider.New, opts); err != nil {
+	if err != nil {
 		log.Fatal(err.Error())
 	}
 }
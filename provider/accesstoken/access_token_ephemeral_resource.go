@@ -0,0 +1,167 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accesstoken
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+)
+
+type AccessTokenEphemeralResource struct {
+	connection *sdk.Connection
+}
+
+var _ ephemeral.EphemeralResource = &AccessTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &AccessTokenEphemeralResource{}
+
+func New() ephemeral.EphemeralResource {
+	return &AccessTokenEphemeralResource{}
+}
+
+func (r *AccessTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest,
+	resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_token"
+}
+
+func (r *AccessTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest,
+	resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Access token of the OCM connection configured in the provider. The token " +
+			"isn't saved to the plan or to the state, so it can be used to configure other providers " +
+			"or tools that need to talk to the OCM API without persisting it.",
+		Attributes: map[string]schema.Attribute{
+			"min_validity": schema.StringAttribute{
+				Description: "Minimum time that the returned token has to be valid for, for example `15m`. " +
+					"If the current token expires before that a new one is requested. By default a new " +
+					"token is only requested when the current one is about to expire.",
+				Optional: true,
+			},
+			"access_token": schema.StringAttribute{
+				Description: "OCM access token.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"expiration": schema.StringAttribute{
+				Description: "Expiration time of the access token, in RFC3339 format. It is empty " +
+					"if the token doesn't contain an expiration time.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *AccessTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest,
+	resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	connection, ok := req.ProviderData.(*sdk.Connection)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *sdk.Connection, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.connection = connection
+}
+
+func (r *AccessTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest,
+	resp *ephemeral.OpenResponse) {
+	state := &AccessTokenState{}
+	resp.Diagnostics.Append(req.Config.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var expiresIn []time.Duration
+	if !state.MinValidity.IsNull() && !state.MinValidity.IsUnknown() {
+		minValidity, err := time.ParseDuration(state.MinValidity.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("min_validity"),
+				"Invalid minimum validity",
+				fmt.Sprintf("Value '%s' isn't a valid duration: %v", state.MinValidity.ValueString(), err),
+			)
+			return
+		}
+		expiresIn = append(expiresIn, minValidity)
+	}
+
+	accessToken, _, err := r.connection.TokensContext(ctx, expiresIn...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Can't get access token",
+			fmt.Sprintf("Can't get the access token of the OCM connection: %v", err),
+		)
+		return
+	}
+	if accessToken == "" {
+		resp.Diagnostics.AddError(
+			"Can't get access token",
+			"The OCM connection doesn't use authentication, so there is no access token",
+		)
+		return
+	}
+
+	state.AccessToken = types.StringValue(accessToken)
+	state.Expiration = types.StringValue("")
+	expiration, ok, err := TokenExpiration(accessToken)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Can't parse access token",
+			fmt.Sprintf("Can't get the expiration time of the access token: %v", err),
+		)
+	} else if ok {
+		state.Expiration = types.StringValue(expiration.UTC().Format(time.RFC3339))
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, state)...)
+}
+
+// TokenExpiration returns the expiration time of the given access token. The signature of the
+// token isn't verified, as that is the job of the server. The returned flag is false if the token
+// doesn't contain an expiration time.
+func TokenExpiration(accessToken string) (expiration time.Time, ok bool, err error) {
+	claims := jwt.MapClaims{}
+	_, _, err = jwt.NewParser().ParseUnverified(accessToken, claims)
+	if err != nil {
+		return
+	}
+	exp, present := claims["exp"]
+	if !present {
+		return
+	}
+	value, isNumber := exp.(float64)
+	if !isNumber {
+		err = fmt.Errorf("expected a number in the 'exp' claim, but got '%v'", exp)
+		return
+	}
+	expiration = time.Unix(int64(value), 0)
+	ok = true
+	return
+}
@@ -232,6 +232,10 @@ func (r *ClusterAutoscalerResource) Create(ctx context.Context, request resource
 		return
 	}
 
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(plan.Cluster.ValueString())
+	defer common.ClusterMutexKV.Unlock(plan.Cluster.ValueString())
+
 	autoscaler, err := r.collection.Cluster(plan.Cluster.ValueString()).Autoscaler().Get().Send()
 	if err != nil && autoscaler.Status() != http.StatusNotFound {
 		response.Diagnostics.AddError("Can't create autoscaler", fmt.Sprintf("Autoscaler for cluster '%s' might already exists. Error: %s",
@@ -332,6 +336,10 @@ func (r *ClusterAutoscalerResource) Update(ctx context.Context, request resource
 		return
 	}
 
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(plan.Cluster.ValueString())
+	defer common.ClusterMutexKV.Unlock(plan.Cluster.ValueString())
+
 	_, err := r.collection.Cluster(plan.Cluster.ValueString()).Autoscaler().Get().SendContext(ctx)
 	if err != nil {
 		response.Diagnostics.AddError(
@@ -395,6 +403,10 @@ func (r *ClusterAutoscalerResource) Delete(ctx context.Context, request resource
 		return
 	}
 
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(state.Cluster.ValueString())
+	defer common.ClusterMutexKV.Unlock(state.Cluster.ValueString())
+
 	resource := r.collection.Cluster(state.Cluster.ValueString()).Autoscaler()
 	_, err := resource.Delete().SendContext(ctx)
 	if err != nil {
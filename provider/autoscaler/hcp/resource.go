@@ -135,6 +135,10 @@ func (r *ClusterAutoscalerResource) Create(ctx context.Context, request resource
 		return
 	}
 
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(plan.Cluster.ValueString())
+	defer common.ClusterMutexKV.Unlock(plan.Cluster.ValueString())
+
 	err = r.updateAutoscaler(ctx, plan, nil, plan.Cluster.ValueString(), r.collection)
 	if err != nil {
 		response.Diagnostics.AddError(
@@ -214,6 +218,10 @@ func (r *ClusterAutoscalerResource) Update(ctx context.Context, request resource
 		return
 	}
 
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(plan.Cluster.ValueString())
+	defer common.ClusterMutexKV.Unlock(plan.Cluster.ValueString())
+
 	_, err := r.collection.Cluster(plan.Cluster.ValueString()).Autoscaler().Get().SendContext(ctx)
 
 	if err != nil {
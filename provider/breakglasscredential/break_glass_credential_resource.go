@@ -0,0 +1,470 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package breakglasscredential
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+)
+
+const (
+	resourceTypeName      = "_hcp_break_glass_credential"
+	failedToCreateSummary = "Failed to create break glass credential"
+	failedToDeleteSummary = "Failed to revoke break glass credential"
+	failedToReadSummary   = "Failed to read break glass credential"
+
+	minExpiration = 10 * time.Minute
+	maxExpiration = 24 * time.Hour
+
+	issueTimeout      = 10 * time.Minute
+	issuePollInterval = 5 * time.Second
+)
+
+type BreakGlassCredentialResource struct {
+	collection  *cmv1.ClustersClient
+	clusterWait common.ClusterWait
+}
+
+// Interface checks
+var _ resource.Resource = &BreakGlassCredentialResource{}
+var _ resource.ResourceWithConfigure = &BreakGlassCredentialResource{}
+var _ resource.ResourceWithImportState = &BreakGlassCredentialResource{}
+var _ resource.ResourceWithModifyPlan = &BreakGlassCredentialResource{}
+
+func New() resource.Resource {
+	return &BreakGlassCredentialResource{}
+}
+
+func (r *BreakGlassCredentialResource) Metadata(_ context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + resourceTypeName
+}
+
+func (r *BreakGlassCredentialResource) Schema(_ context.Context, _ resource.SchemaRequest,
+	resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Break glass credential of a ROSA cluster with hosted control planes and external " +
+			"authentication enabled. It provides emergency access to the cluster when the external " +
+			"authentication provider isn't available. OCM can only revoke all the break glass credentials " +
+			"of a cluster at once, so destroying this resource revokes all of them, and the plan warns " +
+			"when the cluster has other issued credentials. Credentials that have " +
+			"been revoked or have expired are removed from the state, so that they are issued again by " +
+			"the next apply.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Unique identifier of the break glass credential.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster": schema.StringAttribute{
+				Description: "Identifier of the cluster." + common.ValueCannotBeChangedStringDescription,
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`.*\S.*`),
+						"cluster ID may not be empty/blank string"),
+				},
+			},
+			"username": schema.StringAttribute{
+				Description: "Name of the user of the credential. If not set a random name is generated." +
+					common.ValueCannotBeChangedStringDescription,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(35),
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[a-zA-Z0-9-.]+$`),
+						"username may only contain alphanumeric characters, '-' and '.'"),
+				},
+			},
+			"expiration": schema.StringAttribute{
+				Description: "Duration of the validity of the credential, for example `2h` or `90m`. It must " +
+					"be between 10 minutes and 24 hours. If not set the default of OCM is used." +
+					common.ValueCannotBeChangedStringDescription,
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					expirationValidator{},
+				},
+			},
+			"expiration_timestamp": schema.StringAttribute{
+				Description: "Time, in RFC3339 format, when the credential expires.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the credential, for example `issued` or `revoked`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"kubeconfig": schema.StringAttribute{
+				Description: "Kubeconfig that gives access to the cluster using the credential.",
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *BreakGlassCredentialResource) Configure(_ context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connection, ok := req.ProviderData.(*sdk.Connection)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.collection = connection.ClustersMgmt().V1().Clusters()
+	r.clusterWait = common.NewClusterWait(r.collection, connection)
+}
+
+func (r *BreakGlassCredentialResource) Create(ctx context.Context, req resource.CreateRequest,
+	resp *resource.CreateResponse) {
+	plan := &BreakGlassCredentialState{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterId := plan.Cluster.ValueString()
+	waitTimeoutInMinutes := int64(60)
+	cluster, err := r.clusterWait.WaitForClusterToBeReady(ctx, clusterId, waitTimeoutInMinutes)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cluster is not ready",
+			fmt.Sprintf("Cluster with id '%s' is not in the ready state: %v", clusterId, err),
+		)
+		return
+	}
+	if !cluster.Hypershift().Enabled() || !cluster.ExternalAuthConfig().Enabled() {
+		resp.Diagnostics.AddAttributeError(path.Root("cluster"), failedToCreateSummary,
+			fmt.Sprintf("Cluster '%s' doesn't have external authentication providers enabled, break "+
+				"glass credentials are only supported for clusters with hosted control planes created "+
+				"with 'external_auth_providers_enabled' set to 'true'", clusterId))
+		return
+	}
+
+	builder := cmv1.NewBreakGlassCredential()
+	if common.HasValue(plan.Username) {
+		builder.Username(plan.Username.ValueString())
+	}
+	if common.HasValue(plan.Expiration) {
+		expiration, err := time.ParseDuration(plan.Expiration.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("expiration"), failedToCreateSummary, err.Error())
+			return
+		}
+		builder.ExpirationTimestamp(time.Now().Add(expiration))
+	}
+	object, err := builder.Build()
+	if err != nil {
+		resp.Diagnostics.AddError(failedToCreateSummary,
+			fmt.Sprintf("Failed to build break glass credential for cluster '%s': %v", clusterId, err))
+		return
+	}
+
+	// Serialize the changes sent to the cluster, but not the wait for the credential:
+	credentials := r.collection.Cluster(clusterId).BreakGlassCredentials()
+	common.ClusterMutexKV.Lock(clusterId)
+	addResp, err := credentials.Add().Body(object).SendContext(ctx)
+	common.ClusterMutexKV.Unlock(clusterId)
+	if err != nil {
+		resp.Diagnostics.AddError(failedToCreateSummary,
+			fmt.Sprintf("Failed to create break glass credential for cluster '%s': %v",
+				clusterId, common.HandleErr(addResp.Error(), err)))
+		return
+	}
+
+	credential, err := waitForIssued(ctx, credentials.BreakGlassCredential(addResp.Body().ID()))
+	if err != nil {
+		resp.Diagnostics.AddError(failedToCreateSummary,
+			fmt.Sprintf("Break glass credential '%s' for cluster '%s' wasn't issued: %v",
+				addResp.Body().ID(), clusterId, err))
+
+		// Save the credential that was already added, so that Terraform marks it as tainted
+		// and revokes it instead of leaving it behind:
+		populateState(addResp.Body(), plan)
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
+
+	populateState(credential, plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// ModifyPlan adds a warning to the plan if it revokes a credential of a cluster that has other
+// issued credentials, as OCM can only revoke all of them at once.
+func (r *BreakGlassCredentialResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the credential is being created:
+	if req.State.Raw.IsNull() {
+		return
+	}
+	state := &BreakGlassCredentialState{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// All the attributes that can be changed require replacing the credential, which revokes it:
+	if !req.Plan.Raw.IsNull() {
+		plan := &BreakGlassCredentialState{}
+		resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if state.Cluster.Equal(plan.Cluster) && state.Username.Equal(plan.Username) &&
+			state.Expiration.Equal(plan.Expiration) {
+			return
+		}
+	}
+
+	clusterId := state.Cluster.ValueString()
+	credentials, err := listCredentials(ctx, r.collection, clusterId)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("can't list break glass credentials of cluster (%s): %v",
+			clusterId, err))
+		return
+	}
+	others := []string{}
+	for _, credential := range credentials {
+		if credential.ID() == state.ID.ValueString() {
+			continue
+		}
+		switch credential.Status() {
+		case cmv1.BreakGlassCredentialStatusCreated, cmv1.BreakGlassCredentialStatusIssued:
+			others = append(others, fmt.Sprintf("'%s'", credential.ID()))
+		}
+	}
+	if len(others) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Other break glass credentials will be revoked",
+			fmt.Sprintf("OCM can only revoke all the break glass credentials of a cluster at once, "+
+				"so revoking credential '%s' will also revoke credentials %s of cluster '%s'.",
+				state.ID.ValueString(), strings.Join(others, ", "), clusterId),
+		)
+	}
+}
+
+func (r *BreakGlassCredentialResource) Read(ctx context.Context, req resource.ReadRequest,
+	resp *resource.ReadResponse) {
+	state := &BreakGlassCredentialState{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterId := state.Cluster.ValueString()
+	getResp, err := r.collection.Cluster(clusterId).BreakGlassCredentials().
+		BreakGlassCredential(state.ID.ValueString()).Get().SendContext(ctx)
+	if getResp != nil && getResp.Status() == http.StatusNotFound {
+		tflog.Warn(ctx, fmt.Sprintf("break glass credential (%s) of cluster (%s) not found, removing "+
+			"from state", state.ID.ValueString(), clusterId))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(failedToReadSummary,
+			fmt.Sprintf("Cannot read break glass credential '%s' for cluster '%s': %v",
+				state.ID.ValueString(), clusterId, common.HandleErr(getResp.Error(), err)))
+		return
+	}
+
+	credential := getResp.Body()
+	switch credential.Status() {
+	case cmv1.BreakGlassCredentialStatusRevoked,
+		cmv1.BreakGlassCredentialStatusAwaitingRevocation,
+		cmv1.BreakGlassCredentialStatusExpired:
+		tflog.Warn(ctx, fmt.Sprintf("break glass credential (%s) of cluster (%s) is %s, removing from "+
+			"state", state.ID.ValueString(), clusterId, credential.Status()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	populateState(credential, state)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *BreakGlassCredentialResource) Update(_ context.Context, _ resource.UpdateRequest,
+	resp *resource.UpdateResponse) {
+	// All the attributes require replacement, so this is never called:
+	resp.Diagnostics.AddError("Update not supported",
+		"Break glass credentials can't be updated, they need to be replaced")
+}
+
+func (r *BreakGlassCredentialResource) Delete(ctx context.Context, req resource.DeleteRequest,
+	resp *resource.DeleteResponse) {
+	state := &BreakGlassCredentialState{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterId := state.Cluster.ValueString()
+	common.ClusterMutexKV.Lock(clusterId)
+	defer common.ClusterMutexKV.Unlock(clusterId)
+
+	// OCM doesn't support revoking a single credential, only all the credentials of the cluster:
+	tflog.Info(ctx, fmt.Sprintf("revoking all the break glass credentials of cluster (%s)", clusterId))
+	deleteResp, err := r.collection.Cluster(clusterId).BreakGlassCredentials().Delete().SendContext(ctx)
+	if err != nil && (deleteResp == nil || deleteResp.Status() != http.StatusNotFound) {
+		resp.Diagnostics.AddError(failedToDeleteSummary,
+			fmt.Sprintf("Failed to revoke break glass credentials for cluster '%s': %v",
+				clusterId, common.HandleErr(deleteResp.Error(), err)))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState expects the identifier of the cluster and the identifier of the credential separated by
+// a comma. The kubeconfig can only be imported while the credential is issued.
+func (r *BreakGlassCredentialResource) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+	fields := strings.Split(req.ID, ",")
+	if len(fields) != 2 || fields[0] == "" || fields[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import identifier",
+			"Break glass credential to import should be specified as <cluster_id>,<credential_id>",
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster"), fields[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), fields[1])...)
+}
+
+// waitForIssued polls the credential till it has been issued, as only then the kubeconfig is
+// available.
+func waitForIssued(ctx context.Context, client *cmv1.BreakGlassCredentialClient) (*cmv1.BreakGlassCredential,
+	error) {
+	pollCtx, cancel := context.WithTimeout(ctx, issueTimeout)
+	defer cancel()
+	var credential *cmv1.BreakGlassCredential
+	_, err := client.Poll().
+		Interval(issuePollInterval).
+		Predicate(func(getResp *cmv1.BreakGlassCredentialGetResponse) bool {
+			credential = getResp.Body()
+			tflog.Debug(ctx, "polled break glass credential status", map[string]interface{}{
+				"status": credential.Status(),
+			})
+			switch credential.Status() {
+			case cmv1.BreakGlassCredentialStatusIssued,
+				cmv1.BreakGlassCredentialStatusFailed:
+				return true
+			}
+			return false
+		}).
+		StartContext(pollCtx)
+	if err != nil {
+		return nil, err
+	}
+	if credential.Status() != cmv1.BreakGlassCredentialStatusIssued {
+		return nil, fmt.Errorf("status is '%s'", credential.Status())
+	}
+	return credential, nil
+}
+
+func populateState(credential *cmv1.BreakGlassCredential, state *BreakGlassCredentialState) {
+	state.ID = types.StringValue(credential.ID())
+	state.Username = types.StringValue(credential.Username())
+	state.Status = types.StringValue(string(credential.Status()))
+	state.ExpirationTimestamp = formatTimestamp(credential.GetExpirationTimestamp())
+	// The kubeconfig is only returned while the credential is issued:
+	if kubeconfig, ok := credential.GetKubeconfig(); ok && kubeconfig != "" {
+		state.Kubeconfig = types.StringValue(kubeconfig)
+	} else if state.Kubeconfig.IsUnknown() {
+		state.Kubeconfig = types.StringNull()
+	}
+}
+
+func formatTimestamp(value time.Time, ok bool) types.String {
+	if !ok || value.IsZero() {
+		return types.StringNull()
+	}
+	return types.StringValue(value.UTC().Format(time.RFC3339))
+}
+
+type expirationValidator struct{}
+
+var _ validator.String = expirationValidator{}
+
+func (v expirationValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be a duration between %s and %s", minExpiration, maxExpiration)
+}
+
+func (v expirationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v expirationValidator) ValidateString(_ context.Context, req validator.StringRequest,
+	resp *validator.StringResponse) {
+	if !common.HasValue(req.ConfigValue) {
+		return
+	}
+	expiration, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid expiration",
+			fmt.Sprintf("Value '%s' isn't a valid duration: %v", req.ConfigValue.ValueString(), err))
+		return
+	}
+	if expiration < minExpiration || expiration > maxExpiration {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid expiration",
+			fmt.Sprintf("Expiration must be between %s and %s, but it is %s", minExpiration,
+				maxExpiration, expiration))
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package breakglasscredential
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type BreakGlassCredentialState struct {
+	ID                  types.String `tfsdk:"id"`
+	Cluster             types.String `tfsdk:"cluster"`
+	Username            types.String `tfsdk:"username"`
+	Expiration          types.String `tfsdk:"expiration"`
+	ExpirationTimestamp types.String `tfsdk:"expiration_timestamp"`
+	Status              types.String `tfsdk:"status"`
+	Kubeconfig          types.String `tfsdk:"kubeconfig"`
+}
+
+type BreakGlassCredentialsState struct {
+	Cluster types.String                     `tfsdk:"cluster"`
+	Items   []*BreakGlassCredentialItemState `tfsdk:"items"`
+}
+
+type BreakGlassCredentialItemState struct {
+	ID                  types.String `tfsdk:"id"`
+	Username            types.String `tfsdk:"username"`
+	Status              types.String `tfsdk:"status"`
+	ExpirationTimestamp types.String `tfsdk:"expiration_timestamp"`
+	RevocationTimestamp types.String `tfsdk:"revocation_timestamp"`
+}
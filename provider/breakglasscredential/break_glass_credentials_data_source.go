@@ -0,0 +1,166 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package breakglasscredential
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+)
+
+type BreakGlassCredentialsDataSource struct {
+	collection *cmv1.ClustersClient
+}
+
+var _ datasource.DataSource = &BreakGlassCredentialsDataSource{}
+var _ datasource.DataSourceWithConfigure = &BreakGlassCredentialsDataSource{}
+
+func NewDataSource() datasource.DataSource {
+	return &BreakGlassCredentialsDataSource{}
+}
+
+func (d *BreakGlassCredentialsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hcp_break_glass_credentials"
+}
+
+func (d *BreakGlassCredentialsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "List of the break glass credentials of a ROSA cluster with hosted control planes.",
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				Description: "Identifier of the cluster.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`.*\S.*`),
+						"cluster ID may not be empty/blank string"),
+				},
+			},
+			"items": schema.ListNestedAttribute{
+				Description: "Content of the list.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Unique identifier of the break glass credential.",
+							Computed:    true,
+						},
+						"username": schema.StringAttribute{
+							Description: "Name of the user of the credential.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Status of the credential, for example `issued` or `revoked`.",
+							Computed:    true,
+						},
+						"expiration_timestamp": schema.StringAttribute{
+							Description: "Time, in RFC3339 format, when the credential expires.",
+							Computed:    true,
+						},
+						"revocation_timestamp": schema.StringAttribute{
+							Description: "Time, in RFC3339 format, when the credential was revoked. " +
+								"Empty if it hasn't been revoked.",
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *BreakGlassCredentialsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured:
+	if req.ProviderData == nil {
+		return
+	}
+
+	connection, ok := req.ProviderData.(*sdk.Connection)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.collection = connection.ClustersMgmt().V1().Clusters()
+}
+
+func (d *BreakGlassCredentialsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
+	resp *datasource.ReadResponse) {
+	state := &BreakGlassCredentialsState{}
+	resp.Diagnostics.Append(req.Config.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Fetch the complete list of credentials of the cluster:
+	credentials, err := listCredentials(ctx, d.collection, state.Cluster.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Can't list break glass credentials",
+			fmt.Sprintf("Can't list break glass credentials for cluster '%s': %v",
+				state.Cluster.ValueString(), err),
+		)
+		return
+	}
+	state.Items = []*BreakGlassCredentialItemState{}
+	for _, credential := range credentials {
+		state.Items = append(state.Items, &BreakGlassCredentialItemState{
+			ID:                  types.StringValue(credential.ID()),
+			Username:            types.StringValue(credential.Username()),
+			Status:              types.StringValue(string(credential.Status())),
+			ExpirationTimestamp: formatTimestamp(credential.GetExpirationTimestamp()),
+			RevocationTimestamp: formatTimestamp(credential.GetRevocationTimestamp()),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// listCredentials returns the complete list of break glass credentials of the cluster.
+func listCredentials(ctx context.Context, collection *cmv1.ClustersClient,
+	clusterID string) ([]*cmv1.BreakGlassCredential, error) {
+	credentials := []*cmv1.BreakGlassCredential{}
+	listSize := 100
+	listPage := 1
+	listRequest := collection.Cluster(clusterID).BreakGlassCredentials().List().Size(listSize)
+	for {
+		listResp, err := listRequest.Page(listPage).SendContext(ctx)
+		if err != nil {
+			return nil, common.HandleErr(listResp.Error(), err)
+		}
+		credentials = append(credentials, listResp.Items().Slice()...)
+		if listResp.Size() < listSize {
+			break
+		}
+		listPage++
+	}
+	return credentials, nil
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/proxy"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -32,7 +33,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift-online/ocm-sdk-go/errors"
@@ -231,6 +234,13 @@ func (r *ClusterResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Description: "Wait till the cluster is ready.",
 				Optional:    true,
 			},
+			"deletion_policy": schema.StringAttribute{
+				Description: common.DeletionPolicyDescription("cluster"),
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(common.DeletionPolicyDelete, common.DeletionPolicyRetain),
+				},
+			},
 		},
 	}
 	return
@@ -565,6 +575,7 @@ func (r *ClusterResource) Update(ctx context.Context, request resource.UpdateReq
 	object := update.Body()
 
 	// Update the state:
+	state.DeletionPolicy = plan.DeletionPolicy
 	err = populateClusterState(object, state)
 	if err != nil {
 		response.Diagnostics.AddError(
@@ -589,6 +600,14 @@ func (r *ClusterResource) Delete(ctx context.Context, request resource.DeleteReq
 		return
 	}
 
+	// Keep the cluster in OCM if requested, only removing it from the state:
+	if common.ShouldRetain(state.DeletionPolicy) {
+		tflog.Info(ctx, fmt.Sprintf("Deletion policy is '%s', keeping cluster '%s' in OCM",
+			common.DeletionPolicyRetain, state.ID.ValueString()))
+		response.State.RemoveResource(ctx)
+		return
+	}
+
 	// Send the request to delete the cluster:
 	resource := r.collection.Cluster(state.ID.ValueString())
 	_, err := resource.Delete().SendContext(ctx)
@@ -633,7 +652,16 @@ func (r *ClusterResource) Delete(ctx context.Context, request resource.DeleteReq
 
 func (r *ClusterResource) ImportState(ctx context.Context, request resource.ImportStateRequest,
 	response *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
+	// The cluster can be specified with its identifier, its name or its external identifier:
+	clusterID, err := common.ResolveClusterID(ctx, r.collection, request.ID)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Can't import cluster",
+			err.Error(),
+		)
+		return
+	}
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("id"), clusterID)...)
 }
 
 // populateClusterState copies the data from the API object to the Terraform state.
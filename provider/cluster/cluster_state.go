@@ -53,4 +53,5 @@ type ClusterState struct {
 	State                                     types.String `tfsdk:"state"`
 	Version                                   types.String `tfsdk:"version"`
 	Wait                                      types.Bool   `tfsdk:"wait"`
+	DeletionPolicy                            types.String `tfsdk:"deletion_policy"`
 }
@@ -0,0 +1,258 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusteraddon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+)
+
+type AddonsDataSource struct {
+	collection *cmv1.AddOnsClient
+}
+
+var _ datasource.DataSource = &AddonsDataSource{}
+var _ datasource.DataSourceWithConfigure = &AddonsDataSource{}
+
+func NewDataSource() datasource.DataSource {
+	return &AddonsDataSource{}
+}
+
+func (d *AddonsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_addons"
+}
+
+func (d *AddonsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "List of add-ons that can be installed on clusters, with the definitions of their " +
+			"parameters.",
+		Attributes: map[string]schema.Attribute{
+			"search": schema.StringAttribute{
+				Description: "Search criteria. If not set only the enabled add-ons are listed.",
+				Optional:    true,
+			},
+			"order": schema.StringAttribute{
+				Description: "Order criteria.",
+				Optional:    true,
+			},
+			"item": schema.SingleNestedAttribute{
+				Description: "Content of the list when there is exactly one item.",
+				Attributes:  d.itemAttributes(),
+				Computed:    true,
+			},
+			"items": schema.ListNestedAttribute{
+				Description: "Content of the list.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: d.itemAttributes(),
+				},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *AddonsDataSource) itemAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description: "Unique identifier of the add-on, to be used in the `addon` attribute of " +
+				"the `rhcs_cluster_addon` resource.",
+			Computed: true,
+		},
+		"name": schema.StringAttribute{
+			Description: "Name of the add-on.",
+			Computed:    true,
+		},
+		"description": schema.StringAttribute{
+			Description: "Description of the add-on.",
+			Computed:    true,
+		},
+		"version": schema.StringAttribute{
+			Description: "Current version of the add-on.",
+			Computed:    true,
+		},
+		"parameters": schema.ListNestedAttribute{
+			Description: "Definitions of the enabled parameters of the add-on.",
+			Computed:    true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Description: "Identifier of the parameter, to be used as key of the " +
+							"`parameters` attribute of the `rhcs_cluster_addon` resource.",
+						Computed: true,
+					},
+					"name": schema.StringAttribute{
+						Description: "Name of the parameter.",
+						Computed:    true,
+					},
+					"description": schema.StringAttribute{
+						Description: "Description of the parameter.",
+						Computed:    true,
+					},
+					"value_type": schema.StringAttribute{
+						Description: "Type of the value of the parameter, for example `string`, " +
+							"`boolean`, `number` or `cidr`.",
+						Computed: true,
+					},
+					"required": schema.BoolAttribute{
+						Description: "Indicates if the parameter is required.",
+						Computed:    true,
+					},
+					"editable": schema.BoolAttribute{
+						Description: "Indicates if the parameter can be changed after the add-on has " +
+							"been installed.",
+						Computed: true,
+					},
+					"default_value": schema.StringAttribute{
+						Description: "Value used when the parameter isn't set.",
+						Computed:    true,
+					},
+					"validation": schema.StringAttribute{
+						Description: "Regular expression that the value of the parameter must match.",
+						Computed:    true,
+					},
+					"options": schema.ListNestedAttribute{
+						Description: "Valid values of the parameter. Empty if any value is valid.",
+						Computed:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"name": schema.StringAttribute{
+									Description: "Name of the option.",
+									Computed:    true,
+								},
+								"value": schema.StringAttribute{
+									Description: "Value of the option.",
+									Computed:    true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AddonsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured:
+	if req.ProviderData == nil {
+		return
+	}
+
+	connection, ok := req.ProviderData.(*sdk.Connection)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.collection = connection.ClustersMgmt().V1().Addons()
+}
+
+func (d *AddonsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
+	resp *datasource.ReadResponse) {
+	state := &AddonsState{}
+	resp.Diagnostics.Append(req.Config.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Fetch the list of add-ons:
+	state.Items = []*AddonState{}
+	listSize := 100
+	listPage := 1
+	listRequest := d.collection.List().Size(listSize)
+	if common.HasValue(state.Search) {
+		listRequest.Search(state.Search.ValueString())
+	} else {
+		listRequest.Search("enabled = 't'")
+	}
+	if common.HasValue(state.Order) {
+		listRequest.Order(state.Order.ValueString())
+	}
+	for {
+		listResp, err := listRequest.Page(listPage).SendContext(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Can't list add-ons",
+				common.HandleErr(listResp.Error(), err).Error(),
+			)
+			return
+		}
+		listResp.Items().Each(func(addon *cmv1.AddOn) bool {
+			state.Items = append(state.Items, convertAddon(addon))
+			return true
+		})
+		if listResp.Size() < listSize {
+			break
+		}
+		listPage++
+	}
+	if len(state.Items) == 1 {
+		state.Item = state.Items[0]
+	} else {
+		state.Item = nil
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func convertAddon(addon *cmv1.AddOn) *AddonState {
+	result := &AddonState{
+		ID:          types.StringValue(addon.ID()),
+		Name:        types.StringValue(addon.Name()),
+		Description: types.StringValue(addon.Description()),
+		Version:     common.EmptiableStringToStringType(addon.Version().ID()),
+		Parameters:  []*AddonParameterState{},
+	}
+	addon.Parameters().Each(func(parameter *cmv1.AddOnParameter) bool {
+		if !parameter.Enabled() {
+			return true
+		}
+		options := []*AddonParameterOptionState{}
+		for _, option := range parameter.Options() {
+			options = append(options, &AddonParameterOptionState{
+				Name:  types.StringValue(option.Name()),
+				Value: types.StringValue(option.Value()),
+			})
+		}
+		result.Parameters = append(result.Parameters, &AddonParameterState{
+			ID:           types.StringValue(parameter.ID()),
+			Name:         types.StringValue(parameter.Name()),
+			Description:  types.StringValue(parameter.Description()),
+			ValueType:    types.StringValue(parameter.ValueType()),
+			Required:     types.BoolValue(parameter.Required()),
+			Editable:     types.BoolValue(parameter.Editable()),
+			DefaultValue: common.EmptiableStringToStringType(parameter.DefaultValue()),
+			Validation:   common.EmptiableStringToStringType(parameter.Validation()),
+			Options:      options,
+		})
+		return true
+	})
+	return result
+}
@@ -0,0 +1,557 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusteraddon
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ocm_errors "github.com/openshift-online/ocm-sdk-go/errors"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+)
+
+const (
+	resourceTypeName        = "_cluster_addon"
+	failedToCreateSummary   = "Failed to install add-on"
+	failedToUpdateSummary   = "Failed to update add-on"
+	failedToDeleteSummary   = "Failed to uninstall add-on"
+	failedToReadSummary     = "Failed to read add-on"
+	invalidParameterSummary = "Invalid add-on parameter"
+
+	defaultWaitTimeoutInMinutes = int64(60)
+	waitPollInterval            = 30 * time.Second
+)
+
+type ClusterAddonResource struct {
+	collection  *cmv1.ClustersClient
+	addons      *cmv1.AddOnsClient
+	clusterWait common.ClusterWait
+}
+
+// Interface checks
+var _ resource.Resource = &ClusterAddonResource{}
+var _ resource.ResourceWithConfigure = &ClusterAddonResource{}
+var _ resource.ResourceWithImportState = &ClusterAddonResource{}
+var _ resource.ResourceWithModifyPlan = &ClusterAddonResource{}
+
+func New() resource.Resource {
+	return &ClusterAddonResource{}
+}
+
+func (r *ClusterAddonResource) Metadata(_ context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + resourceTypeName
+}
+
+func (r *ClusterAddonResource) Schema(_ context.Context, _ resource.SchemaRequest,
+	resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Add-on installed on a cluster. The parameters are validated against the parameter " +
+			"definitions of the add-on when the plan is created.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Unique identifier of the add-on installation.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster": schema.StringAttribute{
+				Description: "Identifier of the cluster." + common.ValueCannotBeChangedStringDescription,
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`.*\S.*`),
+						"cluster ID may not be empty/blank string"),
+				},
+			},
+			"addon": schema.StringAttribute{
+				Description: "Identifier of the add-on, for example `cluster-logging-operator`. The " +
+					"available add-ons can be listed with the `rhcs_addons` data source." +
+					common.ValueCannotBeChangedStringDescription,
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`.*\S.*`),
+						"add-on ID may not be empty/blank string"),
+				},
+			},
+			"parameters": schema.MapAttribute{
+				Description: "Values of the parameters of the add-on, indexed by parameter identifier. " +
+					"Parameters that aren't set use the default value of the add-on. Only the parameters " +
+					"that are editable can be changed after the installation.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"wait_for_ready": schema.BoolAttribute{
+				Description: "Wait until the add-on is either in a ready state or in a failed state " +
+					"after installing it or changing its parameters, and until it has been removed when " +
+					"uninstalling it. The default value is false.",
+				Optional: true,
+			},
+			"max_wait_timeout_in_minutes": schema.Int64Attribute{
+				Description: fmt.Sprintf("This value sets the maximum duration in minutes to wait for "+
+					"the add-on when `wait_for_ready` is true. The default value is %d.",
+					defaultWaitTimeoutInMinutes),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"state": schema.StringAttribute{
+				Description: "State of the add-on installation, for example `installing` or `ready`.",
+				Computed:    true,
+			},
+			"version": schema.StringAttribute{
+				Description: "Version of the installed add-on.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ClusterAddonResource) Configure(_ context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connection, ok := req.ProviderData.(*sdk.Connection)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.collection = connection.ClustersMgmt().V1().Clusters()
+	r.addons = connection.ClustersMgmt().V1().Addons()
+	r.clusterWait = common.NewClusterWait(r.collection, connection)
+}
+
+// ModifyPlan validates the parameters against the definitions of the add-on. This is done here
+// instead of in a validator because the definitions need to be fetched from OCM. The definitions are
+// only fetched when the add-on is installed or the parameters change.
+func (r *ClusterAddonResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse) {
+	if r.addons == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	plan := &ClusterAddonState{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !common.HasValue(plan.Addon) || plan.Parameters.IsUnknown() {
+		return
+	}
+	values := map[string]string{}
+	for id, value := range plan.Parameters.Elements() {
+		if value.IsUnknown() {
+			return
+		}
+		values[id] = value.(types.String).ValueString()
+	}
+
+	var previous map[string]string
+	if !req.State.Raw.IsNull() {
+		state := &ClusterAddonState{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if state.Addon.Equal(plan.Addon) && state.Parameters.Equal(plan.Parameters) {
+			return
+		}
+		if state.Addon.Equal(plan.Addon) && state.Cluster.Equal(plan.Cluster) {
+			previous = map[string]string{}
+			for id, value := range state.Parameters.Elements() {
+				previous[id] = value.(types.String).ValueString()
+			}
+		}
+	}
+
+	addonId := plan.Addon.ValueString()
+	getResp, err := r.addons.Addon(addonId).Get().SendContext(ctx)
+	if getResp != nil && getResp.Status() == http.StatusNotFound {
+		resp.Diagnostics.AddAttributeError(path.Root("addon"), invalidParameterSummary,
+			fmt.Sprintf("Add-on '%s' doesn't exist", addonId))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(invalidParameterSummary,
+			fmt.Sprintf("Can't fetch the parameter definitions of add-on '%s': %v",
+				addonId, common.HandleErr(getResp.Error(), err)))
+		return
+	}
+
+	for id, err := range ValidateParameters(getResp.Body(), values, previous) {
+		if id == "" {
+			resp.Diagnostics.AddAttributeError(path.Root("parameters"), invalidParameterSummary, err.Error())
+		} else {
+			resp.Diagnostics.AddAttributeError(path.Root("parameters").AtMapKey(id), invalidParameterSummary,
+				err.Error())
+		}
+	}
+}
+
+func (r *ClusterAddonResource) Create(ctx context.Context, req resource.CreateRequest,
+	resp *resource.CreateResponse) {
+	plan := &ClusterAddonState{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterId := plan.Cluster.ValueString()
+	addonId := plan.Addon.ValueString()
+
+	// Wait till the cluster is ready:
+	waitTimeoutInMinutes := int64(60)
+	if _, err := r.clusterWait.WaitForClusterToBeReady(ctx, clusterId, waitTimeoutInMinutes); err != nil {
+		resp.Diagnostics.AddError(
+			"Cluster is not ready",
+			fmt.Sprintf("Cluster with id '%s' is not in the ready state: %v", clusterId, err),
+		)
+		return
+	}
+
+	parameters, err := buildParameters(ctx, plan.Parameters)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("parameters"), failedToCreateSummary, err.Error())
+		return
+	}
+	object, err := cmv1.NewAddOnInstallation().
+		Addon(cmv1.NewAddOn().ID(addonId)).
+		Parameters(parameters).
+		Build()
+	if err != nil {
+		resp.Diagnostics.AddError(failedToCreateSummary,
+			fmt.Sprintf("Failed to build add-on '%s' installation for cluster '%s': %v",
+				addonId, clusterId, err))
+		return
+	}
+
+	// Serialize the changes sent to the cluster, but not the wait for the add-on:
+	common.ClusterMutexKV.Lock(clusterId)
+	addResp, err := r.collection.Cluster(clusterId).Addons().Add().Body(object).SendContext(ctx)
+	common.ClusterMutexKV.Unlock(clusterId)
+	if err != nil {
+		resp.Diagnostics.AddError(failedToCreateSummary,
+			fmt.Sprintf("Failed to install add-on '%s' on cluster '%s': %v",
+				addonId, clusterId, common.HandleErr(addResp.Error(), err)))
+		return
+	}
+	installation := addResp.Body()
+
+	// Save the state before waiting, so that the add-on isn't lost if waiting fails:
+	populateState(installation, plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if common.BoolWithFalseDefault(plan.WaitForReady) {
+		installation, err = r.waitForReady(ctx, clusterId, installation.ID(), plan)
+		if err != nil {
+			resp.Diagnostics.AddError(failedToCreateSummary,
+				fmt.Sprintf("Add-on '%s' on cluster '%s' isn't ready: %v", addonId, clusterId, err))
+			return
+		}
+		populateState(installation, plan)
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	}
+}
+
+func (r *ClusterAddonResource) Read(ctx context.Context, req resource.ReadRequest,
+	resp *resource.ReadResponse) {
+	state := &ClusterAddonState{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterId := state.Cluster.ValueString()
+	getResp, err := r.collection.Cluster(clusterId).Addons().
+		Addoninstallation(state.ID.ValueString()).Get().SendContext(ctx)
+	if getResp != nil && getResp.Status() == http.StatusNotFound {
+		tflog.Warn(ctx, fmt.Sprintf("add-on (%s) of cluster (%s) not found, removing from state",
+			state.ID.ValueString(), clusterId))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(failedToReadSummary,
+			fmt.Sprintf("Cannot read add-on '%s' for cluster '%s': %v",
+				state.ID.ValueString(), clusterId, common.HandleErr(getResp.Error(), err)))
+		return
+	}
+
+	imported := state.Addon.IsNull()
+	populateState(getResp.Body(), state)
+	populateParameters(getResp.Body(), state, imported)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *ClusterAddonResource) Update(ctx context.Context, req resource.UpdateRequest,
+	resp *resource.UpdateResponse) {
+	state := &ClusterAddonState{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan := &ClusterAddonState{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterId := state.Cluster.ValueString()
+	addonId := state.ID.ValueString()
+
+	if _, should := common.ShouldPatchMap(state.Parameters, plan.Parameters); !should {
+		// Only the attributes that control the waiting changed:
+		plan.State = state.State
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
+
+	parameters, err := buildParameters(ctx, plan.Parameters)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("parameters"), failedToUpdateSummary, err.Error())
+		return
+	}
+	patch, err := cmv1.NewAddOnInstallation().
+		Parameters(parameters).
+		Build()
+	if err != nil {
+		resp.Diagnostics.AddError(failedToUpdateSummary,
+			fmt.Sprintf("Failed to build add-on '%s' update for cluster '%s': %v", addonId, clusterId, err))
+		return
+	}
+
+	// Serialize the changes sent to the cluster, but not the wait for the add-on:
+	common.ClusterMutexKV.Lock(clusterId)
+	updateResp, err := r.collection.Cluster(clusterId).Addons().Addoninstallation(addonId).Update().
+		Body(patch).SendContext(ctx)
+	common.ClusterMutexKV.Unlock(clusterId)
+	if err != nil {
+		resp.Diagnostics.AddError(failedToUpdateSummary,
+			fmt.Sprintf("Failed to update add-on '%s' on cluster '%s': %v",
+				addonId, clusterId, common.HandleErr(updateResp.Error(), err)))
+		return
+	}
+	installation := updateResp.Body()
+
+	populateState(installation, plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if common.BoolWithFalseDefault(plan.WaitForReady) {
+		installation, err = r.waitForReady(ctx, clusterId, addonId, plan)
+		if err != nil {
+			resp.Diagnostics.AddError(failedToUpdateSummary,
+				fmt.Sprintf("Add-on '%s' on cluster '%s' isn't ready: %v", addonId, clusterId, err))
+			return
+		}
+		populateState(installation, plan)
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	}
+}
+
+func (r *ClusterAddonResource) Delete(ctx context.Context, req resource.DeleteRequest,
+	resp *resource.DeleteResponse) {
+	state := &ClusterAddonState{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterId := state.Cluster.ValueString()
+	addonId := state.ID.ValueString()
+
+	// Serialize the changes sent to the cluster, but not the wait for the removal:
+	client := r.collection.Cluster(clusterId).Addons().Addoninstallation(addonId)
+	common.ClusterMutexKV.Lock(clusterId)
+	deleteResp, err := client.Delete().SendContext(ctx)
+	common.ClusterMutexKV.Unlock(clusterId)
+	if err != nil && (deleteResp == nil || deleteResp.Status() != http.StatusNotFound) {
+		resp.Diagnostics.AddError(failedToDeleteSummary,
+			fmt.Sprintf("Failed to uninstall add-on '%s' from cluster '%s': %v",
+				addonId, clusterId, common.HandleErr(deleteResp.Error(), err)))
+		return
+	}
+
+	if err == nil && common.BoolWithFalseDefault(state.WaitForReady) {
+		if err := r.waitForRemoval(ctx, client, state); err != nil {
+			resp.Diagnostics.AddError(failedToDeleteSummary,
+				fmt.Sprintf("Add-on '%s' wasn't removed from cluster '%s': %v", addonId, clusterId, err))
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState expects the identifier of the cluster and the identifier of the add-on separated by a
+// comma. All the parameters of the installed add-on are imported.
+func (r *ClusterAddonResource) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+	fields := strings.Split(req.ID, ",")
+	if len(fields) != 2 || fields[0] == "" || fields[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import identifier",
+			"Add-on to import should be specified as <cluster_id>,<addon_id>",
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster"), fields[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), fields[1])...)
+}
+
+// waitForReady polls the add-on installation till it is either ready or failed.
+func (r *ClusterAddonResource) waitForReady(ctx context.Context, clusterId, addonId string,
+	state *ClusterAddonState) (*cmv1.AddOnInstallation, error) {
+	timeout, err := common.ValidateTimeout(common.OptionalInt64(state.MaxWaitTimeoutInMinutes),
+		defaultWaitTimeoutInMinutes)
+	if err != nil {
+		return nil, err
+	}
+	pollCtx, cancel := context.WithTimeout(ctx, time.Duration(*timeout)*time.Minute)
+	defer cancel()
+	var installation *cmv1.AddOnInstallation
+	_, err = r.collection.Cluster(clusterId).Addons().Addoninstallation(addonId).Poll().
+		Interval(waitPollInterval).
+		Predicate(func(getResp *cmv1.AddOnInstallationGetResponse) bool {
+			installation = getResp.Body()
+			tflog.Debug(ctx, "polled add-on installation state", map[string]interface{}{
+				"state": installation.State(),
+			})
+			switch installation.State() {
+			case cmv1.AddOnInstallationStateReady,
+				cmv1.AddOnInstallationStateFailed:
+				return true
+			}
+			return false
+		}).
+		StartContext(pollCtx)
+	if err != nil {
+		return nil, err
+	}
+	if installation.State() != cmv1.AddOnInstallationStateReady {
+		return nil, fmt.Errorf("state is '%s': %s", installation.State(), installation.StateDescription())
+	}
+	return installation, nil
+}
+
+// waitForRemoval polls the add-on installation till it no longer exists.
+func (r *ClusterAddonResource) waitForRemoval(ctx context.Context, client *cmv1.AddOnInstallationClient,
+	state *ClusterAddonState) error {
+	timeout, err := common.ValidateTimeout(common.OptionalInt64(state.MaxWaitTimeoutInMinutes),
+		defaultWaitTimeoutInMinutes)
+	if err != nil {
+		return err
+	}
+	pollCtx, cancel := context.WithTimeout(ctx, time.Duration(*timeout)*time.Minute)
+	defer cancel()
+	_, err = client.Poll().
+		Interval(waitPollInterval).
+		Status(http.StatusNotFound).
+		StartContext(pollCtx)
+	sdkErr, ok := err.(*ocm_errors.Error)
+	if ok && sdkErr.Status() == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+func buildParameters(ctx context.Context, values types.Map) (*cmv1.AddOnInstallationParameterListBuilder,
+	error) {
+	parameters, err := common.OptionalMap(ctx, values)
+	if err != nil {
+		return nil, err
+	}
+	items := []*cmv1.AddOnInstallationParameterBuilder{}
+	for id, value := range parameters {
+		items = append(items, cmv1.NewAddOnInstallationParameter().ID(id).Value(value))
+	}
+	return cmv1.NewAddOnInstallationParameterList().Items(items...), nil
+}
+
+func populateState(installation *cmv1.AddOnInstallation, state *ClusterAddonState) {
+	state.ID = types.StringValue(installation.ID())
+	state.Addon = types.StringValue(installation.Addon().ID())
+	state.State = types.StringValue(string(installation.State()))
+	state.Version = common.EmptiableStringToStringType(installation.AddonVersion().ID())
+}
+
+// populateParameters updates the parameters of the state with the installed values. Only the
+// parameters that are configured are tracked, as OCM also returns the parameters that use the
+// default value. When the add-on has been imported all the parameters are tracked.
+func populateParameters(installation *cmv1.AddOnInstallation, state *ClusterAddonState, imported bool) {
+	installed := map[string]string{}
+	installation.Parameters().Each(func(parameter *cmv1.AddOnInstallationParameter) bool {
+		installed[parameter.ID()] = parameter.Value()
+		return true
+	})
+	tracked := map[string]string{}
+	switch {
+	case imported:
+		if len(installed) == 0 {
+			return
+		}
+		tracked = installed
+	case state.Parameters.IsNull():
+		return
+	default:
+		for id := range state.Parameters.Elements() {
+			if value, ok := installed[id]; ok {
+				tracked[id] = value
+			}
+		}
+	}
+	parameters, _ := common.ConvertStringMapToMapType(tracked)
+	state.Parameters = parameters
+}
@@ -0,0 +1,62 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusteraddon
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type ClusterAddonState struct {
+	ID                      types.String `tfsdk:"id"`
+	Cluster                 types.String `tfsdk:"cluster"`
+	Addon                   types.String `tfsdk:"addon"`
+	Parameters              types.Map    `tfsdk:"parameters"`
+	WaitForReady            types.Bool   `tfsdk:"wait_for_ready"`
+	MaxWaitTimeoutInMinutes types.Int64  `tfsdk:"max_wait_timeout_in_minutes"`
+	State                   types.String `tfsdk:"state"`
+	Version                 types.String `tfsdk:"version"`
+}
+
+type AddonsState struct {
+	Search types.String  `tfsdk:"search"`
+	Order  types.String  `tfsdk:"order"`
+	Item   *AddonState   `tfsdk:"item"`
+	Items  []*AddonState `tfsdk:"items"`
+}
+
+type AddonState struct {
+	ID          types.String           `tfsdk:"id"`
+	Name        types.String           `tfsdk:"name"`
+	Description types.String           `tfsdk:"description"`
+	Version     types.String           `tfsdk:"version"`
+	Parameters  []*AddonParameterState `tfsdk:"parameters"`
+}
+
+type AddonParameterState struct {
+	ID           types.String                 `tfsdk:"id"`
+	Name         types.String                 `tfsdk:"name"`
+	Description  types.String                 `tfsdk:"description"`
+	ValueType    types.String                 `tfsdk:"value_type"`
+	Required     types.Bool                   `tfsdk:"required"`
+	Editable     types.Bool                   `tfsdk:"editable"`
+	DefaultValue types.String                 `tfsdk:"default_value"`
+	Validation   types.String                 `tfsdk:"validation"`
+	Options      []*AddonParameterOptionState `tfsdk:"options"`
+}
+
+type AddonParameterOptionState struct {
+	Name  types.String `tfsdk:"name"`
+	Value types.String `tfsdk:"value"`
+}
@@ -0,0 +1,146 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusteraddon
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// Value types of add-on parameters that are checked before sending the parameters to OCM. Other
+// types, like 'resource', are only checked by OCM.
+const (
+	valueTypeBoolean = "boolean"
+	valueTypeNumber  = "number"
+	valueTypeCIDR    = "cidr"
+)
+
+// ValidateParameters checks the given parameter values against the parameter definitions of the
+// add-on. The previous values are the ones currently installed, and are used to reject changes to
+// parameters that aren't editable; they are nil when the add-on is being installed. It returns one
+// error per invalid parameter, indexed by the parameter identifier, and the errors that don't
+// relate to a particular parameter indexed by the empty string.
+func ValidateParameters(addon *cmv1.AddOn, values, previous map[string]string) map[string]error {
+	errs := map[string]error{}
+	definitions := map[string]*cmv1.AddOnParameter{}
+	addon.Parameters().Each(func(definition *cmv1.AddOnParameter) bool {
+		if definition.Enabled() {
+			definitions[definition.ID()] = definition
+		}
+		return true
+	})
+
+	for id, value := range values {
+		definition, ok := definitions[id]
+		if !ok {
+			errs[id] = fmt.Errorf("add-on '%s' doesn't have a parameter named '%s', valid parameters "+
+				"are %v", addon.ID(), id, sortedKeys(definitions))
+			continue
+		}
+		if err := validateValue(definition, value); err != nil {
+			errs[id] = err
+			continue
+		}
+		if previous != nil && !definition.Editable() {
+			// Parameters that weren't set when the add-on was installed have the default value:
+			previousValue, ok := previous[id]
+			if !ok {
+				previousValue = definition.DefaultValue()
+			}
+			if previousValue != value {
+				errs[id] = fmt.Errorf("parameter '%s' of add-on '%s' can't be changed after installation",
+					id, addon.ID())
+			}
+		}
+	}
+
+	if previous == nil {
+		missing := []string{}
+		for id, definition := range definitions {
+			if _, ok := values[id]; !ok && definition.Required() && definition.DefaultValue() == "" {
+				missing = append(missing, id)
+			}
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			errs[""] = fmt.Errorf("add-on '%s' requires parameters %v", addon.ID(), missing)
+		}
+	}
+
+	return errs
+}
+
+func validateValue(definition *cmv1.AddOnParameter, value string) error {
+	switch definition.ValueType() {
+	case valueTypeBoolean:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("value '%s' of parameter '%s' isn't a valid boolean", value, definition.ID())
+		}
+	case valueTypeNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("value '%s' of parameter '%s' isn't a valid number", value, definition.ID())
+		}
+	case valueTypeCIDR:
+		if _, _, err := net.ParseCIDR(value); err != nil {
+			return fmt.Errorf("value '%s' of parameter '%s' isn't a valid CIDR", value, definition.ID())
+		}
+	}
+
+	if options := definition.Options(); len(options) > 0 {
+		valid := []string{}
+		for _, option := range options {
+			if option.Value() == value {
+				return nil
+			}
+			valid = append(valid, option.Value())
+		}
+		return fmt.Errorf("value '%s' of parameter '%s' isn't one of the valid options %v", value,
+			definition.ID(), valid)
+	}
+
+	if validation := definition.Validation(); validation != "" {
+		re, err := regexp.Compile(validation)
+		if err != nil {
+			// Some validations use regular expression syntax that isn't supported by Go, those are
+			// left to OCM:
+			return nil
+		}
+		if !re.MatchString(value) {
+			message := definition.ValidationErrMsg()
+			if message == "" {
+				message = fmt.Sprintf("it doesn't match '%s'", validation)
+			}
+			return fmt.Errorf("value '%s' of parameter '%s' isn't valid: %s", value, definition.ID(), message)
+		}
+	}
+
+	return nil
+}
+
+func sortedKeys(definitions map[string]*cmv1.AddOnParameter) []string {
+	keys := make([]string, 0, len(definitions))
+	for key := range definitions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
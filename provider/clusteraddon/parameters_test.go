@@ -0,0 +1,109 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusteraddon
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("ValidateParameters", func() {
+	addon, err := cmv1.NewAddOn().
+		ID("my-addon").
+		Parameters(cmv1.NewAddOnParameterList().Items(
+			cmv1.NewAddOnParameter().ID("size").ValueType("number").Required(true).Enabled(true).
+				Editable(true),
+			cmv1.NewAddOnParameter().ID("debug").ValueType("boolean").DefaultValue("false").Enabled(true).
+				Editable(true),
+			cmv1.NewAddOnParameter().ID("cidr").ValueType("cidr").Enabled(true),
+			cmv1.NewAddOnParameter().ID("tier").ValueType("string").Enabled(true).Editable(true).
+				Options(
+					cmv1.NewAddOnParameterOption().Name("Basic").Value("basic"),
+					cmv1.NewAddOnParameterOption().Name("Premium").Value("premium"),
+				),
+			cmv1.NewAddOnParameter().ID("email").ValueType("string").Enabled(true).Editable(true).
+				Validation(`^\S+@\S+$`).ValidationErrMsg("must be an email address"),
+			cmv1.NewAddOnParameter().ID("legacy").ValueType("string").Enabled(false),
+		)).
+		Build()
+	Expect(err).ToNot(HaveOccurred())
+
+	It("accepts valid values", func() {
+		errs := ValidateParameters(addon, map[string]string{
+			"size":  "3",
+			"debug": "true",
+			"cidr":  "10.0.0.0/16",
+			"tier":  "premium",
+			"email": "admin@example.com",
+		}, nil)
+		Expect(errs).To(BeEmpty())
+	})
+
+	It("rejects unknown and disabled parameters", func() {
+		errs := ValidateParameters(addon, map[string]string{
+			"size":   "3",
+			"junk":   "1",
+			"legacy": "1",
+		}, nil)
+		Expect(errs).To(HaveLen(2))
+		Expect(errs["junk"]).To(MatchError(ContainSubstring("doesn't have a parameter named 'junk'")))
+		Expect(errs["legacy"]).To(HaveOccurred())
+	})
+
+	It("rejects values of the wrong type", func() {
+		errs := ValidateParameters(addon, map[string]string{
+			"size":  "three",
+			"debug": "yes",
+			"cidr":  "10.0.0.0",
+		}, nil)
+		Expect(errs["size"]).To(MatchError(ContainSubstring("isn't a valid number")))
+		Expect(errs["debug"]).To(MatchError(ContainSubstring("isn't a valid boolean")))
+		Expect(errs["cidr"]).To(MatchError(ContainSubstring("isn't a valid CIDR")))
+	})
+
+	It("rejects values that aren't valid options or don't match the validation", func() {
+		errs := ValidateParameters(addon, map[string]string{
+			"size":  "3",
+			"tier":  "gold",
+			"email": "admin",
+		}, nil)
+		Expect(errs["tier"]).To(MatchError(ContainSubstring("isn't one of the valid options [basic premium]")))
+		Expect(errs["email"]).To(MatchError(ContainSubstring("must be an email address")))
+	})
+
+	It("requires parameters without default values on installation", func() {
+		errs := ValidateParameters(addon, map[string]string{}, nil)
+		Expect(errs[""]).To(MatchError(ContainSubstring("requires parameters [size]")))
+	})
+
+	It("rejects changes to parameters that aren't editable", func() {
+		errs := ValidateParameters(addon,
+			map[string]string{"size": "5", "cidr": "10.1.0.0/16"},
+			map[string]string{"size": "3", "cidr": "10.0.0.0/16"},
+		)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs["cidr"]).To(MatchError(ContainSubstring("can't be changed after installation")))
+	})
+	It("compares parameters that weren't set on installation with the default value", func() {
+		errs := ValidateParameters(addon,
+			map[string]string{"size": "3", "cidr": "10.1.0.0/16"},
+			map[string]string{"size": "3"},
+		)
+		Expect(errs["cidr"]).To(MatchError(ContainSubstring("can't be changed after installation")))
+	})
+})
@@ -0,0 +1,177 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusteradmincredentials
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+)
+
+type ClusterAdminCredentialsEphemeralResource struct {
+	clusterCollection *cmv1.ClustersClient
+	clusterClient     common.ClusterClient
+}
+
+var _ ephemeral.EphemeralResource = &ClusterAdminCredentialsEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &ClusterAdminCredentialsEphemeralResource{}
+
+func New() ephemeral.EphemeralResource {
+	return &ClusterAdminCredentialsEphemeralResource{}
+}
+
+func (r *ClusterAdminCredentialsEphemeralResource) Metadata(ctx context.Context,
+	req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_admin_credentials"
+}
+
+func (r *ClusterAdminCredentialsEphemeralResource) Schema(ctx context.Context,
+	req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Admin kubeconfig of a cluster. The credentials aren't saved to the plan or to " +
+			"the state, so they can be used to configure the `kubernetes` and `helm` providers without " +
+			"persisting them.",
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				Description: "Identifier of the cluster.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`.*\S.*`), "cluster ID may not be empty/blank string"),
+				},
+			},
+			"kubeconfig": schema.StringAttribute{
+				Description: "Admin kubeconfig of the cluster.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"host": schema.StringAttribute{
+				Description: "URL of the API server of the cluster.",
+				Computed:    true,
+			},
+			"cluster_ca_certificate": schema.StringAttribute{
+				Description: "PEM encoded certificate authority of the API server, empty if the " +
+					"kubeconfig doesn't contain it.",
+				Computed: true,
+			},
+			"client_certificate": schema.StringAttribute{
+				Description: "PEM encoded client certificate, empty if the kubeconfig doesn't contain it.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"client_key": schema.StringAttribute{
+				Description: "PEM encoded client key, empty if the kubeconfig doesn't contain it.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"token": schema.StringAttribute{
+				Description: "Bearer token, empty if the kubeconfig doesn't contain it.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func (r *ClusterAdminCredentialsEphemeralResource) Configure(ctx context.Context,
+	req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	connection, ok := req.ProviderData.(*sdk.Connection)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *sdk.Connection, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.clusterCollection = connection.ClustersMgmt().V1().Clusters()
+	r.clusterClient = common.NewClusterClient(r.clusterCollection)
+}
+
+func (r *ClusterAdminCredentialsEphemeralResource) Open(ctx context.Context,
+	req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	state := &ClusterAdminCredentialsState{}
+	resp.Diagnostics.Append(req.Config.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	clusterId := state.Cluster.ValueString()
+
+	cluster, err := r.clusterClient.FetchCluster(ctx, clusterId)
+	if err != nil {
+		resp.Diagnostics.AddError("Can't get cluster admin credentials", err.Error())
+		return
+	}
+	if cluster.State() != cmv1.ClusterStateReady {
+		resp.Diagnostics.AddError(
+			"Can't get cluster admin credentials",
+			fmt.Sprintf("Cluster '%s' is in state '%s', the credentials are only available when it is ready",
+				clusterId, cluster.State()),
+		)
+		return
+	}
+
+	credentialsResp, err := r.clusterCollection.Cluster(clusterId).Credentials().Get().SendContext(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Can't get cluster admin credentials",
+			fmt.Sprintf("Can't get the credentials of cluster '%s': %v", clusterId, err),
+		)
+		return
+	}
+	kubeconfigText := credentialsResp.Body().Kubeconfig()
+	if kubeconfigText == "" {
+		resp.Diagnostics.AddError(
+			"Can't get cluster admin credentials",
+			fmt.Sprintf("Cluster '%s' doesn't have an admin kubeconfig", clusterId),
+		)
+		return
+	}
+	kubeconfig, err := ParseKubeconfig(kubeconfigText)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Can't get cluster admin credentials",
+			fmt.Sprintf("Can't parse the admin kubeconfig of cluster '%s': %v", clusterId, err),
+		)
+		return
+	}
+	if kubeconfig.Host == "" {
+		kubeconfig.Host = cluster.API().URL()
+	}
+
+	state.Kubeconfig = types.StringValue(kubeconfigText)
+	state.Host = types.StringValue(kubeconfig.Host)
+	state.ClusterCACertificate = types.StringValue(kubeconfig.ClusterCACertificate)
+	state.ClientCertificate = types.StringValue(kubeconfig.ClientCertificate)
+	state.ClientKey = types.StringValue(kubeconfig.ClientKey)
+	state.Token = types.StringValue(kubeconfig.Token)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, state)...)
+}
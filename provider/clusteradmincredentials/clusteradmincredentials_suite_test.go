@@ -0,0 +1,13 @@
+package clusteradmincredentials
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestClusterAdminCredentials(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cluster Admin Credentials Suite")
+}
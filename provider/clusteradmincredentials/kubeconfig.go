@@ -0,0 +1,143 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusteradmincredentials
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Kubeconfig contains the details of the current context of a kubeconfig file, in the format
+// expected by the `kubernetes` and `helm` providers. The certificates and keys are in PEM format.
+type Kubeconfig struct {
+	Host                 string
+	ClusterCACertificate string
+	ClientCertificate    string
+	ClientKey            string
+	Token                string
+}
+
+// kubeconfigFile contains the parts of the kubeconfig file that are needed to extract the
+// details of the current context.
+type kubeconfigFile struct {
+	CurrentContext string `json:"current-context"`
+	Clusters       []struct {
+		Name    string `json:"name"`
+		Cluster struct {
+			Server                   string `json:"server"`
+			CertificateAuthorityData string `json:"certificate-authority-data"`
+		} `json:"cluster"`
+	} `json:"clusters"`
+	Contexts []struct {
+		Name    string `json:"name"`
+		Context struct {
+			Cluster string `json:"cluster"`
+			User    string `json:"user"`
+		} `json:"context"`
+	} `json:"contexts"`
+	Users []struct {
+		Name string `json:"name"`
+		User struct {
+			ClientCertificateData string `json:"client-certificate-data"`
+			ClientKeyData         string `json:"client-key-data"`
+			Token                 string `json:"token"`
+		} `json:"user"`
+	} `json:"users"`
+}
+
+// ParseKubeconfig extracts the details of the current context of the given kubeconfig. If there
+// is no current context the first one is used.
+func ParseKubeconfig(text string) (*Kubeconfig, error) {
+	file := &kubeconfigFile{}
+	err := yaml.Unmarshal([]byte(text), file)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse kubeconfig: %v", err)
+	}
+	if len(file.Contexts) == 0 {
+		return nil, fmt.Errorf("kubeconfig doesn't contain any context")
+	}
+
+	contextIndex := 0
+	if file.CurrentContext != "" {
+		contextIndex = -1
+		for i, item := range file.Contexts {
+			if item.Name == file.CurrentContext {
+				contextIndex = i
+				break
+			}
+		}
+		if contextIndex == -1 {
+			return nil, fmt.Errorf("kubeconfig doesn't contain the current context '%s'", file.CurrentContext)
+		}
+	}
+	context := file.Contexts[contextIndex].Context
+
+	result := &Kubeconfig{}
+	found := false
+	for _, item := range file.Clusters {
+		if item.Name != context.Cluster {
+			continue
+		}
+		found = true
+		result.Host = item.Cluster.Server
+		result.ClusterCACertificate, err = decodeData(item.Cluster.CertificateAuthorityData)
+		if err != nil {
+			return nil, fmt.Errorf("can't decode certificate authority of cluster '%s': %v", item.Name, err)
+		}
+		break
+	}
+	if !found {
+		return nil, fmt.Errorf("kubeconfig doesn't contain the cluster '%s'", context.Cluster)
+	}
+
+	found = false
+	for _, item := range file.Users {
+		if item.Name != context.User {
+			continue
+		}
+		found = true
+		result.ClientCertificate, err = decodeData(item.User.ClientCertificateData)
+		if err != nil {
+			return nil, fmt.Errorf("can't decode client certificate of user '%s': %v", item.Name, err)
+		}
+		result.ClientKey, err = decodeData(item.User.ClientKeyData)
+		if err != nil {
+			return nil, fmt.Errorf("can't decode client key of user '%s': %v", item.Name, err)
+		}
+		result.Token = item.User.Token
+		break
+	}
+	if !found {
+		return nil, fmt.Errorf("kubeconfig doesn't contain the user '%s'", context.User)
+	}
+
+	return result, nil
+}
+
+// decodeData decodes the base64 encoded data fields of the kubeconfig.
+func decodeData(data string) (string, error) {
+	if data == "" {
+		return "", nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return "", err
+	}
+	return string(decoded), nil
+}
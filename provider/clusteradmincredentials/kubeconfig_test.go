@@ -0,0 +1,98 @@
+package clusteradmincredentials
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+var _ = Describe("Kubeconfig", func() {
+	It("Should extract the details of the current context", func() {
+		kubeconfig, err := ParseKubeconfig(`
+apiVersion: v1
+kind: Config
+current-context: admin
+clusters:
+- name: other
+  cluster:
+    server: https://api.other.example.com:6443
+- name: my-cluster
+  cluster:
+    server: https://api.my-cluster.example.com:6443
+    certificate-authority-data: bXktY2E=
+contexts:
+- name: other
+  context:
+    cluster: other
+    user: other
+- name: admin
+  context:
+    cluster: my-cluster
+    user: admin
+users:
+- name: other
+  user:
+    token: other-token
+- name: admin
+  user:
+    client-certificate-data: bXktY2VydA==
+    client-key-data: bXkta2V5
+`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(kubeconfig.Host).To(Equal("https://api.my-cluster.example.com:6443"))
+		Expect(kubeconfig.ClusterCACertificate).To(Equal("my-ca"))
+		Expect(kubeconfig.ClientCertificate).To(Equal("my-cert"))
+		Expect(kubeconfig.ClientKey).To(Equal("my-key"))
+		Expect(kubeconfig.Token).To(BeEmpty())
+	})
+
+	It("Should use the first context if there is no current context", func() {
+		kubeconfig, err := ParseKubeconfig(`
+clusters:
+- name: my-cluster
+  cluster:
+    server: https://api.my-cluster.example.com:6443
+contexts:
+- name: admin
+  context:
+    cluster: my-cluster
+    user: admin
+users:
+- name: admin
+  user:
+    token: my-token
+`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(kubeconfig.Host).To(Equal("https://api.my-cluster.example.com:6443"))
+		Expect(kubeconfig.ClusterCACertificate).To(BeEmpty())
+		Expect(kubeconfig.Token).To(Equal("my-token"))
+	})
+
+	It("Should fail if the current context doesn't exist", func() {
+		_, err := ParseKubeconfig(`
+current-context: missing
+contexts:
+- name: admin
+  context:
+    cluster: my-cluster
+    user: admin
+`)
+		Expect(err).To(MatchError("kubeconfig doesn't contain the current context 'missing'"))
+	})
+
+	It("Should fail if the data isn't base64 encoded", func() {
+		_, err := ParseKubeconfig(`
+clusters:
+- name: my-cluster
+  cluster:
+    server: https://api.my-cluster.example.com:6443
+    certificate-authority-data: "not base64"
+contexts:
+- name: admin
+  context:
+    cluster: my-cluster
+    user: admin
+`)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("can't decode certificate authority of cluster 'my-cluster'"))
+	})
+})
@@ -0,0 +1,151 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package classic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/cluster"
+	rosa "github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/common"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+)
+
+// legacyClusterTypeName is the type of the legacy cluster resource whose state can be moved to this
+// resource with a `moved` block.
+const legacyClusterTypeName = "rhcs_cluster"
+
+func (r *ClusterRosaClassicResource) MoveState(ctx context.Context) []resource.StateMover {
+	sourceSchema := &resource.SchemaResponse{}
+	cluster.New().Schema(ctx, resource.SchemaRequest{}, sourceSchema)
+	return []resource.StateMover{
+		{
+			SourceSchema: &sourceSchema.Schema,
+			StateMover:   moveLegacyClusterState,
+		},
+	}
+}
+
+// moveLegacyClusterState translates the state of the legacy `rhcs_cluster` resource into the state
+// of this resource. Only the attributes that are needed to find the cluster are required, the rest
+// are populated by the read that Terraform runs after moving the state.
+func moveLegacyClusterState(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if req.SourceTypeName != legacyClusterTypeName || !strings.HasSuffix(req.SourceProviderAddress, "/rhcs") {
+		return
+	}
+	if req.SourceState == nil {
+		resp.Diagnostics.AddError(
+			"Can't move cluster state",
+			fmt.Sprintf("The state of the '%s' resource doesn't match its schema", legacyClusterTypeName),
+		)
+		return
+	}
+	source := &cluster.ClusterState{}
+	resp.Diagnostics.Append(req.SourceState.Get(ctx, source)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only ROSA clusters can be managed by this resource:
+	if common.HasValue(source.Product) && source.Product.ValueString() != "rosa" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("product"),
+			"Can't move cluster state",
+			fmt.Sprintf("Cluster with identifier '%s' has product '%s', but only 'rosa' clusters can be "+
+				"moved to the 'rhcs_cluster_rosa_classic' resource", source.ID.ValueString(),
+				source.Product.ValueString()),
+		)
+	}
+	if common.HasValue(source.CloudProvider) && source.CloudProvider.ValueString() != "aws" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("cloud_provider"),
+			"Can't move cluster state",
+			fmt.Sprintf("Cluster with identifier '%s' has cloud provider '%s', but only 'aws' clusters "+
+				"can be moved to the 'rhcs_cluster_rosa_classic' resource", source.ID.ValueString(),
+				source.CloudProvider.ValueString()),
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The AWS credentials aren't used by this resource, as it uses the STS roles instead:
+	for _, name := range []string{"aws_access_key_id", "aws_secret_access_key"} {
+		var value types.String
+		resp.Diagnostics.Append(req.SourceState.GetAttribute(ctx, path.Root(name), &value)...)
+		if common.HasValue(value) {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root(name),
+				"Attribute can't be moved",
+				fmt.Sprintf("Attribute '%s' isn't supported by the 'rhcs_cluster_rosa_classic' "+
+					"resource and it will be discarded", name),
+			)
+		}
+	}
+
+	version := source.Version
+	if common.HasValue(version) {
+		version = types.StringValue(strings.TrimPrefix(version.ValueString(), rosa.VersionPrefix))
+	}
+
+	// Waiting for the deletion of the cluster was controlled by the same `wait` attribute that
+	// controlled waiting for the creation:
+	wait := source.Wait.IsNull() || source.Wait.IsUnknown() || source.Wait.ValueBool()
+
+	attributes := map[string]attr.Value{
+		"id":                         source.ID,
+		"name":                       source.Name,
+		"cloud_region":               source.CloudRegion,
+		"aws_account_id":             source.AWSAccountID,
+		"aws_private_link":           source.AWSPrivateLink,
+		"ccs_enabled":                source.CCSEnabled,
+		"compute_machine_type":       source.ComputeMachineType,
+		"replicas":                   source.ComputeNodes,
+		"host_prefix":                source.HostPrefix,
+		"machine_cidr":               source.MachineCIDR,
+		"service_cidr":               source.ServiceCIDR,
+		"pod_cidr":                   source.PodCIDR,
+		"multi_az":                   source.MultiAZ,
+		"domain_prefix":              source.DomainPrefix,
+		"api_url":                    source.APIURL,
+		"console_url":                source.ConsoleURL,
+		"state":                      source.State,
+		"version":                    version,
+		"wait_for_create_complete":   types.BoolValue(wait),
+		"disable_waiting_in_destroy": types.BoolValue(!wait),
+		"deletion_policy":            source.DeletionPolicy,
+		"aws_subnet_ids":             source.AWSSubnetIDs,
+		"availability_zones":         source.AvailabilityZones,
+		"properties":                 source.Properties,
+		"aws_additional_compute_security_group_ids":       source.AWSAdditionalComputeSecurityGroupIds,
+		"aws_additional_infra_security_group_ids":         source.AWSAdditionalInfraSecurityGroupIds,
+		"aws_additional_control_plane_security_group_ids": source.AWSAdditionalControlPlaneSecurityGroupIds,
+	}
+	for name, value := range attributes {
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root(name), value)...)
+	}
+	if source.Proxy != nil {
+		resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("proxy"), source.Proxy)...)
+	}
+}
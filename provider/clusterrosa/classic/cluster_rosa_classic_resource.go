@@ -29,6 +29,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	semver "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -65,6 +66,7 @@ import (
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/sts"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/identityprovider"
+	"github.com/terraform-redhat/terraform-provider-rhcs/tracing"
 )
 
 const (
@@ -79,6 +81,10 @@ type ClusterRosaClassicResource struct {
 
 var _ resource.ResourceWithConfigure = &ClusterRosaClassicResource{}
 var _ resource.ResourceWithImportState = &ClusterRosaClassicResource{}
+var _ resource.ResourceWithUpgradeState = &ClusterRosaClassicResource{}
+var _ resource.ResourceWithConfigValidators = &ClusterRosaClassicResource{}
+var _ resource.ResourceWithModifyPlan = &ClusterRosaClassicResource{}
+var _ resource.ResourceWithMoveState = &ClusterRosaClassicResource{}
 
 func New() resource.Resource {
 	return &ClusterRosaClassicResource{}
@@ -90,6 +96,7 @@ func (r *ClusterRosaClassicResource) Metadata(ctx context.Context, req resource.
 
 func (r *ClusterRosaClassicResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     schemaVersion,
 		Description: "OpenShift managed cluster using rosa sts.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -365,6 +372,21 @@ func (r *ClusterRosaClassicResource) Schema(ctx context.Context, req resource.Sc
 				Description: "The currently running version of OpenShift on the cluster, for example '4.11.0'.",
 				Computed:    true,
 			},
+			"deletion_policy": schema.StringAttribute{
+				Description: common.DeletionPolicyDescription("cluster"),
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(common.DeletionPolicyDelete, common.DeletionPolicyRetain),
+				},
+			},
+			"delete_protection": schema.BoolAttribute{
+				Description: rosaTypes.DeleteProtectionDescription,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"disable_waiting_in_destroy": schema.BoolAttribute{
 				Description: "Disable addressing cluster state in the destroy resource. Default value is false, and so a `destroy` will wait for the cluster to be deleted.",
 				Optional:    true,
@@ -429,6 +451,24 @@ func (r *ClusterRosaClassicResource) Schema(ctx context.Context, req resource.Sc
 					objectplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"admin_credentials_password_wo": schema.StringAttribute{
+				Description: common.WriteOnlyDescription("Admin password that will be created with the cluster, "+
+					"instead of the password in `admin_credentials`.", "admin_credentials_password_wo_version"),
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Validators: append([]validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("admin_credentials").AtName("password")),
+				}, identityprovider.HTPasswdPasswordValidators...),
+			},
+			"admin_credentials_password_wo_version": schema.Int64Attribute{
+				Description: common.WriteOnlyVersionDescription("admin_credentials_password_wo") +
+					" The password of the admin user is changed in the `htpasswd` identity provider of the cluster.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("admin_credentials_password_wo")),
+				},
+			},
 			"private_hosted_zone": schema.SingleNestedAttribute{
 				Description: "Used in a shared VPC topology. HostedZone attributes. " + common.ValueCannotBeChangedStringDescription,
 				Attributes: map[string]schema.Attribute{
@@ -461,10 +501,31 @@ func (r *ClusterRosaClassicResource) Schema(ctx context.Context, req resource.Sc
 				Description: "This value sets the maximum duration in minutes to wait for the cluster to be in a ready state.",
 				Optional:    true,
 			},
+			"wait_for_upgrade_complete": schema.BoolAttribute{
+				Description: "Wait until the upgrade to the version given in the 'version' attribute is completed " +
+					"when that version changes, instead of only scheduling it. The apply fails if the upgrade fails. " +
+					"The waiter has a timeout of 120 minutes, with the default value set to false",
+				Optional: true,
+			},
+			"max_upgrade_wait_timeout_in_minutes": schema.Int64Attribute{
+				Description: "This value sets the maximum duration in minutes to wait for the upgrade of the cluster to complete.",
+				Optional:    true,
+			},
 		},
 	}
 }
 
+func (r *ClusterRosaClassicResource) ConfigValidators(context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		rosa.NetworkValidator(rosaTypes.Classic),
+	}
+}
+
+func (r *ClusterRosaClassicResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse) {
+	rosaTypes.WarnIfDeleteProtected(ctx, req, resp)
+}
+
 func (r *ClusterRosaClassicResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -483,6 +544,7 @@ func (r *ClusterRosaClassicResource) Configure(ctx context.Context, req resource
 	r.ClusterCollection = connection.ClustersMgmt().V1().Clusters()
 	r.VersionCollection = connection.ClustersMgmt().V1().Versions()
 	r.ClusterWait = common.NewClusterWait(r.ClusterCollection, connection)
+	r.Defaults = common.GetProviderDefaults(connection)
 }
 
 const (
@@ -660,7 +722,7 @@ func createClassicClusterObject(ctx context.Context,
 		isPrivateLink, awsAccountID, nil, stsBuilder, awsSubnetIDs,
 		privateHostedZoneID, privateHostedZoneRoleARN, nil, nil,
 		awsAdditionalComputeSecurityGroupIds, awsAdditionalInfraSecurityGroupIds,
-		awsAdditionalControlPlaneSecurityGroupIds, nil); err != nil {
+		awsAdditionalControlPlaneSecurityGroupIds, nil, nil); err != nil {
 		return nil, err
 	}
 
@@ -725,7 +787,11 @@ func createClassicClusterObject(ctx context.Context,
 	}
 
 	username, password := rosaTypes.ExpandAdminCredentials(ctx, state.AdminCredentials, diags)
-	if common.BoolWithFalseDefault(state.CreateAdminUser) || common.HasValue(state.AdminCredentials) {
+	writeOnlyPassword := common.HasValue(state.AdminCredentialsPasswordWO)
+	if writeOnlyPassword {
+		password = state.AdminCredentialsPasswordWO.ValueString()
+	}
+	if common.BoolWithFalseDefault(state.CreateAdminUser) || common.HasValue(state.AdminCredentials) || writeOnlyPassword {
 		if username == "" {
 			username = commonutils.ClusterAdminUsername
 		}
@@ -752,7 +818,11 @@ func createClassicClusterObject(ctx context.Context,
 		htPasswdIDP := cmv1.NewHTPasswdIdentityProvider().Users(htpassUserList)
 		builder.Htpasswd(htPasswdIDP)
 	}
-	state.AdminCredentials = rosaTypes.FlattenAdminCredentials(username, password)
+	if writeOnlyPassword {
+		state.AdminCredentials = rosaTypes.FlattenAdminUsername(username)
+	} else {
+		state.AdminCredentials = rosaTypes.FlattenAdminCredentials(username, password)
+	}
 
 	builder, err = proxy.BuildProxy(state.Proxy, builder)
 	if err != nil {
@@ -783,17 +853,6 @@ func validateHttpTokensVersion(ctx context.Context, state *ClusterRosaClassicSta
 	return nil
 }
 
-// TODO: move to ocm commons
-func getOcmVersionMinor(ver string) string {
-	version, err := semver.NewVersion(ver)
-	if err != nil {
-		segments := strings.Split(ver, ".")
-		return fmt.Sprintf("%s.%s", segments[0], segments[1])
-	}
-	segments := version.Segments()
-	return fmt.Sprintf("%d.%d", segments[0], segments[1])
-}
-
 func (r *ClusterRosaClassicResource) Create(ctx context.Context, request resource.CreateRequest,
 	response *resource.CreateResponse) {
 	tflog.Debug(ctx, "begin create()")
@@ -805,6 +864,11 @@ func (r *ClusterRosaClassicResource) Create(ctx context.Context, request resourc
 	if response.Diagnostics.HasError() {
 		return
 	}
+	diags = request.Config.GetAttribute(ctx, path.Root("admin_credentials_password_wo"), &state.AdminCredentialsPasswordWO)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
 	summary := "Can't build cluster"
 
 	// In case version with "openshift-v" prefix was used here,
@@ -849,7 +913,22 @@ func (r *ClusterRosaClassicResource) Create(ctx context.Context, request resourc
 		return
 	}
 
+	// The default tags and properties of the provider are only sent to OCM, the state keeps the
+	// configured ones so that the defaults don't show up as differences:
+	configuredTags, configuredProperties := state.Tags, state.Properties
+	state.Tags, state.Properties, err = r.MergeProviderDefaults(ctx, state.Tags, state.Properties)
+	if err != nil {
+		response.Diagnostics.AddError(
+			summary,
+			fmt.Sprintf(
+				"Can't merge the provider default tags and properties for cluster with name '%s': %v",
+				state.Name.ValueString(), err,
+			),
+		)
+		return
+	}
 	object, err := createClassicClusterObject(ctx, state, diags)
+	state.Tags, state.Properties = configuredTags, configuredProperties
 	if err != nil {
 		response.Diagnostics.AddError(
 			summary,
@@ -875,7 +954,8 @@ func (r *ClusterRosaClassicResource) Create(ctx context.Context, request resourc
 	object = add.Body()
 
 	// Save initial state:
-	err = populateRosaClassicClusterState(ctx, object, state, common.DefaultHttpClient{})
+	deleteProtection := common.BoolWithFalseDefault(state.DeleteProtection)
+	err = r.populateState(ctx, object, state)
 	if err != nil {
 		response.Diagnostics.AddError(
 			"Can't populate cluster state",
@@ -886,6 +966,22 @@ func (r *ClusterRosaClassicResource) Create(ctx context.Context, request resourc
 		return
 	}
 
+	// The delete protection is changed with its own endpoint once the cluster exists:
+	if deleteProtection {
+		err = r.UpdateDeleteProtection(ctx, object.ID(), true)
+		if err != nil {
+			response.Diagnostics.AddError(
+				"Can't enable delete protection",
+				fmt.Sprintf("Can't enable the delete protection of cluster with identifier '%s': %v",
+					object.ID(), err),
+			)
+			diags = response.State.Set(ctx, state)
+			response.Diagnostics.Append(diags...)
+			return
+		}
+		state.DeleteProtection = types.BoolValue(true)
+	}
+
 	if common.HasValue(state.WaitForCreateComplete) && state.WaitForCreateComplete.ValueBool() {
 		timeOut := common.OptionalInt64(state.MaxClusterWaitTimeoutInMinutes)
 		timeOut, err = common.ValidateTimeout(timeOut, rosa.MaxClusterWaitTimeoutInMinutes)
@@ -910,7 +1006,7 @@ func (r *ClusterRosaClassicResource) Create(ctx context.Context, request resourc
 	}
 
 	// Save the state post wait completion:
-	err = populateRosaClassicClusterState(ctx, object, state, common.DefaultHttpClient{})
+	err = r.populateState(ctx, object, state)
 	if err != nil {
 		response.Diagnostics.AddError(
 			"Can't populate cluster state",
@@ -921,6 +1017,11 @@ func (r *ClusterRosaClassicResource) Create(ctx context.Context, request resourc
 		return
 	}
 
+	// The response of the creation request doesn't reflect the delete protection enabled later:
+	if deleteProtection {
+		state.DeleteProtection = types.BoolValue(true)
+	}
+
 	diags = response.State.Set(ctx, state)
 	response.Diagnostics.Append(diags...)
 }
@@ -959,7 +1060,7 @@ func (r *ClusterRosaClassicResource) Read(ctx context.Context, request resource.
 	object := get.Body()
 
 	// Save the state:
-	err = populateRosaClassicClusterState(ctx, object, state, common.DefaultHttpClient{})
+	err = r.populateState(ctx, object, state)
 	if err != nil {
 		response.Diagnostics.AddError(
 			"Can't populate cluster state",
@@ -1122,6 +1223,45 @@ func (r *ClusterRosaClassicResource) Update(ctx context.Context, request resourc
 		return
 	}
 
+	// Change the delete protection if needed:
+	if deleteProtection, shouldPatch := common.ShouldPatchBool(state.DeleteProtection, plan.DeleteProtection); shouldPatch {
+		err := r.UpdateDeleteProtection(ctx, state.ID.ValueString(), deleteProtection)
+		if err != nil {
+			response.Diagnostics.AddError(
+				"Can't update delete protection",
+				fmt.Sprintf("Can't update the delete protection of cluster with identifier '%s': %v",
+					state.ID.ValueString(), err),
+			)
+			return
+		}
+	}
+
+	// Change the password of the admin user if the version of the write-only password changed:
+	if !state.AdminCredentialsPasswordWOVersion.Equal(plan.AdminCredentialsPasswordWOVersion) {
+		diags = request.Config.GetAttribute(ctx, path.Root("admin_credentials_password_wo"), &plan.AdminCredentialsPasswordWO)
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+		username, _ := rosaTypes.ExpandAdminCredentials(ctx, state.AdminCredentials, diags)
+		if username == "" {
+			response.Diagnostics.AddError(
+				"Can't change admin password",
+				fmt.Sprintf("Cluster with identifier '%s' doesn't have an admin user", state.ID.ValueString()),
+			)
+			return
+		}
+		err := r.UpdateAdminPassword(ctx, state.ID.ValueString(), username, plan.AdminCredentialsPasswordWO.ValueString())
+		if err != nil {
+			response.Diagnostics.AddError(
+				"Can't change admin password",
+				fmt.Sprintf("Can't change the password of the admin user of cluster with identifier '%s': %v",
+					state.ID.ValueString(), err),
+			)
+			return
+		}
+	}
+
 	clusterBuilder := cmv1.NewCluster()
 
 	clusterBuilder, err := updateProxy(state, plan, clusterBuilder)
@@ -1163,7 +1303,7 @@ func (r *ClusterRosaClassicResource) Update(ctx context.Context, request resourc
 		clusterBuilder.DisableUserWorkloadMonitoring(plan.DisableWorkloadMonitoring.ValueBool())
 	}
 
-	patchProperties := shouldPatchProperties(state, plan)
+	patchProperties := shouldPatchProperties(state, plan, r.ExpectedDefaultProperties(rosa.OCMProperties, plan.Properties))
 	if patchProperties {
 		propertiesElements, err := rosa.ValidatePatchProperties(ctx, state.Properties, plan.Properties)
 		if err != nil {
@@ -1176,6 +1316,7 @@ func (r *ClusterRosaClassicResource) Update(ctx context.Context, request resourc
 			for k, v := range rosa.OCMProperties {
 				propertiesElements[k] = v
 			}
+			propertiesElements = common.MergeDefaults(r.Defaults.Properties, propertiesElements)
 			clusterBuilder.Properties(propertiesElements)
 		}
 	}
@@ -1209,7 +1350,7 @@ func (r *ClusterRosaClassicResource) Update(ctx context.Context, request resourc
 	object := update.Body()
 
 	// Update the state:
-	err = populateRosaClassicClusterState(ctx, object, plan, common.DefaultHttpClient{})
+	err = r.populateState(ctx, object, plan)
 	if err != nil {
 		response.Diagnostics.AddError(
 			"Can't populate cluster state",
@@ -1298,6 +1439,21 @@ func (r *ClusterRosaClassicResource) upgradeClusterIfNeeded(ctx context.Context,
 		}
 	}
 
+	// Wait for the upgrade to complete if requested
+	if !cancelingUpgradeOnly && common.BoolWithFalseDefault(plan.WaitForUpgradeComplete) {
+		timeout, err := common.ValidateTimeout(common.OptionalInt64(plan.MaxUpgradeWaitTimeoutInMinutes),
+			rosa.MaxUpgradeWaitTimeoutInMinutes)
+		if err != nil {
+			return err
+		}
+		tflog.Info(ctx, "Waiting for cluster upgrade to complete")
+		err = upgrade.WaitForUpgradeToComplete(ctx, r.ClusterCollection, state.ID.ValueString(), desiredVersion,
+			rosa.DefaultPollingIntervalInMinutes*time.Minute, time.Duration(*timeout)*time.Minute)
+		if err != nil {
+			return err
+		}
+	}
+
 	state.Version = plan.Version
 	state.UpgradeAcksFor = plan.UpgradeAcksFor
 	return nil
@@ -1359,7 +1515,7 @@ func scheduleUpgrade(ctx context.Context, client *cmv1.ClustersClient, clusterID
 			userAckRequired = true
 		}
 	}
-	targetMinorVersion := getOcmVersionMinor(desiredVersion.String())
+	targetMinorVersion := common.GetOcmVersionMinor(desiredVersion.String())
 	if userAckRequired && userAckString != targetMinorVersion { // User has not acknowledged mandatory gates, stop here.
 		return fmt.Errorf("%s\nTo acknowledge these items, please add \"upgrade_acknowledgements_for = %s\""+
 			" and re-apply the changes", description, targetMinorVersion)
@@ -1424,6 +1580,23 @@ func (r *ClusterRosaClassicResource) Delete(ctx context.Context, request resourc
 		return
 	}
 
+	// Keep the cluster in OCM if requested, only removing it from the state:
+	if common.ShouldRetain(state.DeletionPolicy) {
+		tflog.Info(ctx, fmt.Sprintf("Deletion policy is '%s', keeping cluster '%s' in OCM",
+			common.DeletionPolicyRetain, state.ID.ValueString()))
+		response.State.RemoveResource(ctx)
+		return
+	}
+
+	// Refuse to delete the cluster while it is protected:
+	if common.BoolWithFalseDefault(state.DeleteProtection) {
+		response.Diagnostics.AddError(
+			"Can't delete cluster",
+			fmt.Sprintf(rosaTypes.DeleteProtectionFormat, state.ID.ValueString()),
+		)
+		return
+	}
+
 	// Send the request to delete the cluster:
 	resource := r.ClusterCollection.Cluster(state.ID.ValueString())
 	_, err := resource.Delete().SendContext(ctx)
@@ -1449,7 +1622,10 @@ func (r *ClusterRosaClassicResource) Delete(ctx context.Context, request resourc
 				timeout = state.DestroyTimeout.ValueInt64()
 			}
 		}
-		isNotFound, err := r.retryClusterNotFoundWithTimeout(3, 1*time.Minute, ctx, timeout, resource)
+		waitCtx, span := tracing.StartSpan(ctx, "wait for cluster deletion",
+			tracing.ClusterIDKey.String(state.ID.ValueString()))
+		isNotFound, err := r.retryClusterNotFoundWithTimeout(3, 1*time.Minute, waitCtx, timeout, resource)
+		tracing.EndSpan(span, err)
 		if err != nil {
 			response.Diagnostics.AddError(
 				"Can't poll cluster state",
@@ -1477,7 +1653,16 @@ func (r *ClusterRosaClassicResource) ImportState(ctx context.Context, request re
 	response *resource.ImportStateResponse) {
 	tflog.Debug(ctx, "begin importstate()")
 
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
+	// The cluster can be specified with its identifier, its name or its external identifier:
+	clusterID, err := common.ResolveClusterID(ctx, r.ClusterCollection, request.ID)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Can't import cluster",
+			err.Error(),
+		)
+		return
+	}
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("id"), clusterID)...)
 }
 
 // populateRosaClassicClusterState copies the data from the API object to the Terraform state.
@@ -1488,6 +1673,7 @@ func populateRosaClassicClusterState(ctx context.Context, object *cmv1.Cluster,
 	state.Name = types.StringValue(object.Name())
 	state.DomainPrefix = types.StringValue(object.DomainPrefix())
 	state.CloudRegion = types.StringValue(object.Region().ID())
+	state.DeleteProtection = types.BoolValue(object.DeleteProtection().Enabled())
 	state.MultiAZ = types.BoolValue(object.MultiAZ())
 	if props, ok := object.GetProperties(); ok {
 		propertiesMap := map[string]string{}
@@ -1784,9 +1970,17 @@ func (r *ClusterRosaClassicResource) waitTillClusterIsNotFoundWithTimeout(ctx co
 	timeoutInMinutes := time.Duration(timeout) * time.Minute
 	pollCtx, cancel := context.WithTimeout(ctx, timeoutInMinutes)
 	defer cancel()
+	iterations := tracing.NewPollSpans(ctx, "poll cluster deletion")
 	_, err := resource.Poll().
 		Interval(rosa.DefaultPollingIntervalInMinutes * time.Minute).
 		Status(http.StatusNotFound).
+		Predicate(func(getClusterResponse *cmv1.ClusterGetResponse) bool {
+			iterations.Iteration(
+				tracing.StatusCodeKey.Int(getClusterResponse.Status()),
+				tracing.ClusterStateKey.String(string(getClusterResponse.Body().State())),
+			)
+			return true
+		}).
 		StartContext(pollCtx)
 	sdkErr, ok := err.(*ocm_errors.Error)
 	if ok && sdkErr.Status() == http.StatusNotFound {
@@ -1801,7 +1995,7 @@ func (r *ClusterRosaClassicResource) waitTillClusterIsNotFoundWithTimeout(ctx co
 	return false, nil
 }
 
-func shouldPatchProperties(state, plan *ClusterRosaClassicState) bool {
+func shouldPatchProperties(state, plan *ClusterRosaClassicState, expectedDefaults map[string]string) bool {
 	// User defined properties needs update
 	if _, should := common.ShouldPatchMap(state.Properties, plan.Properties); should {
 		return true
@@ -1814,11 +2008,11 @@ func shouldPatchProperties(state, plan *ClusterRosaClassicState) bool {
 		}
 	}
 
-	if len(extractedDefaults) != len(rosa.OCMProperties) {
+	if len(extractedDefaults) != len(expectedDefaults) {
 		return true
 	}
 
-	for k, v := range rosa.OCMProperties {
+	for k, v := range expectedDefaults {
 		if _, ok := extractedDefaults[k]; !ok {
 			return true
 		} else if extractedDefaults[k] != v {
@@ -1830,3 +2024,15 @@ func shouldPatchProperties(state, plan *ClusterRosaClassicState) bool {
 	return false
 
 }
+
+// populateState populates the state from the cluster object, leaving out of the properties the
+// ones that come from the default properties of the provider and that aren't configured.
+func (r *ClusterRosaClassicResource) populateState(ctx context.Context, object *cmv1.Cluster, state *ClusterRosaClassicState) error {
+	configuredProperties := state.Properties
+	err := populateRosaClassicClusterState(ctx, object, state, common.DefaultHttpClient{})
+	if err != nil {
+		return err
+	}
+	state.Properties, err = r.RemoveDefaultProperties(ctx, state.Properties, configuredProperties)
+	return err
+}
@@ -71,13 +71,19 @@ type ClusterRosaClassicState struct {
 	Ec2MetadataHttpTokens                     types.String                 `tfsdk:"ec2_metadata_http_tokens"`
 	CreateAdminUser                           types.Bool                   `tfsdk:"create_admin_user"`
 	AdminCredentials                          types.Object                 `tfsdk:"admin_credentials"`
+	AdminCredentialsPasswordWO                types.String                 `tfsdk:"admin_credentials_password_wo"`
+	AdminCredentialsPasswordWOVersion         types.Int64                  `tfsdk:"admin_credentials_password_wo_version"`
 	PrivateHostedZone                         *rosaTypes.PrivateHostedZone `tfsdk:"private_hosted_zone"`
 	BaseDNSDomain                             types.String                 `tfsdk:"base_dns_domain"`
 
 	UpgradeAcksFor types.String `tfsdk:"upgrade_acknowledgements_for"`
 
-	DisableWaitingInDestroy        types.Bool  `tfsdk:"disable_waiting_in_destroy"`
-	DestroyTimeout                 types.Int64 `tfsdk:"destroy_timeout"`
-	WaitForCreateComplete          types.Bool  `tfsdk:"wait_for_create_complete"`
-	MaxClusterWaitTimeoutInMinutes types.Int64 `tfsdk:"max_cluster_wait_timeout_in_minutes"`
+	DeleteProtection               types.Bool   `tfsdk:"delete_protection"`
+	DeletionPolicy                 types.String `tfsdk:"deletion_policy"`
+	DisableWaitingInDestroy        types.Bool   `tfsdk:"disable_waiting_in_destroy"`
+	DestroyTimeout                 types.Int64  `tfsdk:"destroy_timeout"`
+	WaitForCreateComplete          types.Bool   `tfsdk:"wait_for_create_complete"`
+	MaxClusterWaitTimeoutInMinutes types.Int64  `tfsdk:"max_cluster_wait_timeout_in_minutes"`
+	WaitForUpgradeComplete         types.Bool   `tfsdk:"wait_for_upgrade_complete"`
+	MaxUpgradeWaitTimeoutInMinutes types.Int64  `tfsdk:"max_upgrade_wait_timeout_in_minutes"`
 }
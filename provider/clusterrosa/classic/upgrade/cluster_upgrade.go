@@ -19,12 +19,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	semver "github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	ocmUtils "github.com/openshift-online/ocm-common/pkg/ocm/utils"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	rosa "github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/common"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 	"github.com/zgalor/weberr"
 )
@@ -39,6 +41,10 @@ func (cu *ClusterUpgrade) State() cmv1.UpgradePolicyStateValue {
 	return cu.policyState.Value()
 }
 
+func (cu *ClusterUpgrade) Description() string {
+	return cu.policyState.Description()
+}
+
 func (cu *ClusterUpgrade) Version() string {
 	return cu.policy.Version()
 }
@@ -114,6 +120,11 @@ func GetScheduledUpgrades(ctx context.Context, client *cmv1.ClustersClient, clus
 		if policy.UpgradeType() != cmv1.UpgradeTypeOSD {
 			continue
 		}
+		// Automatic policies are managed by the `rhcs_cluster_upgrade_policy` resource, they
+		// don't have a fixed version and they are rescheduled after each upgrade
+		if policy.ScheduleType() == cmv1.ScheduleTypeAutomatic {
+			continue
+		}
 		resp, err := upgradeClient.UpgradePolicy(policy.ID()).
 			State().
 			Get().
@@ -242,3 +253,74 @@ func getMissingGateAgreements(
 	}
 	return []*cmv1.VersionGate{}, nil
 }
+
+// Wait till the current version of the cluster is the desired version, polling
+// the cluster and its upgrade policies with the given interval. Returns an
+// error with the description of the state of the upgrade policy if the upgrade
+// fails or is cancelled, or if it doesn't complete before the timeout
+func WaitForUpgradeToComplete(ctx context.Context, client *cmv1.ClustersClient, clusterId string,
+	desiredVersion *semver.Version, interval time.Duration, timeout time.Duration) error {
+	pollCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for {
+		completed, err := checkUpgradeCompleted(pollCtx, client, clusterId, desiredVersion)
+		if pollCtx.Err() != nil {
+			return fmt.Errorf("the upgrade to version %s didn't complete within %v", desiredVersion, timeout)
+		}
+		if err != nil {
+			return err
+		}
+		if completed {
+			return nil
+		}
+		select {
+		case <-pollCtx.Done():
+			return fmt.Errorf("the upgrade to version %s didn't complete within %v", desiredVersion, timeout)
+		case <-time.After(interval):
+		}
+	}
+}
+
+func checkUpgradeCompleted(ctx context.Context, client *cmv1.ClustersClient, clusterId string,
+	desiredVersion *semver.Version) (bool, error) {
+	resp, err := client.Cluster(clusterId).Get().SendContext(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get cluster: %v", err)
+	}
+	// Use the raw identifier of the version if present, otherwise the identifier without the
+	// prefix and the channel group suffix, like when populating the current version
+	version := resp.Body().Version()
+	rawID, ok := version.GetRawID()
+	if !ok {
+		rawID = strings.TrimPrefix(version.ID(), rosa.VersionPrefix)
+		rawID = strings.TrimSuffix(rawID, fmt.Sprintf("-%s", version.ChannelGroup()))
+	}
+	currentVersion, err := semver.NewVersion(rawID)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse current cluster version: %v", err)
+	}
+	if !currentVersion.LessThan(desiredVersion) {
+		return true, nil
+	}
+
+	upgrades, err := GetScheduledUpgrades(ctx, client, clusterId)
+	if err != nil {
+		return false, err
+	}
+	for _, upgrade := range upgrades {
+		toVersion, err := semver.NewVersion(upgrade.Version())
+		if err != nil {
+			return false, fmt.Errorf("failed to parse upgrade version: %v", err)
+		}
+		if !desiredVersion.Equal(toVersion) {
+			continue
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Upgrade policy to %s is in state %s", toVersion, upgrade.State()))
+		switch upgrade.State() {
+		case cmv1.UpgradePolicyStateValueFailed, cmv1.UpgradePolicyStateValueCancelled:
+			return false, fmt.Errorf("the upgrade to version %s ended in state '%s': %s",
+				desiredVersion, upgrade.State(), upgrade.Description())
+		}
+	}
+	return false, nil
+}
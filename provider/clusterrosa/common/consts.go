@@ -18,6 +18,7 @@ const (
 	MaxHCPClusterWaitTimeoutInMinutes  = int64(45)
 	MaxClusterWaitTimeoutInMinutes     = int64(60)
 	MaxMachinePoolWaitTimeoutInMinutes = int64(60)
+	MaxUpgradeWaitTimeoutInMinutes     = int64(120)
 	DefaultPollingIntervalInMinutes    = 2
 	NonPositiveTimeoutSummary          = "Can't poll cluster state with a non-positive timeout"
 	NonPositiveTimeoutFormat           = "Can't poll state of cluster with identifier '%s', the timeout that was set is not a positive number"
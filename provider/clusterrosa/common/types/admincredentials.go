@@ -2,13 +2,17 @@ package types
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/identityprovider/htpasswd"
 )
 
 type AdminCredentials struct {
@@ -33,6 +37,20 @@ func FlattenAdminCredentials(username string, password string) types.Object {
 	return types.ObjectValueMust(attributeTypes, attrs)
 }
 
+// FlattenAdminUsername returns the admin credentials without the password, used when the password
+// is given in a write-only attribute.
+func FlattenAdminUsername(username string) types.Object {
+	attributeTypes := map[string]attr.Type{
+		"username": types.StringType,
+		"password": types.StringType,
+	}
+	attrs := map[string]attr.Value{
+		"username": types.StringValue(username),
+		"password": types.StringNull(),
+	}
+	return types.ObjectValueMust(attributeTypes, attrs)
+}
+
 func ExpandAdminCredentials(ctx context.Context, object types.Object, diags diag.Diagnostics) (username string, password string) {
 	if object.IsNull() {
 		return "", ""
@@ -60,3 +78,30 @@ func AdminCredentialsEqual(state, plan types.Object) bool {
 	}
 	return reflect.DeepEqual(state, plan)
 }
+
+// UpdateAdminPassword changes the password of the admin user of the cluster, which is a user of one
+// of its `htpasswd` identity providers.
+func (b *BaseCluster) UpdateAdminPassword(ctx context.Context, clusterID string, username string,
+	password string) error {
+	identityProviders := b.ClusterCollection.Cluster(clusterID).IdentityProviders()
+	list, err := identityProviders.List().SendContext(ctx)
+	if err != nil {
+		return fmt.Errorf("can't list the identity providers: %v", err)
+	}
+	for _, item := range list.Items().Slice() {
+		if item.Type() != cmv1.IdentityProviderTypeHtpasswd {
+			continue
+		}
+		resource := identityProviders.IdentityProvider(item.ID())
+		users, err := resource.HtpasswdUsers().List().SendContext(ctx)
+		if err != nil {
+			return fmt.Errorf("can't list the users of identity provider '%s': %v", item.Name(), err)
+		}
+		for _, user := range users.Items().Slice() {
+			if user.Username() == username {
+				return htpasswd.UpdateUser(ctx, password, user.ID(), resource)
+			}
+		}
+	}
+	return fmt.Errorf("can't find the admin user '%s' in the htpasswd identity providers", username)
+}
@@ -3,10 +3,12 @@ package types
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 
 	"github.com/Masterminds/semver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
@@ -35,10 +37,74 @@ const (
 	PoolMessage = "This attribute specifically applies to the Worker Machine Pool and becomes irrelevant once the resource is created. Any modifications to the initial Machine Pool should be made through the Terraform imported Machine Pool resource. For more details, refer to [Worker Machine Pool in ROSA Cluster](../guides/worker-machine-pool.md)"
 )
 
+// IAMRoleArnRE matches the ARN of an AWS IAM role in any partition.
+var IAMRoleArnRE = regexp.MustCompile(`^arn:aws[\w-]*:iam::\d{12}:role/\S+$`)
+
 type BaseCluster struct {
 	ClusterCollection *cmv1.ClustersClient
 	VersionCollection *cmv1.VersionsClient
 	ClusterWait       common.ClusterWait
+	Defaults          common.ProviderDefaults
+}
+
+// MergeProviderDefaults returns the tags and properties of the cluster with the default tags and
+// properties of the provider added to them.
+func (b *BaseCluster) MergeProviderDefaults(ctx context.Context, tags types.Map,
+	properties types.Map) (types.Map, types.Map, error) {
+	mergedTags, err := mergeProviderDefaults(ctx, b.Defaults.Tags, tags)
+	if err != nil {
+		return tags, properties, err
+	}
+	mergedProperties, err := mergeProviderDefaults(ctx, b.Defaults.Properties, properties)
+	if err != nil {
+		return tags, properties, err
+	}
+	return mergedTags, mergedProperties, nil
+}
+
+func mergeProviderDefaults(ctx context.Context, defaults map[string]string, values types.Map) (types.Map, error) {
+	if len(defaults) == 0 {
+		return values, nil
+	}
+	elements, err := common.OptionalMap(ctx, values)
+	if err != nil {
+		return values, err
+	}
+	return common.ConvertStringMapToMapType(common.MergeDefaults(defaults, elements))
+}
+
+// RemoveDefaultProperties returns the properties read from OCM without the ones that come from
+// the default properties of the provider and that aren't part of the configured properties.
+func (b *BaseCluster) RemoveDefaultProperties(ctx context.Context, properties types.Map,
+	configured types.Map) (types.Map, error) {
+	if len(b.Defaults.Properties) == 0 || !common.HasValue(properties) {
+		return properties, nil
+	}
+	elements, err := common.OptionalMap(ctx, properties)
+	if err != nil {
+		return properties, err
+	}
+	configuredElements, err := common.OptionalMap(ctx, configured)
+	if err != nil {
+		return properties, err
+	}
+	return common.ConvertStringMapToMapType(common.RemoveDefaults(b.Defaults.Properties, elements, configuredElements))
+}
+
+// ExpectedDefaultProperties returns the properties that OCM should have for a cluster besides the
+// given user defined properties: the ones added by OCM and the default properties of the provider
+// that aren't overridden.
+func (b *BaseCluster) ExpectedDefaultProperties(ocmProperties map[string]string, properties types.Map) map[string]string {
+	result := make(map[string]string, len(ocmProperties)+len(b.Defaults.Properties))
+	for k, v := range b.Defaults.Properties {
+		if _, ok := properties.Elements()[k]; !ok {
+			result[k] = v
+		}
+	}
+	for k, v := range ocmProperties {
+		result[k] = v
+	}
+	return result
 }
 
 // getAndValidateVersionInChannelGroup ensures that the cluster version is
@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+)
+
+const (
+	DeleteProtectionDescription = "Prevents the cluster from being deleted while enabled. Deleting or " +
+		"replacing the cluster fails until it is set to false and applied. The default value is false."
+	DeleteProtectionSummary = "Cluster has delete protection enabled"
+	DeleteProtectionFormat  = "The cluster with identifier '%s' has delete protection enabled. Set " +
+		"'delete_protection' to false and apply the change before deleting or replacing the cluster."
+)
+
+// UpdateDeleteProtection enables or disables the delete protection of the cluster.
+func (b *BaseCluster) UpdateDeleteProtection(ctx context.Context, clusterID string, enabled bool) error {
+	deleteProtection, err := cmv1.NewDeleteProtection().Enabled(enabled).Build()
+	if err != nil {
+		return fmt.Errorf("can't build the delete protection: %v", err)
+	}
+	resp, err := b.ClusterCollection.Cluster(clusterID).DeleteProtection().Update().
+		Body(deleteProtection).SendContext(ctx)
+	if err != nil {
+		return common.HandleErr(resp.Error(), err)
+	}
+	return nil
+}
+
+// WarnIfDeleteProtected adds a warning to the plan if it destroys a cluster that has the delete
+// protection enabled and isn't retained, as applying it will fail.
+func WarnIfDeleteProtected(ctx context.Context, req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the cluster is being created or updated:
+	if req.State.Raw.IsNull() || !req.Plan.Raw.IsNull() {
+		return
+	}
+	var id, deletionPolicy types.String
+	var deleteProtection types.Bool
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("delete_protection"), &deleteProtection)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("deletion_policy"), &deletionPolicy)...)
+	if resp.Diagnostics.HasError() || !common.BoolWithFalseDefault(deleteProtection) {
+		return
+	}
+
+	// Clusters that are retained aren't deleted, so the protection doesn't apply:
+	if common.ShouldRetain(deletionPolicy) {
+		return
+	}
+	resp.Diagnostics.AddWarning(DeleteProtectionSummary, fmt.Sprintf(DeleteProtectionFormat, id.ValueString()))
+}
@@ -3,12 +3,17 @@ package common
 import (
 	"context"
 	"fmt"
+	"net"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	tfTypes "github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/openshift-online/ocm-common/pkg/cluster/validations"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/common/types"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common/attrvalidators"
@@ -73,3 +78,270 @@ var PropertiesValidator = attrvalidators.NewMapValidator("properties map should
 			}
 		}
 	})
+
+const (
+	// Default number of worker nodes when neither 'replicas' nor 'max_replicas' are set:
+	defaultSingleAZReplicas = 2
+	defaultMultiAZReplicas  = 3
+
+	// Nodes that classic clusters have in addition to the worker nodes:
+	classicControlPlaneNodes  = 3
+	classicSingleAZInfraNodes = 2
+	classicMultiAZInfraNodes  = 3
+
+	invalidNetworkSummary = "Invalid network configuration"
+)
+
+// NetworkAttributes contains the values of the network related attributes of a cluster that are
+// checked against each other. Attributes that are null or unknown are nil, and flags that are null
+// are false.
+type NetworkAttributes struct {
+	MachineCIDR       *string
+	ServiceCIDR       *string
+	PodCIDR           *string
+	HostPrefix        *int64
+	MultiAZ           bool
+	PrivateLink       bool
+	Replicas          *int64
+	MaxReplicas       *int64
+	SubnetIDs         []string
+	AvailabilityZones []string
+}
+
+// NetworkValidator returns a validator that checks the network attributes of the cluster against
+// each other during the plan, so that combinations that OCM would reject are reported before the
+// cluster creation starts.
+func NetworkValidator(topology types.ClusterTopology) resource.ConfigValidator {
+	return &networkValidator{topology: topology}
+}
+
+type networkValidator struct {
+	topology types.ClusterTopology
+}
+
+var _ resource.ConfigValidator = &networkValidator{}
+
+func (v *networkValidator) Description(_ context.Context) string {
+	return "CIDRs must not overlap, the pod CIDR must have room for all the nodes and the availability " +
+		"zones must be consistent with the subnets"
+}
+
+func (v *networkValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v *networkValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse) {
+	attributes := &NetworkAttributes{}
+	attributes.MachineCIDR = getString(ctx, req.Config, "machine_cidr", &resp.Diagnostics)
+	attributes.ServiceCIDR = getString(ctx, req.Config, "service_cidr", &resp.Diagnostics)
+	attributes.PodCIDR = getString(ctx, req.Config, "pod_cidr", &resp.Diagnostics)
+	attributes.HostPrefix = getInt64(ctx, req.Config, "host_prefix", &resp.Diagnostics)
+	attributes.Replicas = getInt64(ctx, req.Config, "replicas", &resp.Diagnostics)
+	attributes.SubnetIDs = getStrings(ctx, req.Config, "aws_subnet_ids", &resp.Diagnostics)
+	attributes.AvailabilityZones = getStrings(ctx, req.Config, "availability_zones", &resp.Diagnostics)
+	known := true
+	if v.topology == types.Classic {
+		attributes.MultiAZ = getBool(ctx, req.Config, "multi_az", &known, &resp.Diagnostics)
+		attributes.PrivateLink = getBool(ctx, req.Config, "aws_private_link", &known, &resp.Diagnostics)
+		attributes.MaxReplicas = getInt64(ctx, req.Config, "max_replicas", &resp.Diagnostics)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !known {
+		// The expected number of zones and subnets can't be calculated yet:
+		attributes.AvailabilityZones = nil
+	}
+	resp.Diagnostics.Append(ValidateNetworkAttributes(v.topology, attributes)...)
+}
+
+// ValidateNetworkAttributes checks that the CIDRs don't overlap, that the pod CIDR has room for the
+// maximum number of nodes of the cluster with the given host prefix, and that the availability zones
+// are consistent with the multi AZ flag and with the number of subnets.
+func ValidateNetworkAttributes(topology types.ClusterTopology, attributes *NetworkAttributes) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	validateCIDRs(attributes, &diags)
+	validatePodCIDRSize(topology, attributes, &diags)
+	validateAvailabilityZones(topology, attributes, &diags)
+	return diags
+}
+
+func validateCIDRs(attributes *NetworkAttributes, diags *diag.Diagnostics) {
+	type namedCIDR struct {
+		name  string
+		value string
+	}
+	cidrs := []namedCIDR{}
+	for _, cidr := range []struct {
+		name  string
+		value *string
+	}{
+		{"machine_cidr", attributes.MachineCIDR},
+		{"service_cidr", attributes.ServiceCIDR},
+		{"pod_cidr", attributes.PodCIDR},
+	} {
+		if cidr.value == nil {
+			continue
+		}
+		if err := common.ValidateCIDR(*cidr.value); err != nil {
+			diags.AddAttributeError(path.Root(cidr.name), invalidNetworkSummary, err.Error())
+			continue
+		}
+		cidrs = append(cidrs, namedCIDR{name: cidr.name, value: *cidr.value})
+	}
+	for i, second := range cidrs {
+		for _, first := range cidrs[:i] {
+			// Both are valid, so this can't fail:
+			overlap, _ := common.CIDRsOverlap(first.value, second.value)
+			if overlap {
+				diags.AddAttributeError(path.Root(second.name), invalidNetworkSummary,
+					fmt.Sprintf("'%s' %s overlaps with '%s' %s", second.name, second.value,
+						first.name, first.value))
+			}
+		}
+	}
+}
+
+func validatePodCIDRSize(topology types.ClusterTopology, attributes *NetworkAttributes,
+	diags *diag.Diagnostics) {
+	if attributes.PodCIDR == nil || attributes.HostPrefix == nil {
+		return
+	}
+	_, podNet, err := net.ParseCIDR(*attributes.PodCIDR)
+	if err != nil {
+		// Already reported:
+		return
+	}
+	podPrefix, bits := podNet.Mask.Size()
+	hostPrefix := *attributes.HostPrefix
+	if hostPrefix < int64(podPrefix) || hostPrefix > int64(bits) {
+		diags.AddAttributeError(path.Root("host_prefix"), invalidNetworkSummary,
+			fmt.Sprintf("'host_prefix' %d must be between the prefix length of 'pod_cidr' %s and %d",
+				hostPrefix, *attributes.PodCIDR, bits))
+		return
+	}
+
+	nodes := maxNodes(topology, attributes)
+	if hostPrefix-int64(podPrefix) >= 31 {
+		return
+	}
+	capacity := int64(1) << (hostPrefix - int64(podPrefix))
+	if capacity < nodes {
+		diags.AddAttributeError(path.Root("pod_cidr"), invalidNetworkSummary,
+			fmt.Sprintf("'pod_cidr' %s with 'host_prefix' %d only has room for %d nodes, but the "+
+				"cluster can have up to %d nodes", *attributes.PodCIDR, hostPrefix, capacity, nodes))
+	}
+}
+
+// maxNodes calculates the maximum number of nodes of the cluster that need a block of pod addresses.
+// Classic clusters also have control plane and infrastructure nodes, clusters with hosted control
+// planes only have worker nodes.
+func maxNodes(topology types.ClusterTopology, attributes *NetworkAttributes) int64 {
+	workers := int64(defaultSingleAZReplicas)
+	if attributes.MultiAZ {
+		workers = defaultMultiAZReplicas
+	}
+	if attributes.Replicas != nil {
+		workers = *attributes.Replicas
+	}
+	if attributes.MaxReplicas != nil {
+		workers = *attributes.MaxReplicas
+	}
+	if topology == types.Hcp {
+		return workers
+	}
+	infra := int64(classicSingleAZInfraNodes)
+	if attributes.MultiAZ {
+		infra = classicMultiAZInfraNodes
+	}
+	return workers + classicControlPlaneNodes + infra
+}
+
+func validateAvailabilityZones(topology types.ClusterTopology, attributes *NetworkAttributes,
+	diags *diag.Diagnostics) {
+	if attributes.AvailabilityZones == nil {
+		return
+	}
+	zones := len(attributes.AvailabilityZones)
+	if topology == types.Classic {
+		if err := validations.ValidateAvailabilityZonesCount(attributes.MultiAZ, zones); err != nil {
+			diags.AddAttributeError(path.Root("availability_zones"), invalidNetworkSummary, err.Error())
+			return
+		}
+	}
+
+	if attributes.SubnetIDs == nil {
+		return
+	}
+	subnets := len(attributes.SubnetIDs)
+	switch {
+	case topology == types.Classic && attributes.PrivateLink:
+		// Clusters with PrivateLink only have a private subnet per zone:
+		if subnets != zones {
+			diags.AddAttributeError(path.Root("aws_subnet_ids"), invalidNetworkSummary,
+				fmt.Sprintf("The number of subnets for a cluster with PrivateLink should be the same as "+
+					"the number of availability zones %d, instead received: %d", zones, subnets))
+		}
+	case topology == types.Classic:
+		// Public clusters have a private and a public subnet per zone:
+		if subnets != 2*zones {
+			diags.AddAttributeError(path.Root("aws_subnet_ids"), invalidNetworkSummary,
+				fmt.Sprintf("The number of subnets for a public cluster should be twice the number of "+
+					"availability zones %d, instead received: %d", zones, subnets))
+		}
+	default:
+		// Clusters with hosted control planes need at least a private subnet per zone:
+		if subnets < zones {
+			diags.AddAttributeError(path.Root("aws_subnet_ids"), invalidNetworkSummary,
+				fmt.Sprintf("The number of subnets should be at least the number of availability "+
+					"zones %d, instead received: %d", zones, subnets))
+		}
+	}
+}
+
+func getString(ctx context.Context, config tfsdk.Config, name string, diags *diag.Diagnostics) *string {
+	value := tfTypes.String{}
+	diags.Append(config.GetAttribute(ctx, path.Root(name), &value)...)
+	if !common.HasValue(value) {
+		return nil
+	}
+	return value.ValueStringPointer()
+}
+
+func getInt64(ctx context.Context, config tfsdk.Config, name string, diags *diag.Diagnostics) *int64 {
+	value := tfTypes.Int64{}
+	diags.Append(config.GetAttribute(ctx, path.Root(name), &value)...)
+	if !common.HasValue(value) {
+		return nil
+	}
+	return value.ValueInt64Pointer()
+}
+
+// getBool returns false if the attribute is null, and clears the known flag if it isn't known.
+func getBool(ctx context.Context, config tfsdk.Config, name string, known *bool,
+	diags *diag.Diagnostics) bool {
+	value := tfTypes.Bool{}
+	diags.Append(config.GetAttribute(ctx, path.Root(name), &value)...)
+	if value.IsUnknown() {
+		*known = false
+	}
+	return common.BoolWithFalseDefault(value)
+}
+
+// getStrings returns nil if the list or any of its elements isn't known.
+func getStrings(ctx context.Context, config tfsdk.Config, name string, diags *diag.Diagnostics) []string {
+	value := tfTypes.List{}
+	diags.Append(config.GetAttribute(ctx, path.Root(name), &value)...)
+	if !common.HasValue(value) {
+		return nil
+	}
+	result := []string{}
+	for _, element := range value.Elements() {
+		if element.IsUnknown() || element.IsNull() {
+			return nil
+		}
+		result = append(result, element.(tfTypes.String).ValueString())
+	}
+	return result
+}
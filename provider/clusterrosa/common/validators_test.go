@@ -0,0 +1,116 @@
+package common
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/common/types"
+)
+
+var _ = Describe("Network attributes validation", func() {
+	It("accepts the default network configuration", func() {
+		diags := ValidateNetworkAttributes(types.Classic, &NetworkAttributes{
+			MachineCIDR: pointer("10.0.0.0/16"),
+			ServiceCIDR: pointer("172.30.0.0/16"),
+			PodCIDR:     pointer("10.128.0.0/14"),
+			HostPrefix:  pointer(int64(23)),
+		})
+		Expect(diags).To(BeEmpty())
+	})
+
+	It("reports overlapping CIDRs on the later attribute", func() {
+		diags := ValidateNetworkAttributes(types.Hcp, &NetworkAttributes{
+			MachineCIDR: pointer("10.0.0.0/16"),
+			ServiceCIDR: pointer("10.0.128.0/17"),
+			PodCIDR:     pointer("10.0.0.0/8"),
+		})
+		Expect(diags.ErrorsCount()).To(Equal(3))
+		Expect(diags.Errors()[0].(diag.DiagnosticWithPath).Path()).To(Equal(path.Root("service_cidr")))
+		Expect(diags.Errors()[0].Detail()).To(ContainSubstring("overlaps with 'machine_cidr'"))
+		Expect(diags.Errors()[1].(diag.DiagnosticWithPath).Path()).To(Equal(path.Root("pod_cidr")))
+	})
+
+	It("reports invalid CIDRs", func() {
+		diags := ValidateNetworkAttributes(types.Hcp, &NetworkAttributes{
+			MachineCIDR: pointer("10.0.0.0"),
+		})
+		Expect(diags.ErrorsCount()).To(Equal(1))
+		Expect(diags.Errors()[0].Detail()).To(ContainSubstring("invalid CIDR '10.0.0.0'"))
+	})
+
+	It("reports a pod CIDR that is too small for the nodes of the cluster", func() {
+		// A /20 with a host prefix of 23 has room for 8 nodes, classic clusters need 5 nodes in
+		// addition to the workers:
+		attributes := &NetworkAttributes{
+			PodCIDR:    pointer("10.128.0.0/20"),
+			HostPrefix: pointer(int64(23)),
+			Replicas:   pointer(int64(3)),
+		}
+		Expect(ValidateNetworkAttributes(types.Classic, attributes)).To(BeEmpty())
+
+		attributes.MaxReplicas = pointer(int64(4))
+		diags := ValidateNetworkAttributes(types.Classic, attributes)
+		Expect(diags.ErrorsCount()).To(Equal(1))
+		Expect(diags.Errors()[0].Detail()).To(ContainSubstring("only has room for 8 nodes, but the " +
+			"cluster can have up to 9 nodes"))
+
+		// Clusters with hosted control planes only have worker nodes:
+		Expect(ValidateNetworkAttributes(types.Hcp, attributes)).To(BeEmpty())
+	})
+
+	It("reports a host prefix shorter than the pod CIDR prefix", func() {
+		diags := ValidateNetworkAttributes(types.Hcp, &NetworkAttributes{
+			PodCIDR:    pointer("10.128.0.0/24"),
+			HostPrefix: pointer(int64(23)),
+		})
+		Expect(diags.ErrorsCount()).To(Equal(1))
+		Expect(diags.Errors()[0].(diag.DiagnosticWithPath).Path()).To(Equal(path.Root("host_prefix")))
+	})
+
+	It("reports availability zones inconsistent with multi AZ", func() {
+		diags := ValidateNetworkAttributes(types.Classic, &NetworkAttributes{
+			MultiAZ:           true,
+			AvailabilityZones: []string{"us-east-1a"},
+		})
+		Expect(diags.ErrorsCount()).To(Equal(1))
+		Expect(diags.Errors()[0].(diag.DiagnosticWithPath).Path()).To(
+			Equal(path.Root("availability_zones")))
+	})
+
+	It("reports subnets inconsistent with the availability zones", func() {
+		zones := []string{"us-east-1a", "us-east-1b", "us-east-1c"}
+		Expect(ValidateNetworkAttributes(types.Classic, &NetworkAttributes{
+			MultiAZ:           true,
+			AvailabilityZones: zones,
+			SubnetIDs:         []string{"a", "b", "c", "d", "e", "f"},
+		})).To(BeEmpty())
+		Expect(ValidateNetworkAttributes(types.Classic, &NetworkAttributes{
+			MultiAZ:           true,
+			PrivateLink:       true,
+			AvailabilityZones: zones,
+			SubnetIDs:         []string{"a", "b", "c"},
+		})).To(BeEmpty())
+
+		diags := ValidateNetworkAttributes(types.Classic, &NetworkAttributes{
+			MultiAZ:           true,
+			AvailabilityZones: zones,
+			SubnetIDs:         []string{"a", "b", "c"},
+		})
+		Expect(diags.ErrorsCount()).To(Equal(1))
+		Expect(diags.Errors()[0].(diag.DiagnosticWithPath).Path()).To(Equal(path.Root("aws_subnet_ids")))
+		Expect(diags.Errors()[0].Detail()).To(ContainSubstring("should be twice"))
+
+		diags = ValidateNetworkAttributes(types.Hcp, &NetworkAttributes{
+			AvailabilityZones: zones,
+			SubnetIDs:         []string{"a", "b"},
+		})
+		Expect(diags.ErrorsCount()).To(Equal(1))
+		Expect(diags.Errors()[0].Detail()).To(ContainSubstring("at least the number of availability zones"))
+	})
+})
+
+func pointer[T any](src T) *T {
+	return &src
+}
@@ -141,6 +141,10 @@ func (r *ClusterRosaHcpDatasource) Schema(ctx context.Context, req datasource.Sc
 				Description: "Identifier of the AWS account for billing. " + common.ValueCannotBeChangedStringDescription,
 				Computed:    true,
 			},
+			"audit_log_arn": schema.StringAttribute{
+				Description: "ARN of the AWS IAM role used to forward the audit logs of the control plane to CloudWatch.",
+				Computed:    true,
+			},
 			"aws_subnet_ids": schema.ListAttribute{
 				Description: "AWS subnet IDs. " + common.ValueCannotBeChangedStringDescription,
 				ElementType: types.StringType,
@@ -31,6 +31,7 @@ import (
 	semver "github.com/hashicorp/go-version"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/registry_config"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -68,6 +69,7 @@ import (
 	sharedvpc "github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/hcp/shared_vpc"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/hcp/upgrade"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/sts"
+	"github.com/terraform-redhat/terraform-provider-rhcs/tracing"
 )
 
 const (
@@ -81,6 +83,9 @@ type ClusterRosaHcpResource struct {
 
 var _ resource.ResourceWithConfigure = &ClusterRosaHcpResource{}
 var _ resource.ResourceWithImportState = &ClusterRosaHcpResource{}
+var _ resource.ResourceWithUpgradeState = &ClusterRosaHcpResource{}
+var _ resource.ResourceWithConfigValidators = &ClusterRosaHcpResource{}
+var _ resource.ResourceWithModifyPlan = &ClusterRosaHcpResource{}
 
 func New() resource.Resource {
 	return &ClusterRosaHcpResource{}
@@ -92,6 +97,7 @@ func (r *ClusterRosaHcpResource) Metadata(ctx context.Context, req resource.Meta
 
 func (r *ClusterRosaHcpResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     schemaVersion,
 		Description: "OpenShift managed cluster using ROSA HCP.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -214,6 +220,14 @@ func (r *ClusterRosaHcpResource) Schema(ctx context.Context, req resource.Schema
 					stringvalidator.RegexMatches(regexp.MustCompile(`^\d{12}$`), "aws billing account ID must be only digits and exactly 12 in length"),
 				},
 			},
+			"audit_log_arn": schema.StringAttribute{
+				Description: "ARN of the AWS IAM role used to forward the audit logs of the control plane to CloudWatch. " +
+					"It can be changed or removed after the creation of the cluster.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(rosaTypes.IAMRoleArnRE, "audit log ARN must be a valid AWS IAM role ARN"),
+				},
+			},
 			"aws_subnet_ids": schema.ListAttribute{
 				Description: "AWS subnet IDs. " + common.ValueCannotBeChangedStringDescription,
 				ElementType: types.StringType,
@@ -305,6 +319,21 @@ func (r *ClusterRosaHcpResource) Schema(ctx context.Context, req resource.Schema
 				Description: "The currently running version of OpenShift on the cluster, for example '4.11.0'.",
 				Computed:    true,
 			},
+			"deletion_policy": schema.StringAttribute{
+				Description: common.DeletionPolicyDescription("cluster"),
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(common.DeletionPolicyDelete, common.DeletionPolicyRetain),
+				},
+			},
+			"delete_protection": schema.BoolAttribute{
+				Description: rosaTypes.DeleteProtectionDescription,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"disable_waiting_in_destroy": schema.BoolAttribute{
 				Description: "Disable addressing cluster state in the destroy resource. Default value is false, and so a `destroy` will wait for the cluster to be deleted.",
 				Optional:    true,
@@ -339,6 +368,16 @@ func (r *ClusterRosaHcpResource) Schema(ctx context.Context, req resource.Schema
 				Description: "This value sets the maximum duration in minutes to wait for machine pools to be in a ready state.",
 				Optional:    true,
 			},
+			"wait_for_upgrade_complete": schema.BoolAttribute{
+				Description: "Wait until the upgrade to the version given in the 'version' attribute is completed " +
+					"when that version changes, instead of only scheduling it. The apply fails if the upgrade fails. " +
+					"The waiter has a timeout of 120 minutes, with the default value set to false",
+				Optional: true,
+			},
+			"max_upgrade_wait_timeout_in_minutes": schema.Int64Attribute{
+				Description: "This value sets the maximum duration in minutes to wait for the upgrade of the cluster to complete.",
+				Optional:    true,
+			},
 			"create_admin_user": schema.BoolAttribute{
 				Description: "Indicates if create cluster admin user. Set it true to create cluster admin user with default username `cluster-admin` " +
 					"and generated password. It will be ignored if `admin_credentials` is set." + common.ValueCannotBeChangedStringDescription,
@@ -373,6 +412,24 @@ func (r *ClusterRosaHcpResource) Schema(ctx context.Context, req resource.Schema
 					objectplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"admin_credentials_password_wo": schema.StringAttribute{
+				Description: common.WriteOnlyDescription("Admin password that will be created with the cluster, "+
+					"instead of the password in `admin_credentials`.", "admin_credentials_password_wo_version"),
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Validators: append([]validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("admin_credentials").AtName("password")),
+				}, identityprovider.HTPasswdPasswordValidators...),
+			},
+			"admin_credentials_password_wo_version": schema.Int64Attribute{
+				Description: common.WriteOnlyVersionDescription("admin_credentials_password_wo") +
+					" The password of the admin user is changed in the `htpasswd` identity provider of the cluster.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("admin_credentials_password_wo")),
+				},
+			},
 			"ec2_metadata_http_tokens": schema.StringAttribute{
 				Description: "This value determines which EC2 Instance Metadata Service mode to use for EC2 instances in the cluster." +
 					"This can be set as `optional` (IMDS v1 or v2) or `required` (IMDSv2 only)." + common.ValueCannotBeChangedStringDescription,
@@ -424,6 +481,17 @@ func (r *ClusterRosaHcpResource) Schema(ctx context.Context, req resource.Schema
 	}
 }
 
+func (r *ClusterRosaHcpResource) ConfigValidators(context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		rosa.NetworkValidator(rosaTypes.Hcp),
+	}
+}
+
+func (r *ClusterRosaHcpResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse) {
+	rosaTypes.WarnIfDeleteProtected(ctx, req, resp)
+}
+
 func (r *ClusterRosaHcpResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -442,6 +510,7 @@ func (r *ClusterRosaHcpResource) Configure(ctx context.Context, req resource.Con
 	r.ClusterCollection = connection.ClustersMgmt().V1().Clusters()
 	r.VersionCollection = connection.ClustersMgmt().V1().Versions()
 	r.ClusterWait = common.NewClusterWait(r.ClusterCollection, connection)
+	r.Defaults = common.GetProviderDefaults(connection)
 }
 
 const (
@@ -559,6 +628,7 @@ func createHcpClusterObject(ctx context.Context,
 	etcdKmsKeyArn := common.OptionalString(state.EtcdKmsKeyArn)
 	awsAccountID := common.OptionalString(state.AWSAccountID)
 	awsBillingAccountId := common.OptionalString(state.AWSBillingAccountID)
+	auditLogArn := common.OptionalString(state.AuditLogArn)
 
 	isPrivate := common.BoolWithFalseDefault(state.Private)
 	awsSubnetIDs, err := common.StringListToArray(ctx, state.AWSSubnetIDs)
@@ -602,7 +672,7 @@ func createHcpClusterObject(ctx context.Context,
 		kmsKeyARN, etcdKmsKeyArn,
 		isPrivate, awsAccountID, awsBillingAccountId, stsBuilder, awsSubnetIDs,
 		ingressHostedZoneId, route53RoleArn, internalCommunicationHostedZoneId, vpceRoleArn,
-		awsAdditionalComputeSecurityGroupIds, nil, nil, awsAdditionalAllowedPrincipals); err != nil {
+		awsAdditionalComputeSecurityGroupIds, nil, nil, awsAdditionalAllowedPrincipals, auditLogArn); err != nil {
 		return nil, err
 	}
 
@@ -678,7 +748,11 @@ func createHcpClusterObject(ctx context.Context,
 	}
 
 	username, password := rosaTypes.ExpandAdminCredentials(ctx, state.AdminCredentials, diags)
-	if common.BoolWithFalseDefault(state.CreateAdminUser) || common.HasValue(state.AdminCredentials) {
+	writeOnlyPassword := common.HasValue(state.AdminCredentialsPasswordWO)
+	if writeOnlyPassword {
+		password = state.AdminCredentialsPasswordWO.ValueString()
+	}
+	if common.BoolWithFalseDefault(state.CreateAdminUser) || common.HasValue(state.AdminCredentials) || writeOnlyPassword {
 		if username == "" {
 			username = commonutils.ClusterAdminUsername
 		}
@@ -705,7 +779,11 @@ func createHcpClusterObject(ctx context.Context,
 		htPasswdIDP := cmv1.NewHTPasswdIdentityProvider().Users(htpassUserList)
 		builder.Htpasswd(htPasswdIDP)
 	}
-	state.AdminCredentials = rosaTypes.FlattenAdminCredentials(username, password)
+	if writeOnlyPassword {
+		state.AdminCredentials = rosaTypes.FlattenAdminUsername(username)
+	} else {
+		state.AdminCredentials = rosaTypes.FlattenAdminCredentials(username, password)
+	}
 
 	builder, err = proxy.BuildProxy(state.Proxy, builder)
 	if err != nil {
@@ -722,17 +800,6 @@ func createHcpClusterObject(ctx context.Context,
 	return object, err
 }
 
-// TODO: move to ocm commons
-func getOcmVersionMinor(ver string) string {
-	version, err := semver.NewVersion(ver)
-	if err != nil {
-		segments := strings.Split(ver, ".")
-		return fmt.Sprintf("%s.%s", segments[0], segments[1])
-	}
-	segments := version.Segments()
-	return fmt.Sprintf("%d.%d", segments[0], segments[1])
-}
-
 func (r *ClusterRosaHcpResource) Create(ctx context.Context, request resource.CreateRequest,
 	response *resource.CreateResponse) {
 	tflog.Debug(ctx, "begin create()")
@@ -744,6 +811,11 @@ func (r *ClusterRosaHcpResource) Create(ctx context.Context, request resource.Cr
 	if response.Diagnostics.HasError() {
 		return
 	}
+	diags = request.Config.GetAttribute(ctx, path.Root("admin_credentials_password_wo"), &state.AdminCredentialsPasswordWO)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
 	summary := "Can't build cluster"
 
 	shouldWaitCreationComplete := common.BoolWithFalseDefault(state.WaitForCreateComplete)
@@ -796,7 +868,22 @@ func (r *ClusterRosaHcpResource) Create(ctx context.Context, request resource.Cr
 		return
 	}
 
+	// The default tags and properties of the provider are only sent to OCM, the state keeps the
+	// configured ones so that the defaults don't show up as differences:
+	configuredTags, configuredProperties := state.Tags, state.Properties
+	state.Tags, state.Properties, err = r.MergeProviderDefaults(ctx, state.Tags, state.Properties)
+	if err != nil {
+		response.Diagnostics.AddError(
+			summary,
+			fmt.Sprintf(
+				"Can't merge the provider default tags and properties for cluster with name '%s': %v",
+				state.Name.ValueString(), err,
+			),
+		)
+		return
+	}
 	object, err := createHcpClusterObject(ctx, state, diags)
+	state.Tags, state.Properties = configuredTags, configuredProperties
 	if err != nil {
 		response.Diagnostics.AddError(
 			summary,
@@ -822,7 +909,8 @@ func (r *ClusterRosaHcpResource) Create(ctx context.Context, request resource.Cr
 	object = add.Body()
 
 	// Save initial state:
-	err = populateRosaHcpClusterState(ctx, object, state)
+	deleteProtection := common.BoolWithFalseDefault(state.DeleteProtection)
+	err = r.populateState(ctx, object, state)
 	if err != nil {
 		response.Diagnostics.AddError(
 			"Can't populate cluster state",
@@ -833,6 +921,22 @@ func (r *ClusterRosaHcpResource) Create(ctx context.Context, request resource.Cr
 		return
 	}
 
+	// The delete protection is changed with its own endpoint once the cluster exists:
+	if deleteProtection {
+		err = r.UpdateDeleteProtection(ctx, object.ID(), true)
+		if err != nil {
+			response.Diagnostics.AddError(
+				"Can't enable delete protection",
+				fmt.Sprintf("Can't enable the delete protection of cluster with identifier '%s': %v",
+					object.ID(), err),
+			)
+			diags = response.State.Set(ctx, state)
+			response.Diagnostics.Append(diags...)
+			return
+		}
+		state.DeleteProtection = types.BoolValue(true)
+	}
+
 	if shouldWaitCreationComplete {
 		tflog.Info(ctx, "Waiting for cluster to get ready")
 		timeOut := common.OptionalInt64(state.MaxHCPClusterWaitTimeoutInMinutes)
@@ -881,7 +985,7 @@ func (r *ClusterRosaHcpResource) Create(ctx context.Context, request resource.Cr
 	}
 
 	// Save the state post wait completion:
-	err = populateRosaHcpClusterState(ctx, object, state)
+	err = r.populateState(ctx, object, state)
 	if err != nil {
 		response.Diagnostics.AddError(
 			"Can't populate cluster state",
@@ -892,6 +996,11 @@ func (r *ClusterRosaHcpResource) Create(ctx context.Context, request resource.Cr
 		return
 	}
 
+	// The response of the creation request doesn't reflect the delete protection enabled later:
+	if deleteProtection {
+		state.DeleteProtection = types.BoolValue(true)
+	}
+
 	diags = response.State.Set(ctx, state)
 	response.Diagnostics.Append(diags...)
 }
@@ -930,7 +1039,7 @@ func (r *ClusterRosaHcpResource) Read(ctx context.Context, request resource.Read
 	object := get.Body()
 
 	// Save the state:
-	err = populateRosaHcpClusterState(ctx, object, state)
+	err = r.populateState(ctx, object, state)
 	if err != nil {
 		response.Diagnostics.AddError(
 			"Can't populate cluster state",
@@ -1085,6 +1194,45 @@ func (r *ClusterRosaHcpResource) Update(ctx context.Context, request resource.Up
 		return
 	}
 
+	// Change the delete protection if needed:
+	if deleteProtection, shouldPatch := common.ShouldPatchBool(state.DeleteProtection, plan.DeleteProtection); shouldPatch {
+		err := r.UpdateDeleteProtection(ctx, state.ID.ValueString(), deleteProtection)
+		if err != nil {
+			response.Diagnostics.AddError(
+				"Can't update delete protection",
+				fmt.Sprintf("Can't update the delete protection of cluster with identifier '%s': %v",
+					state.ID.ValueString(), err),
+			)
+			return
+		}
+	}
+
+	// Change the password of the admin user if the version of the write-only password changed:
+	if !state.AdminCredentialsPasswordWOVersion.Equal(plan.AdminCredentialsPasswordWOVersion) {
+		diags = request.Config.GetAttribute(ctx, path.Root("admin_credentials_password_wo"), &plan.AdminCredentialsPasswordWO)
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+		username, _ := rosaTypes.ExpandAdminCredentials(ctx, state.AdminCredentials, diags)
+		if username == "" {
+			response.Diagnostics.AddError(
+				"Can't change admin password",
+				fmt.Sprintf("Cluster with identifier '%s' doesn't have an admin user", state.ID.ValueString()),
+			)
+			return
+		}
+		err := r.UpdateAdminPassword(ctx, state.ID.ValueString(), username, plan.AdminCredentialsPasswordWO.ValueString())
+		if err != nil {
+			response.Diagnostics.AddError(
+				"Can't change admin password",
+				fmt.Sprintf("Can't change the password of the admin user of cluster with identifier '%s': %v",
+					state.ID.ValueString(), err),
+			)
+			return
+		}
+	}
+
 	clusterBuilder := cmv1.NewCluster()
 
 	// Handle channel group changes
@@ -1121,7 +1269,7 @@ func (r *ClusterRosaHcpResource) Update(ctx context.Context, request resource.Up
 		return
 	}
 
-	patchProperties := shouldPatchProperties(state, plan)
+	patchProperties := shouldPatchProperties(state, plan, r.ExpectedDefaultProperties(rosa.OCMProperties, plan.Properties))
 	if patchProperties {
 		propertiesElements, err := rosa.ValidatePatchProperties(ctx, state.Properties, plan.Properties)
 		if err != nil {
@@ -1134,6 +1282,7 @@ func (r *ClusterRosaHcpResource) Update(ctx context.Context, request resource.Up
 			for k, v := range rosa.OCMProperties {
 				propertiesElements[k] = v
 			}
+			propertiesElements = common.MergeDefaults(r.Defaults.Properties, propertiesElements)
 			clusterBuilder.Properties(propertiesElements)
 		}
 	}
@@ -1172,6 +1321,12 @@ func (r *ClusterRosaHcpResource) Update(ctx context.Context, request resource.Up
 		changesToAws = shouldPatch
 	}
 
+	// Unlike the other attributes, the audit log role can be removed, which is done by sending an empty value:
+	if !plan.AuditLogArn.IsUnknown() && !plan.AuditLogArn.Equal(state.AuditLogArn) {
+		awsBuilder.AuditLog(cmv1.NewAuditLog().RoleArn(plan.AuditLogArn.ValueString()))
+		changesToAws = true
+	}
+
 	if changesToAws {
 		clusterBuilder.AWS(awsBuilder)
 	}
@@ -1205,7 +1360,7 @@ func (r *ClusterRosaHcpResource) Update(ctx context.Context, request resource.Up
 	object := update.Body()
 
 	// Update the state:
-	err = populateRosaHcpClusterState(ctx, object, plan)
+	err = r.populateState(ctx, object, plan)
 	if err != nil {
 		response.Diagnostics.AddError(
 			"Can't populate cluster state",
@@ -1291,6 +1446,21 @@ func (r *ClusterRosaHcpResource) upgradeClusterIfNeeded(ctx context.Context, sta
 		}
 	}
 
+	// Wait for the upgrade to complete if requested
+	if !cancelingUpgradeOnly && common.BoolWithFalseDefault(plan.WaitForUpgradeComplete) {
+		timeout, err := common.ValidateTimeout(common.OptionalInt64(plan.MaxUpgradeWaitTimeoutInMinutes),
+			rosa.MaxUpgradeWaitTimeoutInMinutes)
+		if err != nil {
+			return err
+		}
+		tflog.Info(ctx, "Waiting for cluster upgrade to complete")
+		err = upgrade.WaitForUpgradeToComplete(ctx, r.ClusterCollection, state.ID.ValueString(), desiredVersion,
+			rosa.DefaultPollingIntervalInMinutes*time.Minute, time.Duration(*timeout)*time.Minute)
+		if err != nil {
+			return err
+		}
+	}
+
 	state.Version = plan.Version
 	state.UpgradeAcksFor = plan.UpgradeAcksFor
 	return nil
@@ -1347,7 +1517,7 @@ func scheduleUpgrade(ctx context.Context, client *cmv1.ClustersClient, clusterID
 			userAckRequired = true
 		}
 	}
-	targetMinorVersion := getOcmVersionMinor(desiredVersion.String())
+	targetMinorVersion := common.GetOcmVersionMinor(desiredVersion.String())
 	if userAckRequired && userAckString != targetMinorVersion { // User has not acknowledged mandatory gates, stop here.
 		return fmt.Errorf("%s\nTo acknowledge these items, please add \"upgrade_acknowledgements_for = %s\""+
 			" and re-apply the changes", description, targetMinorVersion)
@@ -1412,6 +1582,23 @@ func (r *ClusterRosaHcpResource) Delete(ctx context.Context, request resource.De
 		return
 	}
 
+	// Keep the cluster in OCM if requested, only removing it from the state:
+	if common.ShouldRetain(state.DeletionPolicy) {
+		tflog.Info(ctx, fmt.Sprintf("Deletion policy is '%s', keeping cluster '%s' in OCM",
+			common.DeletionPolicyRetain, state.ID.ValueString()))
+		response.State.RemoveResource(ctx)
+		return
+	}
+
+	// Refuse to delete the cluster while it is protected:
+	if common.BoolWithFalseDefault(state.DeleteProtection) {
+		response.Diagnostics.AddError(
+			"Can't delete cluster",
+			fmt.Sprintf(rosaTypes.DeleteProtectionFormat, state.ID.ValueString()),
+		)
+		return
+	}
+
 	// Send the request to delete the cluster:
 	resource := r.ClusterCollection.Cluster(state.ID.ValueString())
 	_, err := resource.Delete().SendContext(ctx)
@@ -1437,7 +1624,10 @@ func (r *ClusterRosaHcpResource) Delete(ctx context.Context, request resource.De
 				timeout = state.DestroyTimeout.ValueInt64()
 			}
 		}
-		isNotFound, err := r.retryClusterNotFoundWithTimeout(3, 1*time.Minute, ctx, timeout, resource)
+		waitCtx, span := tracing.StartSpan(ctx, "wait for cluster deletion",
+			tracing.ClusterIDKey.String(state.ID.ValueString()))
+		isNotFound, err := r.retryClusterNotFoundWithTimeout(3, 1*time.Minute, waitCtx, timeout, resource)
+		tracing.EndSpan(span, err)
 		if err != nil {
 			response.Diagnostics.AddError(
 				"Can't poll cluster state",
@@ -1465,7 +1655,16 @@ func (r *ClusterRosaHcpResource) ImportState(ctx context.Context, request resour
 	response *resource.ImportStateResponse) {
 	tflog.Debug(ctx, "begin importstate()")
 
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
+	// The cluster can be specified with its identifier, its name or its external identifier:
+	clusterID, err := common.ResolveClusterID(ctx, r.ClusterCollection, request.ID)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Can't import cluster",
+			err.Error(),
+		)
+		return
+	}
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("id"), clusterID)...)
 }
 
 // populateRosaHcpClusterState copies the data from the API object to the Terraform state.
@@ -1476,6 +1675,7 @@ func populateRosaHcpClusterState(ctx context.Context, object *cmv1.Cluster, stat
 	state.Name = types.StringValue(object.Name())
 	state.CloudRegion = types.StringValue(object.Region().ID())
 	state.DomainPrefix = types.StringValue(object.DomainPrefix())
+	state.DeleteProtection = types.BoolValue(object.DeleteProtection().Enabled())
 
 	if props, ok := object.GetProperties(); ok {
 		propertiesMap := map[string]string{}
@@ -1720,6 +1920,8 @@ func populateRosaHcpClusterState(ctx context.Context, object *cmv1.Cluster, stat
 			}
 		}
 
+		state.AuditLogArn = common.EmptiableStringToStringType(awsObj.AuditLog().RoleArn())
+
 		if additionalAllowedPrincipals, ok := awsObj.GetAdditionalAllowedPrincipals(); ok {
 			awsAdditionalAllowedPrincipals, err := common.StringArrayToList(additionalAllowedPrincipals)
 			if err != nil {
@@ -1755,9 +1957,17 @@ func (r *ClusterRosaHcpResource) waitTillClusterIsNotFoundWithTimeout(ctx contex
 	timeoutInMinutes := time.Duration(timeout) * time.Minute
 	pollCtx, cancel := context.WithTimeout(ctx, timeoutInMinutes)
 	defer cancel()
+	iterations := tracing.NewPollSpans(ctx, "poll cluster deletion")
 	_, err := resource.Poll().
 		Interval(rosa.DefaultPollingIntervalInMinutes * time.Minute).
 		Status(http.StatusNotFound).
+		Predicate(func(getClusterResponse *cmv1.ClusterGetResponse) bool {
+			iterations.Iteration(
+				tracing.StatusCodeKey.Int(getClusterResponse.Status()),
+				tracing.ClusterStateKey.String(string(getClusterResponse.Body().State())),
+			)
+			return true
+		}).
 		StartContext(pollCtx)
 	sdkErr, ok := err.(*ocm_errors.Error)
 	if ok && sdkErr.Status() == http.StatusNotFound {
@@ -1772,7 +1982,7 @@ func (r *ClusterRosaHcpResource) waitTillClusterIsNotFoundWithTimeout(ctx contex
 	return false, nil
 }
 
-func shouldPatchProperties(state, plan *ClusterRosaHcpState) bool {
+func shouldPatchProperties(state, plan *ClusterRosaHcpState, expectedDefaults map[string]string) bool {
 	// User defined properties needs update
 	if _, should := common.ShouldPatchMap(state.Properties, plan.Properties); should {
 		return true
@@ -1785,11 +1995,11 @@ func shouldPatchProperties(state, plan *ClusterRosaHcpState) bool {
 		}
 	}
 
-	if len(extractedDefaults) != len(rosa.OCMProperties) {
+	if len(extractedDefaults) != len(expectedDefaults) {
 		return true
 	}
 
-	for k, v := range rosa.OCMProperties {
+	for k, v := range expectedDefaults {
 		if _, ok := extractedDefaults[k]; !ok {
 			return true
 		} else if extractedDefaults[k] != v {
@@ -1801,3 +2011,15 @@ func shouldPatchProperties(state, plan *ClusterRosaHcpState) bool {
 	return false
 
 }
+
+// populateState populates the state from the cluster object, leaving out of the properties the
+// ones that come from the default properties of the provider and that aren't configured.
+func (r *ClusterRosaHcpResource) populateState(ctx context.Context, object *cmv1.Cluster, state *ClusterRosaHcpState) error {
+	configuredProperties := state.Properties
+	err := populateRosaHcpClusterState(ctx, object, state)
+	if err != nil {
+		return err
+	}
+	state.Properties, err = r.RemoveDefaultProperties(ctx, state.Properties, configuredProperties)
+	return err
+}
@@ -33,6 +33,7 @@ type ClusterRosaHcpState struct {
 	Tags                                 types.Map    `tfsdk:"tags"`
 	AWSAdditionalComputeSecurityGroupIds types.List   `tfsdk:"aws_additional_compute_security_group_ids"`
 	AWSAdditionalAllowedPrincipals       types.List   `tfsdk:"aws_additional_allowed_principals"`
+	AuditLogArn                          types.String `tfsdk:"audit_log_arn"`
 
 	// Network fields
 	Domain      types.String `tfsdk:"domain"`
@@ -55,16 +56,22 @@ type ClusterRosaHcpState struct {
 	UpgradeAcksFor types.String `tfsdk:"upgrade_acknowledgements_for"`
 
 	// Meta fields - not related to cluster spec
-	DisableWaitingInDestroy            types.Bool  `tfsdk:"disable_waiting_in_destroy"`
-	DestroyTimeout                     types.Int64 `tfsdk:"destroy_timeout"`
-	WaitForCreateComplete              types.Bool  `tfsdk:"wait_for_create_complete"`
-	WaitForStdComputeNodesComplete     types.Bool  `tfsdk:"wait_for_std_compute_nodes_complete"`
-	MaxHCPClusterWaitTimeoutInMinutes  types.Int64 `tfsdk:"max_hcp_cluster_wait_timeout_in_minutes"`
-	MaxMachinePoolWaitTimeoutInMinutes types.Int64 `tfsdk:"max_machinepool_wait_timeout_in_minutes"`
+	DeleteProtection                   types.Bool   `tfsdk:"delete_protection"`
+	DeletionPolicy                     types.String `tfsdk:"deletion_policy"`
+	DisableWaitingInDestroy            types.Bool   `tfsdk:"disable_waiting_in_destroy"`
+	DestroyTimeout                     types.Int64  `tfsdk:"destroy_timeout"`
+	WaitForCreateComplete              types.Bool   `tfsdk:"wait_for_create_complete"`
+	WaitForStdComputeNodesComplete     types.Bool   `tfsdk:"wait_for_std_compute_nodes_complete"`
+	MaxHCPClusterWaitTimeoutInMinutes  types.Int64  `tfsdk:"max_hcp_cluster_wait_timeout_in_minutes"`
+	MaxMachinePoolWaitTimeoutInMinutes types.Int64  `tfsdk:"max_machinepool_wait_timeout_in_minutes"`
+	WaitForUpgradeComplete             types.Bool   `tfsdk:"wait_for_upgrade_complete"`
+	MaxUpgradeWaitTimeoutInMinutes     types.Int64  `tfsdk:"max_upgrade_wait_timeout_in_minutes"`
 
 	// Admin user fields
-	CreateAdminUser  types.Bool   `tfsdk:"create_admin_user"`
-	AdminCredentials types.Object `tfsdk:"admin_credentials"`
+	CreateAdminUser                   types.Bool   `tfsdk:"create_admin_user"`
+	AdminCredentials                  types.Object `tfsdk:"admin_credentials"`
+	AdminCredentialsPasswordWO        types.String `tfsdk:"admin_credentials_password_wo"`
+	AdminCredentialsPasswordWOVersion types.Int64  `tfsdk:"admin_credentials_password_wo_version"`
 
 	// Registry config fields
 	RegistryConfig *registry_config.RegistryConfig `tfsdk:"registry_config"`
@@ -0,0 +1,249 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	rosaTypes "github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/common/types"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+)
+
+// defaultPageSize is the number of clusters requested in each page when the size isn't given.
+const defaultPageSize = 100
+
+type ClustersDataSource struct {
+	collection *cmv1.ClustersClient
+}
+
+var _ datasource.DataSource = &ClustersDataSource{}
+var _ datasource.DataSourceWithConfigure = &ClustersDataSource{}
+
+func New() datasource.DataSource {
+	return &ClustersDataSource{}
+}
+
+func (s *ClustersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_clusters"
+}
+
+func (s *ClustersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "List of clusters.",
+		Attributes: map[string]schema.Attribute{
+			"search": schema.StringAttribute{
+				Description: "Search criteria, for example " +
+					"\"aws.sts.enabled = 't' and region.id = 'us-east-1'\". " +
+					"If not given all the clusters visible to the user are returned.",
+				Optional: true,
+			},
+			"order": schema.StringAttribute{
+				Description: "Order criteria, for example \"name asc\".",
+				Optional:    true,
+			},
+			"page": schema.Int64Attribute{
+				Description: "Number of the page to return, starting with 1. " +
+					"If neither the page nor the size is given all the pages are returned.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"size": schema.Int64Attribute{
+				Description: fmt.Sprintf("Maximum number of clusters in the page. "+
+					"If only the page is given the default is %d.", defaultPageSize),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"total": schema.Int64Attribute{
+				Description: "Total number of clusters that match the search criteria.",
+				Computed:    true,
+			},
+			"item": schema.SingleNestedAttribute{
+				Description: "Content of the list when there is exactly one item.",
+				Attributes:  s.itemAttributes(),
+				Computed:    true,
+			},
+			"items": schema.ListNestedAttribute{
+				Description: "Content of the list.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: s.itemAttributes(),
+				},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (s *ClustersDataSource) itemAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description: "Unique identifier of the cluster.",
+			Computed:    true,
+		},
+		"external_id": schema.StringAttribute{
+			Description: "Unique external identifier of the cluster.",
+			Computed:    true,
+		},
+		"name": schema.StringAttribute{
+			Description: "Name of the cluster.",
+			Computed:    true,
+		},
+		"product": schema.StringAttribute{
+			Description: "Product identifier of the cluster, for example 'rosa'.",
+			Computed:    true,
+		},
+		"topology": schema.StringAttribute{
+			Description: fmt.Sprintf("Topology of the cluster, either '%s' for clusters with a hosted "+
+				"control plane or '%s' for the rest.", rosaTypes.Hcp, rosaTypes.Classic),
+			Computed: true,
+		},
+		"state": schema.StringAttribute{
+			Description: "State of the cluster, for example 'ready'.",
+			Computed:    true,
+		},
+		"version": schema.StringAttribute{
+			Description: "Current OpenShift version of the cluster, for example '4.14.10'.",
+			Computed:    true,
+		},
+		"cloud_region": schema.StringAttribute{
+			Description: "Cloud region identifier, for example 'us-east-1'.",
+			Computed:    true,
+		},
+		"aws_account_id": schema.StringAttribute{
+			Description: "Identifier of the AWS account of the cluster, if any.",
+			Computed:    true,
+		},
+	}
+}
+
+func (s *ClustersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured:
+	if req.ProviderData == nil {
+		return
+	}
+
+	// Cast the provider data to the specific implementation:
+	connection, ok := req.ProviderData.(*sdk.Connection)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	// Get the collection of clusters:
+	s.collection = connection.ClustersMgmt().V1().Clusters()
+}
+
+func (s *ClustersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Get the state:
+	state := &ClustersState{}
+	diags := req.Config.Get(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Fetch the list of clusters. When the page or the size is given only that page is fetched,
+	// otherwise all the pages are fetched:
+	var listItems []*cmv1.Cluster
+	listSize := defaultPageSize
+	listPage := 1
+	singlePage := common.HasValue(state.Page) || common.HasValue(state.Size)
+	if common.HasValue(state.Size) {
+		listSize = int(state.Size.ValueInt64())
+	}
+	if common.HasValue(state.Page) {
+		listPage = int(state.Page.ValueInt64())
+	}
+	listRequest := s.collection.List().Size(listSize)
+	if common.HasValue(state.Search) {
+		listRequest.Search(state.Search.ValueString())
+	}
+	if common.HasValue(state.Order) {
+		listRequest.Order(state.Order.ValueString())
+	}
+	for {
+		listResponse, err := listRequest.Page(listPage).SendContext(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Can't list clusters",
+				err.Error(),
+			)
+			return
+		}
+		if listItems == nil {
+			listItems = make([]*cmv1.Cluster, 0, listResponse.Size())
+		}
+		listResponse.Items().Each(func(listItem *cmv1.Cluster) bool {
+			listItems = append(listItems, listItem)
+			return true
+		})
+		state.Total = types.Int64Value(int64(listResponse.Total()))
+		if singlePage || listResponse.Size() < listSize {
+			break
+		}
+		listPage++
+	}
+
+	// Populate the state:
+	state.Items = make([]*ClusterState, len(listItems))
+	for i, listItem := range listItems {
+		state.Items[i] = populateClusterState(listItem)
+	}
+	if len(state.Items) == 1 {
+		state.Item = state.Items[0]
+	} else {
+		state.Item = nil
+	}
+
+	// Save the state:
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// populateClusterState copies the summary of the given cluster to an item of the list.
+func populateClusterState(object *cmv1.Cluster) *ClusterState {
+	topology := rosaTypes.Classic
+	if object.Hypershift().Enabled() {
+		topology = rosaTypes.Hcp
+	}
+	return &ClusterState{
+		ID:           types.StringValue(object.ID()),
+		ExternalID:   types.StringValue(object.ExternalID()),
+		Name:         types.StringValue(object.Name()),
+		Product:      types.StringValue(object.Product().ID()),
+		Topology:     types.StringValue(string(topology)),
+		State:        types.StringValue(string(object.State())),
+		Version:      types.StringValue(object.Version().RawID()),
+		CloudRegion:  types.StringValue(object.Region().ID()),
+		AWSAccountID: types.StringValue(object.AWS().AccountID()),
+	}
+}
@@ -0,0 +1,278 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterupgradepolicy
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+)
+
+// UpgradePolicy contains the details of an upgrade policy that are common to the upgrade policies of
+// classic clusters and to the control plane upgrade policies of hosted control plane clusters.
+type UpgradePolicy struct {
+	ID                         string
+	ScheduleType               cmv1.ScheduleType
+	Schedule                   string
+	EnableMinorVersionUpgrades bool
+	NextRun                    time.Time
+}
+
+// UpgradePoliciesClient manages the upgrade policies of a cluster, hiding the differences between
+// classic and hosted control plane clusters.
+type UpgradePoliciesClient interface {
+	List(ctx context.Context, clusterID string) ([]*UpgradePolicy, error)
+	Get(ctx context.Context, clusterID, policyID string) (*UpgradePolicy, bool, error)
+	Create(ctx context.Context, clusterID string, policy *UpgradePolicy) (*UpgradePolicy, error)
+	Update(ctx context.Context, clusterID string, policy *UpgradePolicy) (*UpgradePolicy, error)
+	Delete(ctx context.Context, clusterID, policyID string) error
+}
+
+// NewUpgradePoliciesClient returns the client for the upgrade policies of the given cluster.
+func NewUpgradePoliciesClient(collection *cmv1.ClustersClient, cluster *cmv1.Cluster) UpgradePoliciesClient {
+	if cluster.Hypershift().Enabled() {
+		return &controlPlaneUpgradePoliciesClient{collection: collection}
+	}
+	return &classicUpgradePoliciesClient{collection: collection}
+}
+
+type classicUpgradePoliciesClient struct {
+	collection *cmv1.ClustersClient
+}
+
+var _ UpgradePoliciesClient = &classicUpgradePoliciesClient{}
+
+func (c *classicUpgradePoliciesClient) List(ctx context.Context, clusterID string) ([]*UpgradePolicy, error) {
+	result := []*UpgradePolicy{}
+	client := c.collection.Cluster(clusterID).UpgradePolicies()
+	page := 1
+	size := 100
+	for {
+		resp, err := client.List().Page(page).Size(size).SendContext(ctx)
+		if err != nil {
+			return nil, common.HandleErr(resp.Error(), err)
+		}
+		resp.Items().Each(func(policy *cmv1.UpgradePolicy) bool {
+			result = append(result, classicToUpgradePolicy(policy))
+			return true
+		})
+		if resp.Size() < size {
+			break
+		}
+		page++
+	}
+	return result, nil
+}
+
+func (c *classicUpgradePoliciesClient) Get(ctx context.Context, clusterID,
+	policyID string) (*UpgradePolicy, bool, error) {
+	resp, err := c.collection.Cluster(clusterID).UpgradePolicies().UpgradePolicy(policyID).Get().SendContext(ctx)
+	if resp != nil && resp.Status() == http.StatusNotFound {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, common.HandleErr(resp.Error(), err)
+	}
+	return classicToUpgradePolicy(resp.Body()), true, nil
+}
+
+func (c *classicUpgradePoliciesClient) Create(ctx context.Context, clusterID string,
+	policy *UpgradePolicy) (*UpgradePolicy, error) {
+	builder := cmv1.NewUpgradePolicy().
+		UpgradeType(cmv1.UpgradeTypeOSD).
+		ScheduleType(policy.ScheduleType).
+		Schedule(policy.Schedule).
+		EnableMinorVersionUpgrades(policy.EnableMinorVersionUpgrades)
+	if !policy.NextRun.IsZero() {
+		builder.NextRun(policy.NextRun)
+	}
+	object, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.collection.Cluster(clusterID).UpgradePolicies().Add().Body(object).SendContext(ctx)
+	if err != nil {
+		return nil, common.HandleErr(resp.Error(), err)
+	}
+	return classicToUpgradePolicy(resp.Body()), nil
+}
+
+func (c *classicUpgradePoliciesClient) Update(ctx context.Context, clusterID string,
+	policy *UpgradePolicy) (*UpgradePolicy, error) {
+	builder := cmv1.NewUpgradePolicy().
+		Schedule(policy.Schedule).
+		EnableMinorVersionUpgrades(policy.EnableMinorVersionUpgrades)
+	if !policy.NextRun.IsZero() {
+		builder.NextRun(policy.NextRun)
+	}
+	object, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.collection.Cluster(clusterID).UpgradePolicies().UpgradePolicy(policy.ID).Update().
+		Body(object).SendContext(ctx)
+	if err != nil {
+		return nil, common.HandleErr(resp.Error(), err)
+	}
+	return classicToUpgradePolicy(resp.Body()), nil
+}
+
+func (c *classicUpgradePoliciesClient) Delete(ctx context.Context, clusterID, policyID string) error {
+	resp, err := c.collection.Cluster(clusterID).UpgradePolicies().UpgradePolicy(policyID).Delete().
+		SendContext(ctx)
+	if resp != nil && resp.Status() == http.StatusNotFound {
+		return nil
+	}
+	if err != nil {
+		return common.HandleErr(resp.Error(), err)
+	}
+	return nil
+}
+
+func classicToUpgradePolicy(policy *cmv1.UpgradePolicy) *UpgradePolicy {
+	return &UpgradePolicy{
+		ID:                         policy.ID(),
+		ScheduleType:               policy.ScheduleType(),
+		Schedule:                   policy.Schedule(),
+		EnableMinorVersionUpgrades: policy.EnableMinorVersionUpgrades(),
+		NextRun:                    policy.NextRun(),
+	}
+}
+
+type controlPlaneUpgradePoliciesClient struct {
+	collection *cmv1.ClustersClient
+}
+
+var _ UpgradePoliciesClient = &controlPlaneUpgradePoliciesClient{}
+
+func (c *controlPlaneUpgradePoliciesClient) List(ctx context.Context, clusterID string) ([]*UpgradePolicy, error) {
+	result := []*UpgradePolicy{}
+	client := c.collection.Cluster(clusterID).ControlPlane().UpgradePolicies()
+	page := 1
+	size := 100
+	for {
+		resp, err := client.List().Page(page).Size(size).SendContext(ctx)
+		if err != nil {
+			return nil, common.HandleErr(resp.Error(), err)
+		}
+		resp.Items().Each(func(policy *cmv1.ControlPlaneUpgradePolicy) bool {
+			result = append(result, controlPlaneToUpgradePolicy(policy))
+			return true
+		})
+		if resp.Size() < size {
+			break
+		}
+		page++
+	}
+	return result, nil
+}
+
+func (c *controlPlaneUpgradePoliciesClient) Get(ctx context.Context, clusterID,
+	policyID string) (*UpgradePolicy, bool, error) {
+	resp, err := c.collection.Cluster(clusterID).ControlPlane().UpgradePolicies().
+		ControlPlaneUpgradePolicy(policyID).Get().SendContext(ctx)
+	if resp != nil && resp.Status() == http.StatusNotFound {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, common.HandleErr(resp.Error(), err)
+	}
+	return controlPlaneToUpgradePolicy(resp.Body()), true, nil
+}
+
+func (c *controlPlaneUpgradePoliciesClient) Create(ctx context.Context, clusterID string,
+	policy *UpgradePolicy) (*UpgradePolicy, error) {
+	builder := cmv1.NewControlPlaneUpgradePolicy().
+		UpgradeType(cmv1.UpgradeTypeControlPlane).
+		ScheduleType(policy.ScheduleType).
+		Schedule(policy.Schedule).
+		EnableMinorVersionUpgrades(policy.EnableMinorVersionUpgrades)
+	if !policy.NextRun.IsZero() {
+		builder.NextRun(policy.NextRun)
+	}
+	object, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.collection.Cluster(clusterID).ControlPlane().UpgradePolicies().Add().Body(object).
+		SendContext(ctx)
+	if err != nil {
+		return nil, common.HandleErr(resp.Error(), err)
+	}
+	return controlPlaneToUpgradePolicy(resp.Body()), nil
+}
+
+func (c *controlPlaneUpgradePoliciesClient) Update(ctx context.Context, clusterID string,
+	policy *UpgradePolicy) (*UpgradePolicy, error) {
+	builder := cmv1.NewControlPlaneUpgradePolicy().
+		Schedule(policy.Schedule).
+		EnableMinorVersionUpgrades(policy.EnableMinorVersionUpgrades)
+	if !policy.NextRun.IsZero() {
+		builder.NextRun(policy.NextRun)
+	}
+	object, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.collection.Cluster(clusterID).ControlPlane().UpgradePolicies().
+		ControlPlaneUpgradePolicy(policy.ID).Update().Body(object).SendContext(ctx)
+	if err != nil {
+		return nil, common.HandleErr(resp.Error(), err)
+	}
+	return controlPlaneToUpgradePolicy(resp.Body()), nil
+}
+
+func (c *controlPlaneUpgradePoliciesClient) Delete(ctx context.Context, clusterID, policyID string) error {
+	resp, err := c.collection.Cluster(clusterID).ControlPlane().UpgradePolicies().
+		ControlPlaneUpgradePolicy(policyID).Delete().SendContext(ctx)
+	if resp != nil && resp.Status() == http.StatusNotFound {
+		return nil
+	}
+	if err != nil {
+		return common.HandleErr(resp.Error(), err)
+	}
+	return nil
+}
+
+func controlPlaneToUpgradePolicy(policy *cmv1.ControlPlaneUpgradePolicy) *UpgradePolicy {
+	return &UpgradePolicy{
+		ID:                         policy.ID(),
+		ScheduleType:               policy.ScheduleType(),
+		Schedule:                   policy.Schedule(),
+		EnableMinorVersionUpgrades: policy.EnableMinorVersionUpgrades(),
+		NextRun:                    policy.NextRun(),
+	}
+}
+
+// findAutomaticPolicy returns the automatic upgrade policy of the cluster, as there can be at most
+// one of them. It returns nil if the cluster doesn't have an automatic upgrade policy.
+func findAutomaticPolicy(ctx context.Context, client UpgradePoliciesClient,
+	clusterID string) (*UpgradePolicy, error) {
+	policies, err := client.List(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range policies {
+		if policy.ScheduleType == cmv1.ScheduleTypeAutomatic {
+			return policy, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,406 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterupgradepolicy
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+)
+
+const (
+	resourceTypeName      = "_cluster_upgrade_policy"
+	failedToCreateSummary = "Failed to create cluster upgrade policy"
+	failedToUpdateSummary = "Failed to update cluster upgrade policy"
+	failedToDeleteSummary = "Failed to delete cluster upgrade policy"
+	failedToReadSummary   = "Failed to read cluster upgrade policy"
+	failedToImportSummary = "Failed to import cluster upgrade policy"
+)
+
+type ClusterUpgradePolicyResource struct {
+	collection    *cmv1.ClustersClient
+	clusterClient common.ClusterClient
+	clusterWait   common.ClusterWait
+}
+
+// Interface checks
+var _ resource.Resource = &ClusterUpgradePolicyResource{}
+var _ resource.ResourceWithConfigure = &ClusterUpgradePolicyResource{}
+var _ resource.ResourceWithImportState = &ClusterUpgradePolicyResource{}
+
+func New() resource.Resource {
+	return &ClusterUpgradePolicyResource{}
+}
+
+func (r *ClusterUpgradePolicyResource) Metadata(_ context.Context, request resource.MetadataRequest,
+	response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + resourceTypeName
+}
+
+func (r *ClusterUpgradePolicyResource) Schema(_ context.Context, _ resource.SchemaRequest,
+	resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the recurring automatic upgrade policy of a cluster. For clusters with hosted " +
+			"control planes the policy applies to the control plane, for classic clusters it applies to the " +
+			"whole cluster.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Unique identifier of the upgrade policy.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster": schema.StringAttribute{
+				Description: "Identifier of the cluster." + common.ValueCannotBeChangedStringDescription,
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`.*\S.*`),
+						"cluster ID may not be empty/blank string"),
+				},
+			},
+			"schedule": schema.StringAttribute{
+				Description: "Cron expression, in UTC, of the recurring schedule used to check for and " +
+					"apply available upgrades, for example `0 2 * * 1` for every Monday at 02:00.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^\S+( \S+){4}$`),
+						"schedule must be a cron expression with five fields"),
+				},
+			},
+			"enable_minor_version_upgrades": schema.BoolAttribute{
+				Description: "Indicates if minor version upgrades are allowed in addition to patch upgrades. " +
+					"Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"maintenance_window_start": schema.StringAttribute{
+				Description: "Start of the first maintenance window, in RFC3339 format. No upgrade will be " +
+					"applied before this time.",
+				Optional: true,
+				Validators: []validator.String{
+					timestampValidator{},
+				},
+			},
+			"next_run": schema.StringAttribute{
+				Description: "Time, in RFC3339 format, of the next scheduled upgrade.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *ClusterUpgradePolicyResource) Configure(_ context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connection, ok := req.ProviderData.(*sdk.Connection)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.collection = connection.ClustersMgmt().V1().Clusters()
+	r.clusterClient = common.NewClusterClient(r.collection)
+	r.clusterWait = common.NewClusterWait(r.collection, connection)
+}
+
+func (r *ClusterUpgradePolicyResource) Create(ctx context.Context, req resource.CreateRequest,
+	resp *resource.CreateResponse) {
+	plan := &ClusterUpgradePolicyState{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterId := plan.Cluster.ValueString()
+	waitTimeoutInMinutes := int64(60)
+	cluster, err := r.clusterWait.WaitForClusterToBeReady(ctx, clusterId, waitTimeoutInMinutes)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cluster is not ready",
+			fmt.Sprintf("Cluster with id '%s' is not in the ready state: %v", clusterId, err),
+		)
+		return
+	}
+	client := NewUpgradePoliciesClient(r.collection, cluster)
+
+	common.ClusterMutexKV.Lock(clusterId)
+	defer common.ClusterMutexKV.Unlock(clusterId)
+
+	existing, err := findAutomaticPolicy(ctx, client, clusterId)
+	if err != nil {
+		resp.Diagnostics.AddError(failedToCreateSummary,
+			fmt.Sprintf("Cannot list upgrade policies for cluster '%s': %v", clusterId, err))
+		return
+	}
+	if existing != nil {
+		resp.Diagnostics.AddError(failedToCreateSummary,
+			fmt.Sprintf("Cluster '%s' already has automatic upgrade policy '%s', import it instead",
+				clusterId, existing.ID))
+		return
+	}
+
+	policy := planToUpgradePolicy(plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	policy.ScheduleType = cmv1.ScheduleTypeAutomatic
+	created, err := client.Create(ctx, clusterId, policy)
+	if err != nil {
+		resp.Diagnostics.AddError(failedToCreateSummary,
+			fmt.Sprintf("Failed to create upgrade policy for cluster '%s': %v", clusterId, err))
+		return
+	}
+
+	populateState(created, plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *ClusterUpgradePolicyResource) Read(ctx context.Context, req resource.ReadRequest,
+	resp *resource.ReadResponse) {
+	state := &ClusterUpgradePolicyState{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterId := state.Cluster.ValueString()
+	client, err := r.policiesClient(ctx, clusterId)
+	if err != nil {
+		resp.Diagnostics.AddError(failedToReadSummary, err.Error())
+		return
+	}
+
+	var policy *UpgradePolicy
+	if common.IsStringAttributeUnknownOrEmpty(state.ID) {
+		// The resource has been imported using only the cluster identifier:
+		policy, err = findAutomaticPolicy(ctx, client, clusterId)
+		if err != nil {
+			resp.Diagnostics.AddError(failedToReadSummary,
+				fmt.Sprintf("Cannot list upgrade policies for cluster '%s': %v", clusterId, err))
+			return
+		}
+		if policy == nil {
+			resp.Diagnostics.AddError(failedToReadSummary,
+				fmt.Sprintf("Cluster '%s' doesn't have an automatic upgrade policy", clusterId))
+			return
+		}
+	} else {
+		var exists bool
+		policy, exists, err = client.Get(ctx, clusterId, state.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(failedToReadSummary,
+				fmt.Sprintf("Cannot read upgrade policy '%s' for cluster '%s': %v",
+					state.ID.ValueString(), clusterId, err))
+			return
+		}
+		if !exists {
+			tflog.Warn(ctx, fmt.Sprintf("upgrade policy (%s) of cluster (%s) not found, removing from state",
+				state.ID.ValueString(), clusterId))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+	}
+
+	if policy.ScheduleType != cmv1.ScheduleTypeAutomatic {
+		resp.Diagnostics.AddError(failedToReadSummary,
+			fmt.Sprintf("Upgrade policy '%s' of cluster '%s' has schedule type '%s', only '%s' "+
+				"upgrade policies are supported", policy.ID, clusterId, policy.ScheduleType,
+				cmv1.ScheduleTypeAutomatic))
+		return
+	}
+
+	populateState(policy, state)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *ClusterUpgradePolicyResource) Update(ctx context.Context, req resource.UpdateRequest,
+	resp *resource.UpdateResponse) {
+	state := &ClusterUpgradePolicyState{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	plan := &ClusterUpgradePolicyState{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterId := state.Cluster.ValueString()
+	common.ClusterMutexKV.Lock(clusterId)
+	defer common.ClusterMutexKV.Unlock(clusterId)
+
+	client, err := r.policiesClient(ctx, clusterId)
+	if err != nil {
+		resp.Diagnostics.AddError(failedToUpdateSummary, err.Error())
+		return
+	}
+
+	policy := planToUpgradePolicy(plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// The maintenance window start is only sent when it changes, otherwise an update of the schedule
+	// would move the next run back to a time that may already be in the past:
+	if plan.MaintenanceWindowStart.Equal(state.MaintenanceWindowStart) {
+		policy.NextRun = time.Time{}
+	}
+	policy.ID = state.ID.ValueString()
+	updated, err := client.Update(ctx, clusterId, policy)
+	if err != nil {
+		resp.Diagnostics.AddError(failedToUpdateSummary,
+			fmt.Sprintf("Failed to update upgrade policy '%s' for cluster '%s': %v", policy.ID, clusterId, err))
+		return
+	}
+
+	populateState(updated, plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *ClusterUpgradePolicyResource) Delete(ctx context.Context, req resource.DeleteRequest,
+	resp *resource.DeleteResponse) {
+	state := &ClusterUpgradePolicyState{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterId := state.Cluster.ValueString()
+	common.ClusterMutexKV.Lock(clusterId)
+	defer common.ClusterMutexKV.Unlock(clusterId)
+
+	client, err := r.policiesClient(ctx, clusterId)
+	if err != nil {
+		resp.Diagnostics.AddError(failedToDeleteSummary, err.Error())
+		return
+	}
+	if err := client.Delete(ctx, clusterId, state.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(failedToDeleteSummary,
+			fmt.Sprintf("Failed to delete upgrade policy '%s' for cluster '%s': %v",
+				state.ID.ValueString(), clusterId, err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState accepts either the identifier of the cluster, in which case the automatic upgrade
+// policy of the cluster is imported, or the identifiers of the cluster and the policy separated by
+// a comma.
+func (r *ClusterUpgradePolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+	fields := strings.Split(req.ID, ",")
+	if len(fields) > 2 || strings.TrimSpace(fields[0]) == "" ||
+		(len(fields) == 2 && strings.TrimSpace(fields[1]) == "") {
+		resp.Diagnostics.AddError(failedToImportSummary,
+			fmt.Sprintf("Expected import identifier with format 'cluster_id' or 'cluster_id,policy_id', "+
+				"got '%s'", req.ID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster"), fields[0])...)
+	if len(fields) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), fields[1])...)
+	}
+}
+
+func (r *ClusterUpgradePolicyResource) policiesClient(ctx context.Context,
+	clusterId string) (UpgradePoliciesClient, error) {
+	cluster, err := r.clusterClient.FetchCluster(ctx, clusterId)
+	if err != nil {
+		return nil, err
+	}
+	return NewUpgradePoliciesClient(r.collection, cluster), nil
+}
+
+func planToUpgradePolicy(plan *ClusterUpgradePolicyState, diags *diag.Diagnostics) *UpgradePolicy {
+	policy := &UpgradePolicy{
+		Schedule:                   plan.Schedule.ValueString(),
+		EnableMinorVersionUpgrades: plan.EnableMinorVersionUpgrades.ValueBool(),
+	}
+	if common.HasValue(plan.MaintenanceWindowStart) {
+		nextRun, err := time.Parse(time.RFC3339, plan.MaintenanceWindowStart.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("maintenance_window_start"), "Invalid maintenance window start",
+				err.Error())
+			return nil
+		}
+		policy.NextRun = nextRun
+	}
+	return policy
+}
+
+// populateState copies the values returned by the server into the state. The maintenance window
+// start isn't copied because the server replaces it with the next run once the schedule is computed.
+func populateState(policy *UpgradePolicy, state *ClusterUpgradePolicyState) {
+	state.ID = types.StringValue(policy.ID)
+	state.Schedule = types.StringValue(policy.Schedule)
+	state.EnableMinorVersionUpgrades = types.BoolValue(policy.EnableMinorVersionUpgrades)
+	if policy.NextRun.IsZero() {
+		state.NextRun = types.StringNull()
+	} else {
+		state.NextRun = types.StringValue(policy.NextRun.UTC().Format(time.RFC3339))
+	}
+}
+
+type timestampValidator struct{}
+
+var _ validator.String = timestampValidator{}
+
+func (v timestampValidator) Description(_ context.Context) string {
+	return "value must be a timestamp in RFC3339 format"
+}
+
+func (v timestampValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timestampValidator) ValidateString(_ context.Context, req validator.StringRequest,
+	resp *validator.StringResponse) {
+	if !common.HasValue(req.ConfigValue) {
+		return
+	}
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid timestamp",
+			fmt.Sprintf("Value '%s' isn't a valid RFC3339 timestamp: %v", req.ConfigValue.ValueString(), err))
+	}
+}
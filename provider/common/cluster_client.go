@@ -3,6 +3,8 @@ package common
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 )
@@ -29,3 +31,46 @@ func (c *DefaultClusterClient) FetchCluster(ctx context.Context, clusterId strin
 		return clusterResp.Body(), nil
 	}
 }
+
+// ResolveClusterID returns the identifier of the cluster that matches the given key. The key can be
+// the identifier of the cluster, its name or its external identifier. The identifier is tried first,
+// and if there is no cluster with that identifier the clusters are searched by name and external
+// identifier. It is an error if no cluster matches or if more than one cluster matches.
+func ResolveClusterID(ctx context.Context, collection *cmv1.ClustersClient, key string) (string, error) {
+	getResponse, err := collection.Cluster(key).Get().SendContext(ctx)
+	if getResponse.Status() != http.StatusNotFound {
+		if err != nil {
+			return "", fmt.Errorf("can't get cluster '%s': %v", key, err)
+		}
+		return getResponse.Body().ID(), nil
+	}
+
+	quoted := strings.ReplaceAll(key, "'", "''")
+	query := fmt.Sprintf("name = '%s' or external_id = '%s'", quoted, quoted)
+	listResponse, err := collection.List().Search(query).Size(clusterSearchSize).SendContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("can't search for cluster '%s': %v", key, err)
+	}
+	switch listResponse.Total() {
+	case 0:
+		return "", fmt.Errorf("cluster '%s' not found: there is no cluster with that identifier, "+
+			"name or external identifier", key)
+	case 1:
+		return listResponse.Items().Get(0).ID(), nil
+	default:
+		ids := []string{}
+		listResponse.Items().Each(func(cluster *cmv1.Cluster) bool {
+			ids = append(ids, cluster.ID())
+			return true
+		})
+		if listResponse.Total() > len(ids) {
+			ids = append(ids, "...")
+		}
+		return "", fmt.Errorf("there are %d clusters with name or external identifier '%s' (%s), "+
+			"use the identifier of the cluster instead", listResponse.Total(), key, strings.Join(ids, ", "))
+	}
+}
+
+// clusterSearchSize is the maximum number of clusters listed when reporting that a cluster name or
+// external identifier is ambiguous.
+const clusterSearchSize = 10
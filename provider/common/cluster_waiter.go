@@ -9,6 +9,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/tracing"
 )
 
 const pollingIntervalInMinutes = 2
@@ -128,6 +131,8 @@ func (dw *DefaultClusterWait) WaitForClusterToBeReady(ctx context.Context, clust
 }
 
 func pollClusterCurrentCompute(clusterId string, ctx context.Context, timeout int64, clusterCollection *cmv1.ClustersClient) (*cmv1.Cluster, error) {
+	ctx, span := tracing.StartSpan(ctx, "wait for cluster compute nodes", tracing.ClusterIDKey.String(clusterId))
+	iterations := tracing.NewPollSpans(ctx, "poll cluster compute nodes", tracing.ClusterIDKey.String(clusterId))
 	client := clusterCollection.Cluster(clusterId)
 	var object *cmv1.Cluster
 	pollCtx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Minute)
@@ -139,6 +144,10 @@ func pollClusterCurrentCompute(clusterId string, ctx context.Context, timeout in
 			tflog.Debug(ctx, "polled cluster compute", map[string]interface{}{
 				"currentCompute": object.Status().CurrentCompute(),
 			})
+			iterations.Iteration(
+				tracing.ClusterStateKey.String(string(object.State())),
+				attribute.Int("cluster.current_compute", object.Status().CurrentCompute()),
+			)
 			switch object.Status().CurrentCompute() {
 			case object.Nodes().Compute():
 				return true
@@ -146,6 +155,7 @@ func pollClusterCurrentCompute(clusterId string, ctx context.Context, timeout in
 			return false
 		}).
 		StartContext(pollCtx)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		tflog.Error(ctx, "Failed polling cluster compute")
 		return nil, err
@@ -155,6 +165,8 @@ func pollClusterCurrentCompute(clusterId string, ctx context.Context, timeout in
 }
 
 func pollClusterState(clusterId string, ctx context.Context, timeout int64, clusterCollection *cmv1.ClustersClient) (*cmv1.Cluster, error) {
+	ctx, span := tracing.StartSpan(ctx, "wait for cluster state", tracing.ClusterIDKey.String(clusterId))
+	iterations := tracing.NewPollSpans(ctx, "poll cluster state", tracing.ClusterIDKey.String(clusterId))
 	client := clusterCollection.Cluster(clusterId)
 	var object *cmv1.Cluster
 	pollCtx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Minute)
@@ -166,6 +178,9 @@ func pollClusterState(clusterId string, ctx context.Context, timeout int64, clus
 			tflog.Debug(ctx, "polled cluster state", map[string]interface{}{
 				"state": object.State(),
 			})
+			iterations.Iteration(
+				tracing.ClusterStateKey.String(string(object.State())),
+			)
 			switch object.State() {
 			case cmv1.ClusterStateReady,
 				cmv1.ClusterStateError,
@@ -175,6 +190,7 @@ func pollClusterState(clusterId string, ctx context.Context, timeout int64, clus
 			return false
 		}).
 		StartContext(pollCtx)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		tflog.Error(ctx, "Failed polling cluster state")
 		return nil, err
@@ -0,0 +1,44 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	DeletionPolicyDelete = "delete"
+	DeletionPolicyRetain = "retain"
+
+	DeletionPolicyStringDescription = "What happens to the %s in OCM when the resource is destroyed or removed " +
+		"from the configuration. With `delete` it is deleted. With `retain` it is only removed from the " +
+		"Terraform state and kept in OCM, for example to manage it from a different state. The default value is `delete`."
+)
+
+// DeletionPolicyDescription returns the description of the `deletion_policy` attribute of the
+// resource that manages the given kind of object.
+func DeletionPolicyDescription(kind string) string {
+	return fmt.Sprintf(DeletionPolicyStringDescription, kind)
+}
+
+// ShouldRetain returns true if the deletion policy requires keeping the object in OCM when the
+// resource is deleted.
+func ShouldRetain(deletionPolicy types.String) bool {
+	return HasValue(deletionPolicy) && deletionPolicy.ValueString() == DeletionPolicyRetain
+}
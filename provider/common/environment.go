@@ -0,0 +1,96 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"sort"
+	"strings"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	"github.com/openshift-online/ocm-sdk-go/authentication"
+)
+
+const (
+	EnvironmentProduction         = "production"
+	EnvironmentStage              = "stage"
+	EnvironmentIntegration        = "integration"
+	EnvironmentFedRAMPProduction  = "fedramp-production"
+	EnvironmentFedRAMPStage       = "fedramp-stage"
+	EnvironmentFedRAMPIntegration = "fedramp-integration"
+)
+
+// Environment describes the endpoints of an OCM environment.
+type Environment struct {
+	URL      string
+	TokenURL string
+	// ClientID is the OpenID client that has to be used for the environment, empty if the
+	// default one should be used.
+	ClientID string
+}
+
+// Environments contains the known OCM environments indexed by name.
+var Environments = map[string]Environment{
+	EnvironmentProduction: {
+		URL:      sdk.DefaultURL,
+		TokenURL: sdk.DefaultTokenURL,
+	},
+	EnvironmentStage: {
+		URL:      "https://api.stage.openshift.com",
+		TokenURL: sdk.DefaultTokenURL,
+	},
+	EnvironmentIntegration: {
+		URL:      "https://api.integration.openshift.com",
+		TokenURL: sdk.DefaultTokenURL,
+	},
+	EnvironmentFedRAMPProduction: {
+		URL:      sdk.FedRAMPURL,
+		TokenURL: authentication.FedRAMPTokenURL,
+		ClientID: authentication.FedRAMPClientID,
+	},
+	EnvironmentFedRAMPStage: {
+		URL:      "https://api.stage.openshiftusgov.com",
+		TokenURL: "https://sso.stage.openshiftusgov.com/realms/redhat-external/protocol/openid-connect/token",
+		ClientID: authentication.FedRAMPClientID,
+	},
+	EnvironmentFedRAMPIntegration: {
+		URL:      "https://api.int.openshiftusgov.com",
+		TokenURL: "https://sso.int.openshiftusgov.com/realms/redhat-external/protocol/openid-connect/token",
+		ClientID: authentication.FedRAMPClientID,
+	},
+}
+
+// EnvironmentNames returns the sorted names of the known OCM environments.
+func EnvironmentNames() []string {
+	names := make([]string, 0, len(Environments))
+	for name := range Environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EnvironmentFromURL returns the name of the OCM environment that uses the given API URL, or an
+// empty string if it doesn't match any of the known environments.
+func EnvironmentFromURL(url string) string {
+	url = strings.TrimSuffix(url, "/")
+	for name, env := range Environments {
+		if env.URL == url {
+			return name
+		}
+	}
+	return ""
+}
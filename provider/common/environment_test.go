@@ -0,0 +1,29 @@
+package common
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+var _ = Describe("Environment tests", func() {
+	It("Should resolve the environment of known URLs", func() {
+		Expect(EnvironmentFromURL("https://api.openshift.com")).To(Equal(EnvironmentProduction))
+		Expect(EnvironmentFromURL("https://api.stage.openshift.com/")).To(Equal(EnvironmentStage))
+		Expect(EnvironmentFromURL("https://api.integration.openshift.com")).To(Equal(EnvironmentIntegration))
+		Expect(EnvironmentFromURL("https://api.openshiftusgov.com")).To(Equal(EnvironmentFedRAMPProduction))
+		Expect(EnvironmentFromURL("https://api.int.openshiftusgov.com")).To(Equal(EnvironmentFedRAMPIntegration))
+	})
+	It("Should return an empty name for unknown URLs", func() {
+		Expect(EnvironmentFromURL("http://localhost:8000")).To(BeEmpty())
+	})
+	It("Should return the names sorted", func() {
+		Expect(EnvironmentNames()).To(Equal([]string{
+			EnvironmentFedRAMPIntegration,
+			EnvironmentFedRAMPProduction,
+			EnvironmentFedRAMPStage,
+			EnvironmentIntegration,
+			EnvironmentProduction,
+			EnvironmentStage,
+		}))
+	})
+})
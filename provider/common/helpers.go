@@ -138,6 +138,21 @@ func IsGreaterThanOrEqual(version1, version2 string) (bool, error) {
 	return v1.GreaterThanOrEqual(v2), nil
 }
 
+// GetOcmVersionMinor returns the major and minor segments of the given version, for example
+// `4.14` for `4.14.2`.
+func GetOcmVersionMinor(ver string) string {
+	v, err := version.NewVersion(ver)
+	if err != nil {
+		segments := strings.Split(ver, ".")
+		if len(segments) < 2 {
+			return ver
+		}
+		return fmt.Sprintf("%s.%s", segments[0], segments[1])
+	}
+	segments := v.Segments()
+	return fmt.Sprintf("%d.%d", segments[0], segments[1])
+}
+
 func HandleErr(res *ocmerrors.Error, err error) error {
 	msg := res.Reason()
 	if msg == "" {
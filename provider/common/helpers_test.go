@@ -51,3 +51,34 @@ var _ = Describe("Helper function tests", func() {
 		})
 	})
 })
+
+var _ = Describe("Provider defaults", func() {
+	defaults := map[string]string{
+		"cost-center": "123",
+		"owner":       "team",
+	}
+
+	It("Should merge the defaults giving precedence to the values", func() {
+		Expect(MergeDefaults(defaults, map[string]string{"owner": "me", "env": "dev"})).To(Equal(map[string]string{
+			"cost-center": "123",
+			"owner":       "me",
+			"env":         "dev",
+		}))
+		Expect(MergeDefaults(defaults, nil)).To(Equal(defaults))
+		Expect(MergeDefaults(nil, nil)).To(BeNil())
+	})
+
+	It("Should remove the values that come from the defaults", func() {
+		values := map[string]string{
+			"cost-center": "123",
+			"owner":       "someone-else",
+			"env":         "dev",
+		}
+		Expect(RemoveDefaults(defaults, values, nil)).To(Equal(map[string]string{
+			"owner": "someone-else",
+			"env":   "dev",
+		}))
+		Expect(RemoveDefaults(defaults, values, map[string]string{"cost-center": "123"})).To(Equal(values))
+		Expect(RemoveDefaults(nil, values, nil)).To(Equal(values))
+	})
+})
@@ -4,6 +4,10 @@ import (
 	"sync"
 )
 
+// ClusterMutexKV serializes the changes that the resources belonging to a cluster send to OCM,
+// as OCM rejects concurrent changes to the same cluster. The key is the cluster identifier.
+var ClusterMutexKV = NewMutexKV()
+
 type mutexKV struct {
 	lock  sync.Mutex
 	store map[string]*sync.Mutex
@@ -0,0 +1,85 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"sync"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+)
+
+// ProviderDefaults contains the values of the provider configuration that are applied to the
+// attributes of the resources.
+type ProviderDefaults struct {
+	// Tags are added to the AWS tags of clusters and machine pools.
+	Tags map[string]string
+	// Properties are added to the properties of clusters.
+	Properties map[string]string
+}
+
+// providerDefaults contains the defaults of each configured provider, indexed by the connection
+// that the provider passes to the resources.
+var providerDefaults sync.Map
+
+// SetProviderDefaults saves the defaults of the provider that created the given connection.
+func SetProviderDefaults(connection *sdk.Connection, defaults ProviderDefaults) {
+	providerDefaults.Store(connection, defaults)
+}
+
+// GetProviderDefaults returns the defaults of the provider that created the given connection.
+func GetProviderDefaults(connection *sdk.Connection) ProviderDefaults {
+	if defaults, ok := providerDefaults.Load(connection); ok {
+		return defaults.(ProviderDefaults)
+	}
+	return ProviderDefaults{}
+}
+
+// MergeDefaults returns a new map containing the defaults and the given values. The given values
+// take precedence over the defaults. It returns nil if both maps are empty.
+func MergeDefaults(defaults map[string]string, values map[string]string) map[string]string {
+	if len(defaults) == 0 && len(values) == 0 {
+		return values
+	}
+	result := make(map[string]string, len(defaults)+len(values))
+	for k, v := range defaults {
+		result[k] = v
+	}
+	for k, v := range values {
+		result[k] = v
+	}
+	return result
+}
+
+// RemoveDefaults returns a new map without the values that come from the defaults, so that they
+// don't cause a difference with the configuration. A value is kept if it is also present in the
+// configured values, or if it was changed after being set from the defaults.
+func RemoveDefaults(defaults map[string]string, values map[string]string,
+	configured map[string]string) map[string]string {
+	if len(defaults) == 0 {
+		return values
+	}
+	result := make(map[string]string, len(values))
+	for k, v := range values {
+		if defaultValue, isDefault := defaults[k]; isDefault && defaultValue == v {
+			if _, isConfigured := configured[k]; !isConfigured {
+				continue
+			}
+		}
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,123 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// RawStateTransform modifies the JSON representation of the state saved by a previous version of
+// the schema of a resource, so that it matches the current version.
+type RawStateTransform func(ctx context.Context, state map[string]interface{}) error
+
+// RawStateUpgrader returns a state upgrader that applies the given transformations to the JSON
+// representation of the saved state and then reads it using the current schema. Attributes that
+// aren't part of the current schema are discarded, and new attributes are initialized to null, so
+// the transformations only need to handle attributes that were renamed or moved.
+func RawStateUpgrader(transforms ...RawStateTransform) resource.StateUpgrader {
+	return resource.StateUpgrader{
+		StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest,
+			resp *resource.UpgradeStateResponse) {
+			if req.RawState == nil || req.RawState.JSON == nil {
+				resp.Diagnostics.AddError(
+					"Can't upgrade state",
+					"The saved state doesn't contain a JSON representation",
+				)
+				return
+			}
+			state := map[string]interface{}{}
+			err := json.Unmarshal(req.RawState.JSON, &state)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Can't upgrade state",
+					fmt.Sprintf("Can't parse saved state: %v", err),
+				)
+				return
+			}
+			for _, transform := range transforms {
+				err = transform(ctx, state)
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Can't upgrade state",
+						fmt.Sprintf("Can't transform saved state: %v", err),
+					)
+					return
+				}
+			}
+			data, err := json.Marshal(state)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Can't upgrade state",
+					fmt.Sprintf("Can't serialize upgraded state: %v", err),
+				)
+				return
+			}
+			raw := tfprotov6.RawState{JSON: data}
+			value, err := raw.UnmarshalWithOpts(
+				resp.State.Schema.Type().TerraformType(ctx),
+				tfprotov6.UnmarshalOpts{
+					ValueFromJSONOpts: tftypes.ValueFromJSONOpts{
+						IgnoreUndefinedAttributes: true,
+					},
+				},
+			)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Can't upgrade state",
+					fmt.Sprintf("Can't read upgraded state with the current schema: %v", err),
+				)
+				return
+			}
+			resp.State.Raw = value
+		},
+	}
+}
+
+// MoveStateAttributes returns a transformation that moves the given top level attributes of the
+// saved state into the given nested object attribute, using the new names given in the map. The
+// nested object is only created if at least one of the attributes has a value, and it isn't
+// modified if it already exists.
+func MoveStateAttributes(object string, names map[string]string) RawStateTransform {
+	return func(ctx context.Context, state map[string]interface{}) error {
+		nested := map[string]interface{}{}
+		found := false
+		for oldName, newName := range names {
+			value, ok := state[oldName]
+			if !ok {
+				continue
+			}
+			delete(state, oldName)
+			if value != nil {
+				found = true
+			}
+			nested[newName] = value
+		}
+		if _, exists := state[object]; exists && state[object] != nil {
+			return nil
+		}
+		if found {
+			state[object] = nested
+		}
+		return nil
+	}
+}
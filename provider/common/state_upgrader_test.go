@@ -0,0 +1,119 @@
+package common
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+var _ = Describe("Raw state upgrader", func() {
+	type autoscaling struct {
+		Enabled     types.Bool  `tfsdk:"enabled"`
+		MinReplicas types.Int64 `tfsdk:"min_replicas"`
+	}
+	type pool struct {
+		Name        types.String `tfsdk:"name"`
+		Replicas    types.Int64  `tfsdk:"replicas"`
+		Autoscaling *autoscaling `tfsdk:"autoscaling"`
+	}
+	poolSchema := schema.Schema{
+		Version: 1,
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"replicas": schema.Int64Attribute{
+				Optional: true,
+			},
+			"autoscaling": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						Required: true,
+					},
+					"min_replicas": schema.Int64Attribute{
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+
+	upgrade := func(upgrader resource.StateUpgrader, json string) (*pool, *resource.UpgradeStateResponse) {
+		ctx := context.Background()
+		req := resource.UpgradeStateRequest{
+			RawState: &tfprotov6.RawState{JSON: []byte(json)},
+		}
+		resp := &resource.UpgradeStateResponse{
+			State: tfsdk.State{Schema: poolSchema},
+		}
+		upgrader.StateUpgrader(ctx, req, resp)
+		if resp.Diagnostics.HasError() {
+			return nil, resp
+		}
+		result := &pool{}
+		resp.Diagnostics.Append(resp.State.Get(ctx, result)...)
+		return result, resp
+	}
+
+	It("Discards removed attributes and initializes new ones to null", func() {
+		result, resp := upgrade(RawStateUpgrader(), `{
+		  "name": "my-pool",
+		  "removed": "junk"
+		}`)
+		Expect(resp.Diagnostics).To(BeEmpty())
+		Expect(result.Name.ValueString()).To(Equal("my-pool"))
+		Expect(result.Replicas.IsNull()).To(BeTrue())
+		Expect(result.Autoscaling).To(BeNil())
+	})
+
+	It("Moves top level attributes into a nested attribute", func() {
+		result, resp := upgrade(
+			RawStateUpgrader(
+				MoveStateAttributes("autoscaling", map[string]string{
+					"autoscaling_enabled": "enabled",
+					"min_replicas":        "min_replicas",
+				}),
+			),
+			`{
+			  "name": "my-pool",
+			  "autoscaling_enabled": true,
+			  "min_replicas": 2
+			}`,
+		)
+		Expect(resp.Diagnostics).To(BeEmpty())
+		Expect(result.Autoscaling).ToNot(BeNil())
+		Expect(result.Autoscaling.Enabled.ValueBool()).To(BeTrue())
+		Expect(result.Autoscaling.MinReplicas.ValueInt64()).To(BeEquivalentTo(2))
+	})
+
+	It("Doesn't replace an existing nested attribute", func() {
+		result, resp := upgrade(
+			RawStateUpgrader(
+				MoveStateAttributes("autoscaling", map[string]string{
+					"autoscaling_enabled": "enabled",
+				}),
+			),
+			`{
+			  "name": "my-pool",
+			  "autoscaling": {
+			    "enabled": false
+			  }
+			}`,
+		)
+		Expect(resp.Diagnostics).To(BeEmpty())
+		Expect(result.Autoscaling.Enabled.ValueBool()).To(BeFalse())
+		Expect(result.Autoscaling.MinReplicas.IsNull()).To(BeTrue())
+	})
+
+	It("Fails if the saved state isn't valid JSON", func() {
+		_, resp := upgrade(RawStateUpgrader(), `junk`)
+		Expect(resp.Diagnostics.HasError()).To(BeTrue())
+	})
+})
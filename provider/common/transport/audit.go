@@ -0,0 +1,198 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// RedactedValue is the value written to the audit log instead of the sensitive values.
+const RedactedValue = "REDACTED"
+
+// sensitiveFields contains the names of the fields of the request and response bodies whose
+// values are never written to the audit log.
+var sensitiveFields = map[string]bool{
+	"access_token":      true,
+	"bind_password":     true,
+	"client_secret":     true,
+	"hashed_password":   true,
+	"id_token":          true,
+	"kubeconfig":        true,
+	"password":          true,
+	"refresh_token":     true,
+	"secret":            true,
+	"secret_access_key": true,
+	"token":             true,
+}
+
+// AuditRecord is the line written to the audit log for each request.
+type AuditRecord struct {
+	Time         time.Time       `json:"time"`
+	Method       string          `json:"method"`
+	Path         string          `json:"path"`
+	Status       int             `json:"status,omitempty"`
+	Duration     float64         `json:"duration_ms"`
+	OperationID  string          `json:"operation_id,omitempty"`
+	Resource     string          `json:"resource,omitempty"`
+	Operation    string          `json:"operation,omitempty"`
+	RequestBody  json.RawMessage `json:"request_body,omitempty"`
+	ResponseBody json.RawMessage `json:"response_body,omitempty"`
+	Error        string          `json:"error,omitempty"`
+}
+
+// auditTransport writes a line to the audit log for each request.
+type auditTransport struct {
+	next   http.RoundTripper
+	lock   *sync.Mutex
+	writer io.Writer
+}
+
+// NewAuditWrapper returns a transport wrapper that writes to the given writer a JSON line for
+// each request, containing the method, path, status, duration and operation identifier, as well
+// as the request and response bodies with the sensitive values redacted.
+func NewAuditWrapper(writer io.Writer) func(http.RoundTripper) http.RoundTripper {
+	lock := &sync.Mutex{}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &auditTransport{
+			next:   next,
+			lock:   lock,
+			writer: writer,
+		}
+	}
+}
+
+func (t *auditTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	request, err := rewindableBody(request)
+	if err != nil {
+		return nil, err
+	}
+	record := &AuditRecord{
+		Time:      time.Now().UTC(),
+		Method:    request.Method,
+		Path:      request.URL.Path,
+		Resource:  ResourceFromContext(request.Context()),
+		Operation: OperationFromContext(request.Context()),
+	}
+
+	// Read the request body from a copy, so that the original one is sent untouched:
+	if request.GetBody != nil {
+		body, bodyErr := request.GetBody()
+		if bodyErr == nil {
+			data, readErr := io.ReadAll(body)
+			body.Close()
+			if readErr == nil {
+				record.RequestBody = Redact(request.Header.Get("Content-Type"), data)
+			}
+		}
+	}
+
+	response, err := t.next.RoundTrip(request)
+	record.Duration = float64(time.Since(record.Time).Microseconds()) / 1000
+	if err != nil {
+		record.Error = err.Error()
+	} else {
+		record.Status = response.StatusCode
+		record.OperationID = response.Header.Get("X-Operation-Id")
+		if response.Body != nil {
+			data, readErr := io.ReadAll(response.Body)
+			response.Body.Close()
+			response.Body = io.NopCloser(bytes.NewReader(data))
+			if readErr != nil {
+				record.Error = readErr.Error()
+			} else {
+				record.ResponseBody = Redact(response.Header.Get("Content-Type"), data)
+			}
+		}
+	}
+
+	t.write(record)
+	return response, err
+}
+
+// write writes the record as a single line, so that lines of concurrent requests aren't mixed.
+func (t *auditTransport) write(record *AuditRecord) {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	_, _ = t.writer.Write(line)
+}
+
+// Redact returns the given body as a JSON document where the values of the sensitive fields are
+// replaced by `REDACTED`. JSON and form bodies are supported, anything else is omitted, as it
+// isn't possible to know if it contains sensitive values.
+func Redact(contentType string, data []byte) json.RawMessage {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	var value interface{}
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		form, err := url.ParseQuery(string(data))
+		if err != nil {
+			return nil
+		}
+		fields := map[string]interface{}{}
+		for name, values := range form {
+			if len(values) == 1 {
+				fields[name] = values[0]
+			} else {
+				fields[name] = values
+			}
+		}
+		value = fields
+	default:
+		err := json.Unmarshal(data, &value)
+		if err != nil {
+			return nil
+		}
+	}
+	result, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return nil
+	}
+	return result
+}
+
+func redactValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for name, field := range typed {
+			if sensitiveFields[name] {
+				typed[name] = RedactedValue
+			} else {
+				typed[name] = redactValue(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = redactValue(item)
+		}
+	}
+	return value
+}
@@ -0,0 +1,99 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+var _ = Describe("Audit transport", func() {
+	It("Should write a redacted line for each request", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Operation-Id", "my-operation")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": "123", "htpasswd": {"users": {"items": [{"username": "admin", "password": "secret"}]}}}`))
+		}))
+		defer server.Close()
+
+		output := &bytes.Buffer{}
+		client := &http.Client{Transport: NewAuditWrapper(output)(http.DefaultTransport)}
+		ctx := WithResource(context.Background(), "rhcs_identity_provider", "create")
+		request, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/api/clusters_mgmt/v1/clusters",
+			strings.NewReader(`{"name": "my-idp", "ldap": {"bind_dn": "cn=admin", "bind_password": "secret"}}`))
+		request.Header.Set("Content-Type", "application/json")
+		response, err := client.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		body, err := io.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		response.Body.Close()
+		Expect(string(body)).To(ContainSubstring(`"password": "secret"`))
+
+		Expect(output.String()).ToNot(ContainSubstring("secret"))
+		Expect(strings.Count(output.String(), "\n")).To(Equal(1))
+		record := &AuditRecord{}
+		Expect(json.Unmarshal(output.Bytes(), record)).To(Succeed())
+		Expect(record.Method).To(Equal(http.MethodPost))
+		Expect(record.Path).To(Equal("/api/clusters_mgmt/v1/clusters"))
+		Expect(record.Status).To(Equal(http.StatusCreated))
+		Expect(record.OperationID).To(Equal("my-operation"))
+		Expect(record.Resource).To(Equal("rhcs_identity_provider"))
+		Expect(record.Operation).To(Equal("create"))
+		Expect(string(record.RequestBody)).To(ContainSubstring(`"bind_password":"REDACTED"`))
+		Expect(string(record.RequestBody)).To(ContainSubstring(`"bind_dn":"cn=admin"`))
+		Expect(string(record.ResponseBody)).To(ContainSubstring(`"password":"REDACTED"`))
+	})
+
+	It("Should record the error of failed requests", func() {
+		output := &bytes.Buffer{}
+		client := &http.Client{Transport: NewAuditWrapper(output)(http.DefaultTransport)}
+		_, err := client.Get("http://127.0.0.1:0/api/clusters_mgmt/v1/clusters")
+		Expect(err).To(HaveOccurred())
+
+		record := &AuditRecord{}
+		Expect(json.Unmarshal(output.Bytes(), record)).To(Succeed())
+		Expect(record.Status).To(BeZero())
+		Expect(record.Error).ToNot(BeEmpty())
+	})
+
+	It("Should redact form bodies", func() {
+		body := Redact("application/x-www-form-urlencoded",
+			[]byte("grant_type=refresh_token&client_id=cloud-services&refresh_token=my-token"))
+		Expect(string(body)).To(MatchJSON(`{
+			"grant_type": "refresh_token",
+			"client_id": "cloud-services",
+			"refresh_token": "REDACTED"
+		}`))
+	})
+
+	It("Should redact the secrets of the external authentication clients", func() {
+		body := Redact("application/json", []byte(`{
+			"id": "my-auth",
+			"clients": [{
+				"component": {"name": "console", "namespace": "openshift-console"},
+				"id": "my-client",
+				"secret": "my-secret"
+			}]
+		}`))
+		Expect(string(body)).To(MatchJSON(`{
+			"id": "my-auth",
+			"clients": [{
+				"component": {"name": "console", "namespace": "openshift-console"},
+				"id": "my-client",
+				"secret": "REDACTED"
+			}]
+		}`))
+	})
+
+	It("Should omit bodies that can't be parsed", func() {
+		Expect(Redact("text/plain", []byte("password=secret"))).To(BeNil())
+		Expect(Redact("application/json", nil)).To(BeNil())
+	})
+})
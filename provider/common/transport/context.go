@@ -0,0 +1,54 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transport
+
+import (
+	"context"
+)
+
+// resourceKey is the context key used to store the Terraform resource that sends the requests.
+type resourceKey struct{}
+
+// resourceInfo contains the type of the Terraform resource and the operation being performed.
+type resourceInfo struct {
+	typeName  string
+	operation string
+}
+
+// WithResource returns a copy of the context that contains the type of the Terraform resource
+// or data source that sends the requests and the operation being performed, like `create` or
+// `read`, so that they can be written to the audit log.
+func WithResource(ctx context.Context, resource string, operation string) context.Context {
+	return context.WithValue(ctx, resourceKey{}, resourceInfo{
+		typeName:  resource,
+		operation: operation,
+	})
+}
+
+// ResourceFromContext returns the type of the Terraform resource stored in the context, or an
+// empty string if there is none.
+func ResourceFromContext(ctx context.Context) string {
+	info, _ := ctx.Value(resourceKey{}).(resourceInfo)
+	return info.typeName
+}
+
+// OperationFromContext returns the operation stored in the context, or an empty string if there
+// is none.
+func OperationFromContext(ctx context.Context) string {
+	info, _ := ctx.Value(resourceKey{}).(resourceInfo)
+	return info.operation
+}
@@ -0,0 +1,52 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transport
+
+import (
+	"net/http"
+)
+
+// limiterTransport limits the number of requests that are in progress at the same time.
+type limiterTransport struct {
+	next  http.RoundTripper
+	slots chan struct{}
+}
+
+// NewLimiterWrapper returns a transport wrapper that allows at most the given number of requests
+// to be sent concurrently. Additional requests wait till one of the previous ones finishes, or
+// till their context is cancelled.
+func NewLimiterWrapper(maxConcurrentRequests int) func(http.RoundTripper) http.RoundTripper {
+	slots := make(chan struct{}, maxConcurrentRequests)
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &limiterTransport{
+			next:  next,
+			slots: slots,
+		}
+	}
+}
+
+func (t *limiterTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	select {
+	case t.slots <- struct{}{}:
+	case <-request.Context().Done():
+		return nil, request.Context().Err()
+	}
+	defer func() {
+		<-t.slots
+	}()
+	return t.next.RoundTrip(request)
+}
@@ -0,0 +1,72 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+var _ = Describe("Limiter transport", func() {
+	It("Should not send more than the maximum number of concurrent requests", func() {
+		var current, peak atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			value := current.Add(1)
+			for {
+				old := peak.Load()
+				if value <= old || peak.CompareAndSwap(old, value) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			current.Add(-1)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := &http.Client{Transport: NewLimiterWrapper(2)(http.DefaultTransport)}
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				response, err := client.Get(server.URL)
+				Expect(err).ToNot(HaveOccurred())
+				response.Body.Close()
+			}()
+		}
+		wg.Wait()
+		Expect(peak.Load()).To(BeEquivalentTo(2))
+	})
+
+	It("Should stop waiting when the context is cancelled", func() {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		defer close(release)
+
+		client := &http.Client{Transport: NewLimiterWrapper(1)(http.DefaultTransport)}
+		go func() {
+			response, err := client.Get(server.URL)
+			if err == nil {
+				response.Body.Close()
+			}
+		}()
+		time.Sleep(20 * time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		request, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		_, err := client.Do(request)
+		Expect(err).To(MatchError(ContainSubstring("context deadline exceeded")))
+	})
+})
@@ -0,0 +1,76 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transport
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ReadOnlyError is the error returned when a request that could modify OCM is sent while the
+// provider is in read-only mode.
+type ReadOnlyError struct {
+	Method    string
+	Path      string
+	Resource  string
+	Operation string
+}
+
+func (e *ReadOnlyError) Error() string {
+	message := fmt.Sprintf("the provider is in read-only mode, the %s request to '%s' was blocked",
+		e.Method, e.Path)
+	if e.Resource != "" {
+		message = fmt.Sprintf("%s (%s operation of resource '%s')", message, e.Operation, e.Resource)
+	}
+	return message
+}
+
+// readOnlyTransport rejects the requests that could modify OCM.
+type readOnlyTransport struct {
+	next http.RoundTripper
+}
+
+// NewReadOnlyWrapper returns a transport wrapper that rejects all the requests to the OCM API that
+// aren't `GET`, `HEAD` or `OPTIONS`, without sending them. The requests to other paths, like the
+// ones sent to the SSO server to obtain tokens, aren't affected.
+func NewReadOnlyWrapper() func(http.RoundTripper) http.RoundTripper {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &readOnlyTransport{
+			next: next,
+		}
+	}
+}
+
+func (t *readOnlyTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return t.next.RoundTrip(request)
+	}
+	if !strings.HasPrefix(request.URL.Path, "/api/") {
+		return t.next.RoundTrip(request)
+	}
+	if request.Body != nil {
+		request.Body.Close()
+	}
+	return nil, &ReadOnlyError{
+		Method:    request.Method,
+		Path:      request.URL.Path,
+		Resource:  ResourceFromContext(request.Context()),
+		Operation: OperationFromContext(request.Context()),
+	}
+}
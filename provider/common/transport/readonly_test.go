@@ -0,0 +1,61 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+var _ = Describe("Read-only transport", func() {
+	var (
+		server   *httptest.Server
+		requests atomic.Int32
+		client   *http.Client
+	)
+
+	BeforeEach(func() {
+		requests.Store(0)
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			w.WriteHeader(http.StatusOK)
+		}))
+		DeferCleanup(server.Close)
+		client = &http.Client{Transport: NewReadOnlyWrapper()(http.DefaultTransport)}
+	})
+
+	It("Should send read requests", func() {
+		response, err := client.Get(server.URL + "/api/clusters_mgmt/v1/clusters/123")
+		Expect(err).ToNot(HaveOccurred())
+		response.Body.Close()
+		Expect(requests.Load()).To(BeEquivalentTo(1))
+	})
+
+	It("Should block requests that modify the API", func() {
+		ctx := WithResource(context.Background(), "rhcs_machine_pool", "delete")
+		for _, method := range []string{http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete} {
+			request, _ := http.NewRequestWithContext(ctx, method,
+				server.URL+"/api/clusters_mgmt/v1/clusters/123/machine_pools/my-pool", strings.NewReader("{}"))
+			_, err := client.Do(request)
+			var readOnlyErr *ReadOnlyError
+			Expect(errors.As(err, &readOnlyErr)).To(BeTrue())
+			Expect(readOnlyErr.Method).To(Equal(method))
+			Expect(err.Error()).To(ContainSubstring("read-only mode"))
+			Expect(err.Error()).To(ContainSubstring("delete operation of resource 'rhcs_machine_pool'"))
+		}
+		Expect(requests.Load()).To(BeZero())
+	})
+
+	It("Should send requests that don't go to the API", func() {
+		response, err := client.Post(server.URL+"/auth/realms/redhat-external/protocol/openid-connect/token",
+			"application/x-www-form-urlencoded", strings.NewReader("grant_type=refresh_token"))
+		Expect(err).ToNot(HaveOccurred())
+		response.Body.Close()
+		Expect(requests.Load()).To(BeEquivalentTo(1))
+	})
+})
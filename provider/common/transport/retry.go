@@ -0,0 +1,183 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transport
+
+import (
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	ocmlogging "github.com/openshift-online/ocm-sdk-go/logging"
+)
+
+const (
+	DefaultMaxAttempts = 3
+	DefaultMinBackoff  = 1 * time.Second
+	DefaultMaxBackoff  = 30 * time.Second
+)
+
+// RetryOptions contains the settings of the retry transport.
+type RetryOptions struct {
+	// MaxAttempts is the total number of times that a request is sent, including the first one.
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+}
+
+// retryTransport retries failed requests using exponential backoff with jitter.
+type retryTransport struct {
+	next    http.RoundTripper
+	options RetryOptions
+	logger  ocmlogging.Logger
+}
+
+// NewRetryWrapper returns a transport wrapper that retries the requests that fail with a network
+// error or with a 429, 502, 503 or 504 status code. Only idempotent requests are retried, except
+// for the 429 status code, which means that the server didn't process the request. The
+// `Retry-After` header sent by the server takes precedence over the computed backoff.
+func NewRetryWrapper(options RetryOptions, logger ocmlogging.Logger) func(http.RoundTripper) http.RoundTripper {
+	if options.MaxAttempts < 1 {
+		options.MaxAttempts = DefaultMaxAttempts
+	}
+	if options.MinBackoff <= 0 {
+		options.MinBackoff = DefaultMinBackoff
+	}
+	if options.MaxBackoff < options.MinBackoff {
+		options.MaxBackoff = options.MinBackoff
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &retryTransport{
+			next:    next,
+			options: options,
+			logger:  logger,
+		}
+	}
+}
+
+func (t *retryTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	ctx := request.Context()
+	request, err := rewindableBody(request)
+	if err != nil {
+		return nil, err
+	}
+	for attempt := 1; ; attempt++ {
+		response, err := t.next.RoundTrip(request)
+		if attempt >= t.options.MaxAttempts || !t.shouldRetry(request, response, err) {
+			return response, err
+		}
+
+		// Requests with a body can only be sent again if the body can be rewound:
+		if request.Body != nil && request.Body != http.NoBody {
+			if request.GetBody == nil {
+				return response, err
+			}
+			body, bodyErr := request.GetBody()
+			if bodyErr != nil {
+				return response, err
+			}
+			request = request.Clone(ctx)
+			request.Body = body
+		}
+
+		delay := t.backoff(attempt)
+		reason := ""
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = response.Status
+			if retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+				delay = retryAfter
+			}
+			// Drain and close the body so that the connection can be reused:
+			_, _ = io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+		t.logger.Info(ctx, "Retrying %s %s in %s (attempt %d of %d): %s",
+			request.Method, request.URL.Path, delay, attempt+1, t.options.MaxAttempts, reason)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (t *retryTransport) shouldRetry(request *http.Request, response *http.Response, err error) bool {
+	if request.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		return isIdempotent(request.Method)
+	}
+	switch response.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(request.Method)
+	}
+	return false
+}
+
+// backoff calculates the delay before the given retry attempt. The delay grows exponentially from
+// the minimum backoff and it is randomized between half and the full value, so that concurrent
+// clients don't retry at the same time.
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	delay := t.options.MinBackoff
+	for i := 1; i < attempt && delay < t.options.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > t.options.MaxBackoff {
+		delay = t.options.MaxBackoff
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter parses the value of the `Retry-After` header, which can be either a number of
+// seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
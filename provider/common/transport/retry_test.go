@@ -0,0 +1,131 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/logging"
+)
+
+var _ = Describe("Retry transport", func() {
+	var (
+		server   *httptest.Server
+		attempts atomic.Int32
+		statuses []int
+		bodies   []string
+		client   *http.Client
+	)
+
+	BeforeEach(func() {
+		attempts.Store(0)
+		bodies = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			attempt := int(attempts.Add(1))
+			status := http.StatusOK
+			if attempt <= len(statuses) {
+				status = statuses[attempt-1]
+			}
+			if status == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", "0")
+			}
+			w.WriteHeader(status)
+		}))
+		DeferCleanup(server.Close)
+		wrapper := NewRetryWrapper(RetryOptions{
+			MaxAttempts: 3,
+			MinBackoff:  time.Millisecond,
+			MaxBackoff:  5 * time.Millisecond,
+		}, logging.New())
+		client = &http.Client{Transport: wrapper(http.DefaultTransport)}
+	})
+
+	It("Should retry idempotent requests on server errors", func() {
+		statuses = []int{http.StatusServiceUnavailable, http.StatusBadGateway}
+		response, err := client.Get(server.URL)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(attempts.Load()).To(BeEquivalentTo(3))
+	})
+
+	It("Should stop after the maximum number of attempts", func() {
+		statuses = []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable,
+			http.StatusServiceUnavailable, http.StatusServiceUnavailable}
+		response, err := client.Get(server.URL)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusServiceUnavailable))
+		Expect(attempts.Load()).To(BeEquivalentTo(3))
+	})
+
+	It("Should not retry non idempotent requests on server errors", func() {
+		statuses = []int{http.StatusServiceUnavailable}
+		response, err := client.Post(server.URL, "application/json", bytes.NewBufferString("{}"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusServiceUnavailable))
+		Expect(attempts.Load()).To(BeEquivalentTo(1))
+	})
+
+	It("Should retry rejected requests sending the body again", func() {
+		statuses = []int{http.StatusTooManyRequests}
+		response, err := client.Post(server.URL, "application/json", bytes.NewBufferString(`{"a":1}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(bodies).To(Equal([]string{`{"a":1}`, `{"a":1}`}))
+	})
+
+	It("Should retry rejected requests whose body can only be read once", func() {
+		statuses = []int{http.StatusTooManyRequests}
+		request, _ := http.NewRequest(http.MethodPost, server.URL, nil)
+		request.Body = io.NopCloser(bytes.NewBufferString(`{"a":1}`))
+		response, err := client.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(bodies).To(Equal([]string{`{"a":1}`, `{"a":1}`}))
+	})
+
+	It("Should not retry client errors", func() {
+		statuses = []int{http.StatusNotFound}
+		response, err := client.Get(server.URL)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+		Expect(attempts.Load()).To(BeEquivalentTo(1))
+	})
+
+	It("Should stop waiting when the context is cancelled", func() {
+		statuses = []int{http.StatusServiceUnavailable}
+		wrapper := NewRetryWrapper(RetryOptions{MaxAttempts: 3, MinBackoff: time.Hour, MaxBackoff: time.Hour}, logging.New())
+		client = &http.Client{Transport: wrapper(http.DefaultTransport)}
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		request, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		_, err := client.Do(request)
+		Expect(err).To(HaveOccurred())
+		Expect(attempts.Load()).To(BeEquivalentTo(1))
+	})
+
+	Context("parseRetryAfter", func() {
+		It("Should parse seconds", func() {
+			delay, ok := parseRetryAfter("5")
+			Expect(ok).To(BeTrue())
+			Expect(delay).To(Equal(5 * time.Second))
+		})
+		It("Should parse dates", func() {
+			delay, ok := parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+			Expect(ok).To(BeTrue())
+			Expect(delay).To(BeNumerically("~", time.Minute, 2*time.Second))
+		})
+		It("Should ignore invalid values", func() {
+			_, ok := parseRetryAfter("soon")
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
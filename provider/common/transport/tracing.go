@@ -0,0 +1,84 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transport
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/tracing"
+)
+
+// clusterPathRE extracts the identifier of the cluster from the paths of the clusters API.
+var clusterPathRE = regexp.MustCompile(`^/api/clusters_mgmt/v1/clusters/([^/]+)`)
+
+// tracingTransport creates a span for each request.
+type tracingTransport struct {
+	next http.RoundTripper
+}
+
+// NewTracingWrapper returns a transport wrapper that creates a client span for each request, as a
+// child of the span contained in the context of the request.
+func NewTracingWrapper() func(http.RoundTripper) http.RoundTripper {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &tracingTransport{
+			next: next,
+		}
+	}
+}
+
+func (t *tracingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	attributes := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(request.Method),
+		semconv.URLPath(request.URL.Path),
+		semconv.ServerAddress(request.URL.Hostname()),
+	}
+	if matches := clusterPathRE.FindStringSubmatch(request.URL.Path); matches != nil {
+		attributes = append(attributes, tracing.ClusterIDKey.String(matches[1]))
+	}
+	if resource := ResourceFromContext(request.Context()); resource != "" {
+		attributes = append(attributes, tracing.ResourceTypeKey.String(resource))
+	}
+	ctx, span := tracing.StartClientSpan(request.Context(), request.Method, attributes...)
+	defer span.End()
+
+	// Propagate the trace to the server, without modifying the original request:
+	request = request.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(request.Header))
+
+	response, err := t.next.RoundTrip(request)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return response, err
+	}
+	span.SetAttributes(semconv.HTTPResponseStatusCode(response.StatusCode))
+	if operationID := response.Header.Get("X-Operation-Id"); operationID != "" {
+		span.SetAttributes(attribute.String("ocm.operation_id", operationID))
+	}
+	if response.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, fmt.Sprintf("%d %s", response.StatusCode, http.StatusText(response.StatusCode)))
+	}
+	return response, err
+}
@@ -0,0 +1,70 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/tracing"
+)
+
+var _ = Describe("Tracing transport", func() {
+	var (
+		recorder    *tracetest.SpanRecorder
+		traceparent string
+		server      *httptest.Server
+		client      *http.Client
+	)
+
+	BeforeEach(func() {
+		recorder = tracetest.NewSpanRecorder()
+		previousProvider := otel.GetTracerProvider()
+		previousPropagator := otel.GetTextMapPropagator()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+		DeferCleanup(otel.SetTracerProvider, previousProvider)
+		DeferCleanup(otel.SetTextMapPropagator, previousPropagator)
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			traceparent = r.Header.Get("traceparent")
+			w.Header().Set("X-Operation-Id", "my-operation")
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		DeferCleanup(server.Close)
+		client = &http.Client{Transport: NewTracingWrapper()(http.DefaultTransport)}
+	})
+
+	It("Should create a client span for each request", func() {
+		ctx, parent := tracing.StartSpan(WithResource(context.Background(), "rhcs_machine_pool", "read"), "parent")
+		request, _ := http.NewRequestWithContext(ctx, http.MethodGet,
+			server.URL+"/api/clusters_mgmt/v1/clusters/123/machine_pools/my-pool", nil)
+		response, err := client.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		response.Body.Close()
+		parent.End()
+
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(2))
+		span := spans[0]
+		Expect(span.Name()).To(Equal(http.MethodGet))
+		Expect(span.SpanKind()).To(Equal(trace.SpanKindClient))
+		Expect(span.Parent().SpanID()).To(Equal(parent.SpanContext().SpanID()))
+		Expect(span.Attributes()).To(ContainElements(
+			tracing.ClusterIDKey.String("123"),
+			tracing.ResourceTypeKey.String("rhcs_machine_pool"),
+			semconv.HTTPResponseStatusCode(http.StatusNotFound),
+		))
+		Expect(span.Status().Code).To(Equal(codes.Error))
+		Expect(traceparent).To(ContainSubstring(span.SpanContext().SpanID().String()))
+	})
+})
@@ -2,6 +2,7 @@ package common
 
 import (
 	"fmt"
+	"net"
 	"regexp"
 	"strings"
 )
@@ -32,3 +33,25 @@ func ValidateHTPasswdPassword(password string) error {
 	}
 	return nil
 }
+
+// ValidateCIDR checks that the given text is a valid block of IP addresses.
+func ValidateCIDR(cidr string) error {
+	if _, _, err := net.ParseCIDR(cidr); err != nil {
+		return fmt.Errorf("invalid CIDR '%s': %v", cidr, err)
+	}
+	return nil
+}
+
+// CIDRsOverlap checks if the two given blocks of IP addresses overlap. It returns an error if any of
+// them isn't a valid CIDR.
+func CIDRsOverlap(first, second string) (bool, error) {
+	if err := ValidateCIDR(first); err != nil {
+		return false, err
+	}
+	if err := ValidateCIDR(second); err != nil {
+		return false, err
+	}
+	_, firstNet, _ := net.ParseCIDR(first)
+	_, secondNet, _ := net.ParseCIDR(second)
+	return firstNet.Contains(secondNet.IP) || secondNet.Contains(firstNet.IP), nil
+}
@@ -0,0 +1,50 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	WriteOnlyStringDescription = " The value is sent to OCM but it is never saved to the plan or to the state, " +
+		"which requires Terraform 1.11 or newer. Changes of the value are ignored unless the `%s` attribute is changed too."
+	WriteOnlyVersionStringDescription = "Version of the `%s` attribute. Changing it sends the current value of `%s` to OCM."
+)
+
+// WriteOnlyDescription returns the description of a write-only attribute whose changes are
+// triggered by the given version attribute.
+func WriteOnlyDescription(description string, versionAttribute string) string {
+	return description + fmt.Sprintf(WriteOnlyStringDescription, versionAttribute)
+}
+
+// WriteOnlyVersionDescription returns the description of the attribute that triggers the changes of
+// the given write-only attribute.
+func WriteOnlyVersionDescription(writeOnlyAttribute string) string {
+	return fmt.Sprintf(WriteOnlyVersionStringDescription, writeOnlyAttribute, writeOnlyAttribute)
+}
+
+// SecretValue returns the value of a secret that can be given either in a regular attribute or in
+// a write-only attribute. The write-only attribute takes precedence.
+func SecretValue(value types.String, writeOnlyValue types.String) string {
+	if HasValue(writeOnlyValue) {
+		return writeOnlyValue.ValueString()
+	}
+	return value.ValueString()
+}
@@ -0,0 +1,85 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ocmConfigEnvVar is the environment variable used by the `ocm` and `rosa` command line tools to
+// override the location of their configuration file.
+const ocmConfigEnvVar = "OCM_CONFIG"
+
+// OCMConfig contains the subset of the configuration file written by `ocm login` and `rosa login`
+// that is relevant for the provider.
+type OCMConfig struct {
+	AccessToken  string `json:"access_token,omitempty"`
+	ClientID     string `json:"client_id,omitempty"`
+	ClientSecret string `json:"client_secret,omitempty"`
+	Insecure     bool   `json:"insecure,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	TokenURL     string `json:"token_url,omitempty"`
+	URL          string `json:"url,omitempty"`
+}
+
+// DefaultOCMConfigLocation returns the location of the configuration file used by the `ocm` and
+// `rosa` command line tools. The `OCM_CONFIG` environment variable takes precedence over the
+// default `~/.config/ocm/ocm.json` location.
+func DefaultOCMConfigLocation() (string, error) {
+	if location, ok := os.LookupEnv(ocmConfigEnvVar); ok && location != "" {
+		return location, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "ocm", "ocm.json"), nil
+}
+
+// LoadOCMConfig reads and parses the OCM configuration file in the given location.
+func LoadOCMConfig(location string) (*OCMConfig, error) {
+	data, err := os.ReadFile(location)
+	if err != nil {
+		return nil, fmt.Errorf("can't read OCM configuration file '%s': %v", location, err)
+	}
+	config := &OCMConfig{}
+	err = json.Unmarshal(data, config)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse OCM configuration file '%s': %v", location, err)
+	}
+	return config, nil
+}
+
+// HasCredentials returns true if the configuration file contains any token or client credentials.
+func (c *OCMConfig) HasCredentials() bool {
+	return c.AccessToken != "" || c.RefreshToken != "" || (c.ClientID != "" && c.ClientSecret != "")
+}
+
+// Tokens returns the tokens stored in the configuration file, the access token first.
+func (c *OCMConfig) Tokens() []string {
+	tokens := []string{}
+	if c.AccessToken != "" {
+		tokens = append(tokens, c.AccessToken)
+	}
+	if c.RefreshToken != "" {
+		tokens = append(tokens, c.RefreshToken)
+	}
+	return tokens
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+var _ = Describe("OCM configuration file", func() {
+	var dir string
+
+	writeConfig := func(name string, content string) string {
+		location := filepath.Join(dir, name)
+		Expect(os.WriteFile(location, []byte(content), 0600)).To(Succeed())
+		return location
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		GinkgoT().Setenv("HOME", dir)
+		GinkgoT().Setenv(ocmConfigEnvVar, "")
+		GinkgoT().Setenv("RHCS_CONFIG_FILE", "")
+		Expect(os.Unsetenv("RHCS_CONFIG_FILE")).To(Succeed())
+	})
+
+	Context("DefaultOCMConfigLocation", func() {
+		It("Should use the OCM_CONFIG environment variable", func() {
+			GinkgoT().Setenv(ocmConfigEnvVar, "/my/ocm.json")
+			location, err := DefaultOCMConfigLocation()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(location).To(Equal("/my/ocm.json"))
+		})
+		It("Should default to the user configuration directory", func() {
+			location, err := DefaultOCMConfigLocation()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(location).To(Equal(filepath.Join(dir, ".config", "ocm", "ocm.json")))
+		})
+	})
+
+	Context("LoadOCMConfig", func() {
+		It("Should parse the file written by the CLI", func() {
+			location := writeConfig("ocm.json", `{
+				"access_token": "my-access-token",
+				"client_id": "cloud-services",
+				"refresh_token": "my-refresh-token",
+				"scopes": ["openid"],
+				"token_url": "https://sso.example.com/token",
+				"url": "https://api.example.com"
+			}`)
+			config, err := LoadOCMConfig(location)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.URL).To(Equal("https://api.example.com"))
+			Expect(config.TokenURL).To(Equal("https://sso.example.com/token"))
+			Expect(config.ClientID).To(Equal("cloud-services"))
+			Expect(config.HasCredentials()).To(BeTrue())
+			Expect(config.Tokens()).To(Equal([]string{"my-access-token", "my-refresh-token"}))
+		})
+		It("Should fail if the file doesn't exist", func() {
+			_, err := LoadOCMConfig(filepath.Join(dir, "missing.json"))
+			Expect(err).To(HaveOccurred())
+		})
+		It("Should fail if the file isn't valid JSON", func() {
+			location := writeConfig("ocm.json", "not json")
+			_, err := LoadOCMConfig(location)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("can't parse OCM configuration file"))
+		})
+	})
+
+	Context("loadOCMConfig", func() {
+		p := &Provider{}
+		nullConfig := Config{ConfigFile: types.StringNull()}
+
+		It("Should ignore a missing file in the default location", func() {
+			config, err := p.loadOCMConfig(nullConfig, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config).To(BeNil())
+		})
+		It("Should fall back to OCM_CONFIG when there are no credentials", func() {
+			GinkgoT().Setenv(ocmConfigEnvVar, writeConfig("ocm.json", `{"refresh_token": "my-token"}`))
+			config, err := p.loadOCMConfig(nullConfig, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.Tokens()).To(Equal([]string{"my-token"}))
+		})
+		It("Should not use the default location when credentials were provided", func() {
+			GinkgoT().Setenv(ocmConfigEnvVar, writeConfig("ocm.json", `{"refresh_token": "my-token"}`))
+			config, err := p.loadOCMConfig(nullConfig, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config).To(BeNil())
+		})
+		It("Should always use an explicit file", func() {
+			location := writeConfig("explicit.json", `{"url": "https://api.example.com"}`)
+			config, err := p.loadOCMConfig(Config{ConfigFile: types.StringValue(location)}, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.URL).To(Equal("https://api.example.com"))
+		})
+		It("Should fail if an explicit file doesn't exist", func() {
+			_, err := p.loadOCMConfig(Config{ConfigFile: types.StringValue(filepath.Join(dir, "missing.json"))}, false)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -174,6 +174,11 @@ func (r *DefaultIngressResource) Create(ctx context.Context, req resource.Create
 		)
 		return
 	}
+
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(plan.Cluster.ValueString())
+	defer common.ClusterMutexKV.Unlock(plan.Cluster.ValueString())
+
 	err = r.updateIngress(ctx, nil, plan, plan.Cluster.ValueString(), r.collection, resp.Diagnostics)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -241,6 +246,10 @@ func (r *DefaultIngressResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(state.Cluster.ValueString())
+	defer common.ClusterMutexKV.Unlock(state.Cluster.ValueString())
+
 	err := r.updateIngress(ctx, state, plan, plan.Cluster.ValueString(), r.collection, resp.Diagnostics)
 	if err != nil {
 		diags.AddError(
@@ -110,6 +110,11 @@ func (r *DefaultIngressResource) Create(ctx context.Context, req resource.Create
 		)
 		return
 	}
+
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(plan.Cluster.ValueString())
+	defer common.ClusterMutexKV.Unlock(plan.Cluster.ValueString())
+
 	err = r.updateIngress(ctx, nil, plan, plan.Cluster.ValueString(), r.collection)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -177,6 +182,10 @@ func (r *DefaultIngressResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(state.Cluster.ValueString())
+	defer common.ClusterMutexKV.Unlock(state.Cluster.ValueString())
+
 	err := r.updateIngress(ctx, state, plan, plan.Cluster.ValueString(), r.collection)
 	if err != nil {
 		diags.AddError(
@@ -0,0 +1,519 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalauthprovider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+)
+
+const (
+	resourceTypeName      = "_hcp_external_auth_provider"
+	failedToCreateSummary = "Failed to create external authentication provider"
+	failedToUpdateSummary = "Failed to update external authentication provider"
+	failedToDeleteSummary = "Failed to delete external authentication provider"
+	failedToReadSummary   = "Failed to read external authentication provider"
+
+	// The console client is always registered for the OpenShift console component:
+	consoleComponentName      = "console"
+	consoleComponentNamespace = "openshift-console"
+)
+
+type ExternalAuthProviderResource struct {
+	collection  *cmv1.ClustersClient
+	clusterWait common.ClusterWait
+}
+
+// Interface checks
+var _ resource.Resource = &ExternalAuthProviderResource{}
+var _ resource.ResourceWithConfigure = &ExternalAuthProviderResource{}
+var _ resource.ResourceWithImportState = &ExternalAuthProviderResource{}
+
+func New() resource.Resource {
+	return &ExternalAuthProviderResource{}
+}
+
+func (r *ExternalAuthProviderResource) Metadata(_ context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + resourceTypeName
+}
+
+func (r *ExternalAuthProviderResource) Schema(_ context.Context, _ resource.SchemaRequest,
+	resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "External OIDC authentication provider of a ROSA cluster with hosted control planes. " +
+			"The cluster must have been created with `external_auth_providers_enabled` set to `true`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Unique identifier of the external authentication provider.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster": schema.StringAttribute{
+				Description: "Identifier of the cluster." + common.ValueCannotBeChangedStringDescription,
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`.*\S.*`),
+						"cluster ID may not be empty/blank string"),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the external authentication provider." +
+					common.ValueCannotBeChangedStringDescription,
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`),
+						"name must consist of lower case alphanumeric characters or '-', start with "+
+							"a letter and end with an alphanumeric character"),
+				},
+			},
+			"issuer": schema.SingleNestedAttribute{
+				Description: "Token issuer of the external authentication provider.",
+				Required:    true,
+				Attributes: map[string]schema.Attribute{
+					"url": schema.StringAttribute{
+						Description: "URL of the token issuer. It must use the `https` scheme.",
+						Required:    true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(regexp.MustCompile(`^https://\S+$`),
+								"issuer URL must use the https scheme"),
+						},
+					},
+					"audiences": schema.ListAttribute{
+						Description: "Audiences that the tokens must be issued for.",
+						ElementType: types.StringType,
+						Required:    true,
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+							listvalidator.UniqueValues(),
+						},
+					},
+					"ca": schema.StringAttribute{
+						Description: "PEM encoded certificate authority bundle used to validate the " +
+							"connections to the token issuer.",
+						Optional: true,
+					},
+				},
+			},
+			"claim": schema.SingleNestedAttribute{
+				Description: "Rules used to map and validate the claims of the tokens.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"username": schema.SingleNestedAttribute{
+						Description: "Mapping of the token claim used as the name of the user.",
+						Optional:    true,
+						Attributes: map[string]schema.Attribute{
+							"claim": schema.StringAttribute{
+								Description: "Name of the claim, for example `email`.",
+								Required:    true,
+							},
+							"prefix": schema.StringAttribute{
+								Description: "Prefix added to the user name. Only used when " +
+									"`prefix_policy` is `Prefix`.",
+								Optional: true,
+							},
+							"prefix_policy": schema.StringAttribute{
+								Description: "Policy used to prefix the user name. Valid values are " +
+									"`NoPrefix` and `Prefix`. When not set the issuer URL is used as " +
+									"prefix for claims other than `email`.",
+								Optional: true,
+								Validators: []validator.String{
+									stringvalidator.OneOf("NoPrefix", "Prefix"),
+								},
+							},
+						},
+					},
+					"groups": schema.SingleNestedAttribute{
+						Description: "Mapping of the token claim used as the groups of the user.",
+						Optional:    true,
+						Attributes: map[string]schema.Attribute{
+							"claim": schema.StringAttribute{
+								Description: "Name of the claim, for example `groups`.",
+								Required:    true,
+							},
+							"prefix": schema.StringAttribute{
+								Description: "Prefix added to the names of the groups.",
+								Optional:    true,
+							},
+						},
+					},
+					"validation_rules": schema.ListNestedAttribute{
+						Description: "Rules that the claims of the tokens must satisfy.",
+						Optional:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"claim": schema.StringAttribute{
+									Description: "Name of the claim.",
+									Required:    true,
+								},
+								"required_value": schema.StringAttribute{
+									Description: "Value that the claim must have.",
+									Required:    true,
+								},
+							},
+						},
+					},
+				},
+			},
+			"console_client": schema.SingleNestedAttribute{
+				Description: "OIDC client used by the OpenShift console to log in users.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"client_id": schema.StringAttribute{
+						Description: "Identifier of the client.",
+						Required:    true,
+					},
+					"client_secret": schema.StringAttribute{
+						Description: "Secret of the client. It isn't returned by the server, so changes " +
+							"made outside of Terraform aren't detected.",
+						Optional:  true,
+						Sensitive: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ExternalAuthProviderResource) Configure(_ context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connection, ok := req.ProviderData.(*sdk.Connection)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.collection = connection.ClustersMgmt().V1().Clusters()
+	r.clusterWait = common.NewClusterWait(r.collection, connection)
+}
+
+func (r *ExternalAuthProviderResource) Create(ctx context.Context, req resource.CreateRequest,
+	resp *resource.CreateResponse) {
+	plan := &ExternalAuthProviderState{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterId := plan.Cluster.ValueString()
+	waitTimeoutInMinutes := int64(60)
+	cluster, err := r.clusterWait.WaitForClusterToBeReady(ctx, clusterId, waitTimeoutInMinutes)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cluster is not ready",
+			fmt.Sprintf("Cluster with id '%s' is not in the ready state: %v", clusterId, err),
+		)
+		return
+	}
+	if !cluster.Hypershift().Enabled() {
+		resp.Diagnostics.AddAttributeError(path.Root("cluster"), failedToCreateSummary,
+			fmt.Sprintf("Cluster '%s' isn't a cluster with hosted control planes, external "+
+				"authentication providers are only supported for such clusters", clusterId))
+		return
+	}
+	if !cluster.ExternalAuthConfig().Enabled() {
+		resp.Diagnostics.AddAttributeError(path.Root("cluster"), failedToCreateSummary,
+			fmt.Sprintf("Cluster '%s' doesn't have external authentication providers enabled, it "+
+				"must be created with 'external_auth_providers_enabled' set to 'true'", clusterId))
+		return
+	}
+
+	object, err := buildExternalAuth(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError(failedToCreateSummary,
+			fmt.Sprintf("Failed to build external authentication provider '%s' for cluster '%s': %v",
+				plan.Name.ValueString(), clusterId, err))
+		return
+	}
+
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(clusterId)
+	defer common.ClusterMutexKV.Unlock(clusterId)
+	addResp, err := r.collection.Cluster(clusterId).ExternalAuthConfig().ExternalAuths().Add().
+		Body(object).SendContext(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(failedToCreateSummary,
+			fmt.Sprintf("Failed to create external authentication provider '%s' for cluster '%s': %v",
+				plan.Name.ValueString(), clusterId, common.HandleErr(addResp.Error(), err)))
+		return
+	}
+
+	populateState(addResp.Body(), plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *ExternalAuthProviderResource) Read(ctx context.Context, req resource.ReadRequest,
+	resp *resource.ReadResponse) {
+	state := &ExternalAuthProviderState{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterId := state.Cluster.ValueString()
+	getResp, err := r.collection.Cluster(clusterId).ExternalAuthConfig().ExternalAuths().
+		ExternalAuth(state.ID.ValueString()).Get().SendContext(ctx)
+	if getResp != nil && getResp.Status() == http.StatusNotFound {
+		tflog.Warn(ctx, fmt.Sprintf("external authentication provider (%s) of cluster (%s) not found, "+
+			"removing from state", state.ID.ValueString(), clusterId))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(failedToReadSummary,
+			fmt.Sprintf("Cannot read external authentication provider '%s' for cluster '%s': %v",
+				state.ID.ValueString(), clusterId, common.HandleErr(getResp.Error(), err)))
+		return
+	}
+
+	populateState(getResp.Body(), state)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *ExternalAuthProviderResource) Update(ctx context.Context, req resource.UpdateRequest,
+	resp *resource.UpdateResponse) {
+	state := &ExternalAuthProviderState{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	plan := &ExternalAuthProviderState{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterId := state.Cluster.ValueString()
+	object, err := buildExternalAuth(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError(failedToUpdateSummary,
+			fmt.Sprintf("Failed to build external authentication provider '%s' for cluster '%s': %v",
+				state.ID.ValueString(), clusterId, err))
+		return
+	}
+
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(clusterId)
+	defer common.ClusterMutexKV.Unlock(clusterId)
+	updateResp, err := r.collection.Cluster(clusterId).ExternalAuthConfig().ExternalAuths().
+		ExternalAuth(state.ID.ValueString()).Update().Body(object).SendContext(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(failedToUpdateSummary,
+			fmt.Sprintf("Failed to update external authentication provider '%s' for cluster '%s': %v",
+				state.ID.ValueString(), clusterId, common.HandleErr(updateResp.Error(), err)))
+		return
+	}
+
+	plan.ID = state.ID
+	populateState(updateResp.Body(), plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *ExternalAuthProviderResource) Delete(ctx context.Context, req resource.DeleteRequest,
+	resp *resource.DeleteResponse) {
+	state := &ExternalAuthProviderState{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterId := state.Cluster.ValueString()
+	common.ClusterMutexKV.Lock(clusterId)
+	defer common.ClusterMutexKV.Unlock(clusterId)
+
+	deleteResp, err := r.collection.Cluster(clusterId).ExternalAuthConfig().ExternalAuths().
+		ExternalAuth(state.ID.ValueString()).Delete().SendContext(ctx)
+	if err != nil && (deleteResp == nil || deleteResp.Status() != http.StatusNotFound) {
+		resp.Diagnostics.AddError(failedToDeleteSummary,
+			fmt.Sprintf("Failed to delete external authentication provider '%s' for cluster '%s': %v",
+				state.ID.ValueString(), clusterId, common.HandleErr(deleteResp.Error(), err)))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState expects the identifier of the cluster and the name of the external authentication
+// provider separated by a comma.
+func (r *ExternalAuthProviderResource) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+	fields := strings.Split(req.ID, ",")
+	if len(fields) != 2 || fields[0] == "" || fields[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import identifier",
+			"External authentication provider to import should be specified as <cluster_id>,<name>",
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster"), fields[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), fields[1])...)
+}
+
+func buildExternalAuth(ctx context.Context, state *ExternalAuthProviderState) (*cmv1.ExternalAuth, error) {
+	builder := cmv1.NewExternalAuth().ID(state.Name.ValueString())
+
+	audiences, err := common.StringListToArray(ctx, state.Issuer.Audiences)
+	if err != nil {
+		return nil, err
+	}
+	issuer := cmv1.NewTokenIssuer().
+		URL(state.Issuer.URL.ValueString()).
+		Audiences(audiences...)
+	if common.HasValue(state.Issuer.CA) {
+		issuer.CA(state.Issuer.CA.ValueString())
+	}
+	builder.Issuer(issuer)
+
+	if state.Claim != nil {
+		claim := cmv1.NewExternalAuthClaim()
+		mappings := cmv1.NewTokenClaimMappings()
+		if state.Claim.Username != nil {
+			username := cmv1.NewUsernameClaim().Claim(state.Claim.Username.Claim.ValueString())
+			if common.HasValue(state.Claim.Username.Prefix) {
+				username.Prefix(state.Claim.Username.Prefix.ValueString())
+			}
+			if common.HasValue(state.Claim.Username.PrefixPolicy) {
+				username.PrefixPolicy(state.Claim.Username.PrefixPolicy.ValueString())
+			}
+			mappings.UserName(username)
+		}
+		if state.Claim.Groups != nil {
+			groups := cmv1.NewGroupsClaim().Claim(state.Claim.Groups.Claim.ValueString())
+			if common.HasValue(state.Claim.Groups.Prefix) {
+				groups.Prefix(state.Claim.Groups.Prefix.ValueString())
+			}
+			mappings.Groups(groups)
+		}
+		if !mappings.Empty() {
+			claim.Mappings(mappings)
+		}
+		rules := []*cmv1.TokenClaimValidationRuleBuilder{}
+		for _, rule := range state.Claim.ValidationRules {
+			rules = append(rules, cmv1.NewTokenClaimValidationRule().
+				Claim(rule.Claim.ValueString()).
+				RequiredValue(rule.RequiredValue.ValueString()))
+		}
+		claim.ValidationRules(rules...)
+		builder.Claim(claim)
+	}
+
+	clients := []*cmv1.ExternalAuthClientConfigBuilder{}
+	if state.ConsoleClient != nil {
+		client := cmv1.NewExternalAuthClientConfig().
+			ID(state.ConsoleClient.ClientID.ValueString()).
+			Component(cmv1.NewClientComponent().
+				Name(consoleComponentName).
+				Namespace(consoleComponentNamespace))
+		if common.HasValue(state.ConsoleClient.ClientSecret) {
+			client.Secret(state.ConsoleClient.ClientSecret.ValueString())
+		}
+		clients = append(clients, client)
+	}
+	builder.Clients(clients...)
+
+	return builder.Build()
+}
+
+// populateState copies the values returned by the server into the state. The secret of the console
+// client is kept as it is, because the server doesn't return it.
+func populateState(object *cmv1.ExternalAuth, state *ExternalAuthProviderState) {
+	state.ID = types.StringValue(object.ID())
+	state.Name = types.StringValue(object.ID())
+
+	if state.Issuer == nil {
+		state.Issuer = &Issuer{}
+	}
+	state.Issuer.URL = types.StringValue(object.Issuer().URL())
+	audiences, _ := common.StringArrayToList(object.Issuer().Audiences())
+	state.Issuer.Audiences = audiences
+	state.Issuer.CA = common.EmptiableStringToStringType(object.Issuer().CA())
+
+	state.Claim = nil
+	if claim, ok := object.GetClaim(); ok {
+		result := &Claim{}
+		if username, ok := claim.Mappings().GetUserName(); ok {
+			result.Username = &UsernameClaim{
+				Claim:        types.StringValue(username.Claim()),
+				Prefix:       common.EmptiableStringToStringType(username.Prefix()),
+				PrefixPolicy: common.EmptiableStringToStringType(username.PrefixPolicy()),
+			}
+		}
+		if groups, ok := claim.Mappings().GetGroups(); ok {
+			result.Groups = &GroupsClaim{
+				Claim:  types.StringValue(groups.Claim()),
+				Prefix: common.EmptiableStringToStringType(groups.Prefix()),
+			}
+		}
+		for _, rule := range claim.ValidationRules() {
+			result.ValidationRules = append(result.ValidationRules, ValidationRule{
+				Claim:         types.StringValue(rule.Claim()),
+				RequiredValue: types.StringValue(rule.RequiredValue()),
+			})
+		}
+		if result.Username != nil || result.Groups != nil || len(result.ValidationRules) > 0 {
+			state.Claim = result
+		}
+	}
+
+	var consoleClient *ConsoleClient
+	for _, client := range object.Clients() {
+		if client.Component().Name() != consoleComponentName ||
+			client.Component().Namespace() != consoleComponentNamespace {
+			continue
+		}
+		consoleClient = &ConsoleClient{
+			ClientID:     types.StringValue(client.ID()),
+			ClientSecret: types.StringNull(),
+		}
+		if client.Secret() != "" {
+			consoleClient.ClientSecret = types.StringValue(client.Secret())
+		} else if state.ConsoleClient != nil {
+			consoleClient.ClientSecret = state.ConsoleClient.ClientSecret
+		}
+	}
+	state.ConsoleClient = consoleClient
+}
@@ -0,0 +1,61 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalauthprovider
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type ExternalAuthProviderState struct {
+	ID            types.String   `tfsdk:"id"`
+	Cluster       types.String   `tfsdk:"cluster"`
+	Name          types.String   `tfsdk:"name"`
+	Issuer        *Issuer        `tfsdk:"issuer"`
+	Claim         *Claim         `tfsdk:"claim"`
+	ConsoleClient *ConsoleClient `tfsdk:"console_client"`
+}
+
+type Issuer struct {
+	URL       types.String `tfsdk:"url"`
+	Audiences types.List   `tfsdk:"audiences"`
+	CA        types.String `tfsdk:"ca"`
+}
+
+type Claim struct {
+	Username        *UsernameClaim   `tfsdk:"username"`
+	Groups          *GroupsClaim     `tfsdk:"groups"`
+	ValidationRules []ValidationRule `tfsdk:"validation_rules"`
+}
+
+type UsernameClaim struct {
+	Claim        types.String `tfsdk:"claim"`
+	Prefix       types.String `tfsdk:"prefix"`
+	PrefixPolicy types.String `tfsdk:"prefix_policy"`
+}
+
+type GroupsClaim struct {
+	Claim  types.String `tfsdk:"claim"`
+	Prefix types.String `tfsdk:"prefix"`
+}
+
+type ValidationRule struct {
+	Claim         types.String `tfsdk:"claim"`
+	RequiredValue types.String `tfsdk:"required_value"`
+}
+
+type ConsoleClient struct {
+	ClientID     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+}
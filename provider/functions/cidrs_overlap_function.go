@@ -0,0 +1,90 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+)
+
+type CIDRsOverlapFunction struct{}
+
+var _ function.Function = &CIDRsOverlapFunction{}
+
+func NewCIDRsOverlap() function.Function {
+	return &CIDRsOverlapFunction{}
+}
+
+func (f *CIDRsOverlapFunction) Metadata(ctx context.Context, req function.MetadataRequest,
+	resp *function.MetadataResponse) {
+	resp.Name = "cidrs_overlap"
+}
+
+func (f *CIDRsOverlapFunction) Definition(ctx context.Context, req function.DefinitionRequest,
+	resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Checks if the network blocks of a cluster overlap.",
+		MarkdownDescription: "Returns `true` if any two of the machine, service and pod blocks of IP " +
+			"addresses overlap, which isn't allowed for the `machine_cidr`, `service_cidr` and `pod_cidr` " +
+			"attributes of a cluster.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "machine",
+				Description: "Block of IP addresses for nodes.",
+			},
+			function.StringParameter{
+				Name:        "service",
+				Description: "Block of IP addresses for the cluster service network.",
+			},
+			function.StringParameter{
+				Name:        "pod",
+				Description: "Block of IP addresses for pods.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *CIDRsOverlapFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	cidrs := make([]string, 3)
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &cidrs[0], &cidrs[1], &cidrs[2]))
+	if resp.Error != nil {
+		return
+	}
+
+	for i, cidr := range cidrs {
+		if err := common.ValidateCIDR(cidr); err != nil {
+			resp.Error = function.NewArgumentFuncError(int64(i), err.Error())
+			return
+		}
+	}
+
+	result := false
+	for i := range cidrs {
+		for j := i + 1; j < len(cidrs); j++ {
+			overlap, err := common.CIDRsOverlap(cidrs[i], cidrs[j])
+			if err != nil {
+				resp.Error = function.NewFuncError(err.Error())
+				return
+			}
+			result = result || overlap
+		}
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
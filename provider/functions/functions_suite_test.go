@@ -0,0 +1,13 @@
+package functions
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestFunctions(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Functions Suite")
+}
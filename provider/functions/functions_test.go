@@ -0,0 +1,124 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/ginkgo/v2/dsl/core"  // nolint
+	. "github.com/onsi/ginkgo/v2/dsl/table" // nolint
+	. "github.com/onsi/gomega"              // nolint
+)
+
+// run calls the given function with the given arguments and returns the result.
+func run(f function.Function, args ...attr.Value) (attr.Value, *function.FuncError) {
+	ctx := context.Background()
+	definition := &function.DefinitionResponse{}
+	f.Definition(ctx, function.DefinitionRequest{}, definition)
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData(args),
+	}
+	resp := &function.RunResponse{
+		Result: function.NewResultData(definition.Definition.Return.GetType().ValueType(ctx)),
+	}
+	f.Run(ctx, req, resp)
+	return resp.Result.Value(), resp.Error
+}
+
+var _ = Describe("Functions", func() {
+	DescribeTable("minor_version",
+		func(version string, expected string) {
+			result, err := run(NewMinorVersion(), types.StringValue(version))
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal(types.StringValue(expected)))
+		},
+		Entry("Patch version", "4.14.2", "4.14"),
+		Entry("OCM version", "openshift-v4.15.0", "4.15"),
+		Entry("Candidate version", "4.16.0-rc.1", "4.16"),
+	)
+
+	It("minor_version fails with an invalid version", func() {
+		_, err := run(NewMinorVersion(), types.StringValue("junk"))
+		Expect(err).ToNot(BeNil())
+		Expect(err.Text).To(ContainSubstring("Value 'junk' isn't a valid version"))
+	})
+
+	DescribeTable("version_compare",
+		func(a, b string, expected int64) {
+			result, err := run(NewVersionCompare(), types.StringValue(a), types.StringValue(b))
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal(types.Int64Value(expected)))
+		},
+		Entry("Older", "4.14.2", "4.14.10", int64(-1)),
+		Entry("Equal", "openshift-v4.14.2", "4.14.2", int64(0)),
+		Entry("Newer", "4.15.0", "4.14.10", int64(1)),
+	)
+
+	It("version_compare reports the invalid argument", func() {
+		_, err := run(NewVersionCompare(), types.StringValue("4.14.2"), types.StringValue("junk"))
+		Expect(err).ToNot(BeNil())
+		Expect(err.FunctionArgument).ToNot(BeNil())
+		Expect(*err.FunctionArgument).To(BeEquivalentTo(1))
+	})
+
+	DescribeTable("cidrs_overlap",
+		func(machine, service, pod string, expected bool) {
+			result, err := run(NewCIDRsOverlap(),
+				types.StringValue(machine), types.StringValue(service), types.StringValue(pod))
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal(types.BoolValue(expected)))
+		},
+		Entry("Defaults", "10.0.0.0/16", "172.30.0.0/16", "10.128.0.0/14", false),
+		Entry("Machine contains pod", "10.0.0.0/8", "172.30.0.0/16", "10.128.0.0/14", true),
+		Entry("Pod contains service", "10.0.0.0/16", "172.30.0.0/16", "172.0.0.0/8", true),
+	)
+
+	It("cidrs_overlap reports the invalid argument", func() {
+		_, err := run(NewCIDRsOverlap(),
+			types.StringValue("10.0.0.0/16"), types.StringValue("junk"), types.StringValue("10.128.0.0/14"))
+		Expect(err).ToNot(BeNil())
+		Expect(*err.FunctionArgument).To(BeEquivalentTo(1))
+		Expect(err.Text).To(ContainSubstring("invalid CIDR 'junk'"))
+	})
+
+	It("operator_role_arns returns the classic roles", func() {
+		result, err := run(NewOperatorRoleARNs(), types.StringValue("my-prefix"),
+			types.StringValue("123456789012"), types.StringValue("4.14.2"), types.BoolValue(false))
+		Expect(err).To(BeNil())
+		list := result.(types.List)
+		Expect(list.Elements()).To(Equal([]attr.Value{
+			types.StringValue("arn:aws:iam::123456789012:role/my-prefix-openshift-cloud-credential-operator-cloud-credential-o"),
+			types.StringValue("arn:aws:iam::123456789012:role/my-prefix-openshift-cloud-network-config-controller-cloud-creden"),
+			types.StringValue("arn:aws:iam::123456789012:role/my-prefix-openshift-cluster-csi-drivers-ebs-cloud-credentials"),
+			types.StringValue("arn:aws:iam::123456789012:role/my-prefix-openshift-image-registry-installer-cloud-credentials"),
+			types.StringValue("arn:aws:iam::123456789012:role/my-prefix-openshift-ingress-operator-cloud-credentials"),
+			types.StringValue("arn:aws:iam::123456789012:role/my-prefix-openshift-machine-api-aws-cloud-credentials"),
+		}))
+	})
+
+	It("operator_role_arns returns the hosted control plane roles", func() {
+		result, err := run(NewOperatorRoleARNs(), types.StringValue("my-prefix"),
+			types.StringValue("123456789012"), types.StringValue("openshift-v4.15.0"), types.BoolValue(true))
+		Expect(err).To(BeNil())
+		list := result.(types.List)
+		Expect(list.Elements()).To(HaveLen(8))
+		Expect(list.Elements()[0]).To(Equal(
+			types.StringValue("arn:aws:iam::123456789012:role/my-prefix-kube-system-capa-controller-manager"),
+		))
+	})
+
+	It("operator_role_arns skips the roles not supported by the version", func() {
+		result, err := run(NewOperatorRoleARNs(), types.StringValue("my-prefix"),
+			types.StringValue("123456789012"), types.StringValue("4.9.0"), types.BoolValue(false))
+		Expect(err).To(BeNil())
+		Expect(result.(types.List).Elements()).To(HaveLen(5))
+	})
+
+	It("operator_role_arns fails with an invalid account", func() {
+		_, err := run(NewOperatorRoleARNs(), types.StringValue("my-prefix"),
+			types.StringValue("1234"), types.StringValue("4.14.2"), types.BoolValue(false))
+		Expect(err).ToNot(BeNil())
+		Expect(err.Text).To(ContainSubstring("Value '1234' isn't a valid AWS account identifier"))
+	})
+})
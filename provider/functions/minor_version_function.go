@@ -0,0 +1,84 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+)
+
+const versionPrefix = "openshift-v"
+
+type MinorVersionFunction struct{}
+
+var _ function.Function = &MinorVersionFunction{}
+
+func NewMinorVersion() function.Function {
+	return &MinorVersionFunction{}
+}
+
+func (f *MinorVersionFunction) Metadata(ctx context.Context, req function.MetadataRequest,
+	resp *function.MetadataResponse) {
+	resp.Name = "minor_version"
+}
+
+func (f *MinorVersionFunction) Definition(ctx context.Context, req function.DefinitionRequest,
+	resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Returns the minor version of an OpenShift version.",
+		MarkdownDescription: "Returns the major and minor segments of an OpenShift version, for example " +
+			"`4.14` for `4.14.2`. The version may include the `openshift-v` prefix used by OCM.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "version",
+				Description: "OpenShift version, for example `4.14.2` or `openshift-v4.14.2`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *MinorVersionFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	value, err := parseVersion(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, common.GetOcmVersionMinor(value)))
+}
+
+// parseVersion checks that the given text is a valid version and returns it without the `openshift-v`
+// prefix used by OCM.
+func parseVersion(text string) (string, error) {
+	text = strings.TrimPrefix(text, versionPrefix)
+	if _, err := version.NewVersion(text); err != nil {
+		return "", fmt.Errorf("Value '%s' isn't a valid version: %v", text, err)
+	}
+	return text, nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package functions
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+)
+
+// operatorRole describes one of the operators that need an IAM role. Unlike the `rosa_operator_roles`
+// data sources these functions can't ask OCM for the list of operators, so it is kept here.
+type operatorRole struct {
+	namespace  string
+	name       string
+	minVersion string
+	classic    bool
+	hcp        bool
+}
+
+var operatorRoles = []operatorRole{
+	{namespace: "kube-system", name: "capa-controller-manager", hcp: true},
+	{namespace: "kube-system", name: "control-plane-operator", hcp: true},
+	{namespace: "kube-system", name: "kms-provider", hcp: true},
+	{namespace: "kube-system", name: "kube-controller-manager", hcp: true},
+	{namespace: "openshift-cloud-credential-operator", name: "cloud-credential-operator-iam-ro-creds", classic: true},
+	{namespace: "openshift-cloud-network-config-controller", name: "cloud-credentials", minVersion: "4.10",
+		classic: true, hcp: true},
+	{namespace: "openshift-cluster-csi-drivers", name: "ebs-cloud-credentials", classic: true, hcp: true},
+	{namespace: "openshift-image-registry", name: "installer-cloud-credentials", classic: true, hcp: true},
+	{namespace: "openshift-ingress-operator", name: "cloud-credentials", classic: true, hcp: true},
+	{namespace: "openshift-machine-api", name: "aws-cloud-credentials", classic: true},
+}
+
+const (
+	roleARNFmt        = "arn:aws:iam::%s:role/%s"
+	maxRoleNameLength = 64
+)
+
+var accountIDRE = regexp.MustCompile(`^[0-9]{12}$`)
+
+type OperatorRoleARNsFunction struct{}
+
+var _ function.Function = &OperatorRoleARNsFunction{}
+
+func NewOperatorRoleARNs() function.Function {
+	return &OperatorRoleARNsFunction{}
+}
+
+func (f *OperatorRoleARNsFunction) Metadata(ctx context.Context, req function.MetadataRequest,
+	resp *function.MetadataResponse) {
+	resp.Name = "operator_role_arns"
+}
+
+func (f *OperatorRoleARNsFunction) Definition(ctx context.Context, req function.DefinitionRequest,
+	resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Returns the ARNs of the operator IAM roles of a cluster.",
+		MarkdownDescription: "Returns the ARNs of the operator IAM roles of a cluster, named like the roles " +
+			"returned by the `rhcs_rosa_operator_roles` and `rhcs_rosa_hcp_operator_roles` data sources: " +
+			"`<prefix>-<namespace>-<name>`, truncated to 64 characters. The list is sorted by namespace " +
+			"and name, and it only contains the operators supported by the given OpenShift version.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "prefix",
+				Description: "Operator role prefix.",
+			},
+			function.StringParameter{
+				Name:        "account_id",
+				Description: "Identifier of the AWS account.",
+			},
+			function.StringParameter{
+				Name:        "version",
+				Description: "OpenShift version of the cluster, for example `4.14.2` or `openshift-v4.14.2`.",
+			},
+			function.BoolParameter{
+				Name:        "hcp",
+				Description: "Return the roles of a hosted control plane cluster instead of a classic cluster.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *OperatorRoleARNsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var prefix, accountID, version string
+	var hcp bool
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &prefix, &accountID, &version, &hcp))
+	if resp.Error != nil {
+		return
+	}
+
+	if prefix == "" {
+		resp.Error = function.NewArgumentFuncError(0, "Operator role prefix can't be empty")
+		return
+	}
+	if !accountIDRE.MatchString(accountID) {
+		resp.Error = function.NewArgumentFuncError(1,
+			fmt.Sprintf("Value '%s' isn't a valid AWS account identifier, it should contain 12 digits", accountID))
+		return
+	}
+	version, err := parseVersion(version)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(2, err.Error())
+		return
+	}
+
+	result := []string{}
+	for _, role := range operatorRoles {
+		if (hcp && !role.hcp) || (!hcp && !role.classic) {
+			continue
+		}
+		if role.minVersion != "" {
+			supported, err := common.IsGreaterThanOrEqual(version, role.minVersion)
+			if err != nil {
+				resp.Error = function.NewFuncError(err.Error())
+				return
+			}
+			if !supported {
+				continue
+			}
+		}
+		result = append(result, fmt.Sprintf(roleARNFmt, accountID, operatorRoleName(prefix, role)))
+	}
+	sort.Strings(result)
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
+
+func operatorRoleName(prefix string, role operatorRole) string {
+	name := fmt.Sprintf("%s-%s-%s", prefix, role.namespace, role.name)
+	if len(name) > maxRoleNameLength {
+		name = name[0:maxRoleNameLength]
+	}
+	return name
+}
@@ -0,0 +1,95 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+)
+
+type VersionCompareFunction struct{}
+
+var _ function.Function = &VersionCompareFunction{}
+
+func NewVersionCompare() function.Function {
+	return &VersionCompareFunction{}
+}
+
+func (f *VersionCompareFunction) Metadata(ctx context.Context, req function.MetadataRequest,
+	resp *function.MetadataResponse) {
+	resp.Name = "version_compare"
+}
+
+func (f *VersionCompareFunction) Definition(ctx context.Context, req function.DefinitionRequest,
+	resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compares two OpenShift versions.",
+		MarkdownDescription: "Compares two OpenShift versions and returns `-1` if the first is older than the " +
+			"second, `0` if they are equal and `1` if the first is newer than the second. The versions may " +
+			"include the `openshift-v` prefix used by OCM.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "a",
+				Description: "First version.",
+			},
+			function.StringParameter{
+				Name:        "b",
+				Description: "Second version.",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *VersionCompareFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var a, b string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &a, &b))
+	if resp.Error != nil {
+		return
+	}
+
+	for i, v := range []string{a, b} {
+		if _, err := parseVersion(v); err != nil {
+			resp.Error = function.NewArgumentFuncError(int64(i), err.Error())
+			return
+		}
+	}
+
+	aIsGreaterOrEqual, err := common.IsGreaterThanOrEqual(a, b)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+	bIsGreaterOrEqual, err := common.IsGreaterThanOrEqual(b, a)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	var result int64
+	switch {
+	case aIsGreaterOrEqual && bIsGreaterOrEqual:
+		result = 0
+	case aIsGreaterOrEqual:
+		result = 1
+	default:
+		result = -1
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
@@ -19,24 +19,21 @@ import (
 )
 
 type GithubIdentityProvider struct {
-	CA            types.String `tfsdk:"ca"`
-	ClientID      types.String `tfsdk:"client_id"`
-	ClientSecret  types.String `tfsdk:"client_secret"`
-	Hostname      types.String `tfsdk:"hostname"`
-	Organizations types.List   `tfsdk:"organizations"`
-	Teams         types.List   `tfsdk:"teams"`
+	CA                    types.String `tfsdk:"ca"`
+	ClientID              types.String `tfsdk:"client_id"`
+	ClientSecret          types.String `tfsdk:"client_secret"`
+	ClientSecretWO        types.String `tfsdk:"client_secret_wo"`
+	ClientSecretWOVersion types.Int64  `tfsdk:"client_secret_wo_version"`
+	Hostname              types.String `tfsdk:"hostname"`
+	Organizations         types.List   `tfsdk:"organizations"`
+	Teams                 types.List   `tfsdk:"teams"`
 }
 
-var githubSchema = map[string]schema.Attribute{
+var githubSchema = addClientSecretAttributes(map[string]schema.Attribute{
 	"client_id": schema.StringAttribute{
 		Description: "Client identifier of a registered Github OAuth application.",
 		Required:    true,
 	},
-	"client_secret": schema.StringAttribute{
-		Description: "Client secret issued by Github.",
-		Required:    true,
-		Sensitive:   true,
-	},
 	"ca": schema.StringAttribute{
 		Description: "Path to PEM-encoded certificate file to use when making requests to the server.",
 		Optional:    true,
@@ -69,7 +66,7 @@ var githubSchema = map[string]schema.Attribute{
 			),
 		},
 	},
-}
+}, "Client secret issued by Github.")
 
 func githubTeamsFormatValidator() validator.String {
 	return attrvalidators.NewStringValidator("validate teams format", func(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
@@ -109,7 +106,7 @@ func githubHostnameValidator() validator.String {
 func CreateGithubIDPBuilder(ctx context.Context, state *GithubIdentityProvider) (*cmv1.GithubIdentityProviderBuilder, error) {
 	githubBuilder := cmv1.NewGithubIdentityProvider()
 	githubBuilder.ClientID(state.ClientID.ValueString())
-	githubBuilder.ClientSecret(state.ClientSecret.ValueString())
+	githubBuilder.ClientSecret(common.SecretValue(state.ClientSecret, state.ClientSecretWO))
 	if !state.CA.IsUnknown() && !state.CA.IsNull() {
 		githubBuilder.CA(state.CA.ValueString())
 	}
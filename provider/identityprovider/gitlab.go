@@ -10,26 +10,24 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common/attrvalidators"
 )
 
 type GitlabIdentityProvider struct {
-	CA           types.String `tfsdk:"ca"`
-	ClientID     types.String `tfsdk:"client_id"`
-	ClientSecret types.String `tfsdk:"client_secret"`
-	URL          types.String `tfsdk:"url"`
+	CA                    types.String `tfsdk:"ca"`
+	ClientID              types.String `tfsdk:"client_id"`
+	ClientSecret          types.String `tfsdk:"client_secret"`
+	ClientSecretWO        types.String `tfsdk:"client_secret_wo"`
+	ClientSecretWOVersion types.Int64  `tfsdk:"client_secret_wo_version"`
+	URL                   types.String `tfsdk:"url"`
 }
 
-var gitlabSchema = map[string]schema.Attribute{
+var gitlabSchema = addClientSecretAttributes(map[string]schema.Attribute{
 	"client_id": schema.StringAttribute{
 		Description: "Client identifier of a registered Gitlab OAuth application.",
 		Required:    true,
 	},
-	"client_secret": schema.StringAttribute{
-		Description: "Client secret issued by Gitlab.",
-		Required:    true,
-		Sensitive:   true,
-	},
 	"url": schema.StringAttribute{
 		Description: "URL of the Gitlab instance.",
 		Required:    true,
@@ -41,7 +39,7 @@ var gitlabSchema = map[string]schema.Attribute{
 		Description: "Optional trusted certificate authority bundle.",
 		Optional:    true,
 	},
-}
+}, "Client secret issued by Gitlab.")
 
 func gitlabUrlValidator() validator.String {
 	return attrvalidators.NewStringValidator("url validator", func(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
@@ -65,7 +63,7 @@ func CreateGitlabIDPBuilder(ctx context.Context, state *GitlabIdentityProvider)
 		gitlabBuilder.CA(state.CA.ValueString())
 	}
 	gitlabBuilder.ClientID(state.ClientID.ValueString())
-	gitlabBuilder.ClientSecret(state.ClientSecret.ValueString())
+	gitlabBuilder.ClientSecret(common.SecretValue(state.ClientSecret, state.ClientSecretWO))
 	gitlabBuilder.URL(state.URL.ValueString())
 	return gitlabBuilder, nil
 }
@@ -14,21 +14,18 @@ import (
 )
 
 type GoogleIdentityProvider struct {
-	ClientID     types.String `tfsdk:"client_id"`
-	ClientSecret types.String `tfsdk:"client_secret"`
-	HostedDomain types.String `tfsdk:"hosted_domain"`
+	ClientID              types.String `tfsdk:"client_id"`
+	ClientSecret          types.String `tfsdk:"client_secret"`
+	ClientSecretWO        types.String `tfsdk:"client_secret_wo"`
+	ClientSecretWOVersion types.Int64  `tfsdk:"client_secret_wo_version"`
+	HostedDomain          types.String `tfsdk:"hosted_domain"`
 }
 
-var googleSchema = map[string]schema.Attribute{
+var googleSchema = addClientSecretAttributes(map[string]schema.Attribute{
 	"client_id": schema.StringAttribute{
 		Description: "Client identifier of a registered Google OAuth application.",
 		Required:    true,
 	},
-	"client_secret": schema.StringAttribute{
-		Description: "Client secret issued by Google.",
-		Required:    true,
-		Sensitive:   true,
-	},
 	"hosted_domain": schema.StringAttribute{
 		Description: "Restrict users to a Google Apps domain.",
 		Optional:    true,
@@ -36,7 +33,7 @@ var googleSchema = map[string]schema.Attribute{
 			googleHostedDomainValidator(),
 		},
 	},
-}
+}, "Client secret issued by Google.")
 
 func googleHostedDomainValidator() validator.String {
 	errSumm := "Invalid Google IDP resource configuration"
@@ -54,7 +51,7 @@ func googleHostedDomainValidator() validator.String {
 func CreateGoogleIDPBuilder(ctx context.Context, mappingMethod string, state *GoogleIdentityProvider) (*cmv1.GoogleIdentityProviderBuilder, error) {
 	builder := cmv1.NewGoogleIdentityProvider()
 	builder.ClientID(state.ClientID.ValueString())
-	builder.ClientSecret(state.ClientSecret.ValueString())
+	builder.ClientSecret(common.SecretValue(state.ClientSecret, state.ClientSecretWO))
 
 	// Mapping method validation. if mappingMethod != lookup, then hosted-domain is mandatory.
 	if mappingMethod != string(cmv1.IdentityProviderMappingMethodLookup) {
@@ -7,13 +7,16 @@ import (
 	"reflect"
 	"regexp"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	idputils "github.com/openshift-online/ocm-common/pkg/idp/utils"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common/attrvalidators"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/identityprovider/htpasswd"
 
@@ -45,8 +48,19 @@ var (
 )
 
 type HTPasswdUser struct {
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
+	Username          types.String `tfsdk:"username"`
+	Password          types.String `tfsdk:"password"`
+	PasswordWO        types.String `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int64  `tfsdk:"password_wo_version"`
+}
+
+// revision returns the value that changes when the password of the user has to be sent to OCM:
+// the password itself, or the version of the write-only password.
+func (u HTPasswdUser) revision() string {
+	if !u.Password.IsNull() {
+		return u.Password.ValueString()
+	}
+	return fmt.Sprintf("password_wo_version(%s)", u.PasswordWOVersion.String())
 }
 
 type HTPasswdIdentityProvider struct {
@@ -74,11 +88,27 @@ var htpasswdUserList = map[string]schema.Attribute{
 		Validators:  HTPasswdUsernameValidators,
 	},
 	"password": schema.StringAttribute{
-		Description: "User password.",
-		Required:    true,
+		Description: "User password. Exactly one of `password` and `password_wo` must be set.",
+		Optional:    true,
 		Sensitive:   true,
+		Validators: append([]validator.String{
+			stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("password_wo")),
+		}, HTPasswdPasswordValidators...),
+	},
+	"password_wo": schema.StringAttribute{
+		Description: common.WriteOnlyDescription("User password.", "password_wo_version"),
+		Optional:    true,
+		Sensitive:   true,
+		WriteOnly:   true,
 		Validators:  HTPasswdPasswordValidators,
 	},
+	"password_wo_version": schema.Int64Attribute{
+		Description: common.WriteOnlyVersionDescription("password_wo"),
+		Optional:    true,
+		Validators: []validator.Int64{
+			int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("password_wo")),
+		},
+	},
 }
 
 func CreateHTPasswdIDPBuilder(ctx context.Context, state *HTPasswdIdentityProvider) (*cmv1.HTPasswdIdentityProviderBuilder, error) {
@@ -86,12 +116,13 @@ func CreateHTPasswdIDPBuilder(ctx context.Context, state *HTPasswdIdentityProvid
 	userListBuilder := cmv1.NewHTPasswdUserList()
 	userList := []*cmv1.HTPasswdUserBuilder{}
 	for _, user := range state.Users {
-		hashedPwd, err := idputils.GenerateHTPasswdCompatibleHash(user.Password.ValueString())
+		password := common.SecretValue(user.Password, user.PasswordWO)
+		hashedPwd, err := idputils.GenerateHTPasswdCompatibleHash(password)
 		if err != nil {
 			return nil, err
 		}
 		if os.Getenv("IS_TEST") == "true" {
-			hashedPwd = fmt.Sprintf("hash(%s)", user.Password.ValueString())
+			hashedPwd = fmt.Sprintf("hash(%s)", password)
 		}
 		userBuilder := &cmv1.HTPasswdUserBuilder{}
 		userBuilder.Username(user.Username.ValueString())
@@ -132,11 +163,15 @@ func htPasswdUserListToStringMaps(ctx context.Context, users []HTPasswdUser,
 		return nil, err
 	}
 	for _, user := range get.Items().Slice() {
-		csUserMap[user.Username()] = htpasswd.HtPasswdUserWithId{user.ID(), user.Username(), ""}
+		csUserMap[user.Username()] = htpasswd.HtPasswdUserWithId{Id: user.ID(), Username: user.Username()}
 	}
 	for _, user := range users {
-		csUserMap[user.Username.ValueString()] = htpasswd.HtPasswdUserWithId{csUserMap[user.Username.ValueString()].Id,
-			csUserMap[user.Username.ValueString()].Username, user.Password.ValueString()}
+		csUserMap[user.Username.ValueString()] = htpasswd.HtPasswdUserWithId{
+			Id:       csUserMap[user.Username.ValueString()].Id,
+			Username: csUserMap[user.Username.ValueString()].Username,
+			Password: common.SecretValue(user.Password, user.PasswordWO),
+			Revision: user.revision(),
+		}
 	}
 	finalUserMap := make(map[string]htpasswd.HtPasswdUserWithId)
 	// Remove deleted users
@@ -13,6 +13,9 @@ type HtPasswdUserWithId struct {
 	Id       string
 	Username string
 	Password string
+	// Revision changes when the password has to be sent to OCM. It is the password itself, or the
+	// version of the password when it is given in a write-only attribute.
+	Revision string
 }
 
 type PatchParams struct {
@@ -51,7 +54,7 @@ func PatchOrAddUserInState(params PatchParams) error {
 	}
 	for user, planValue := range params.PlanUserMap {
 		if stateValue, ok := params.StateUserMap[user]; ok { // Is in current state
-			if planValue != stateValue && !slices.Contains(params.RemovedUsers, user) { // Password changed, update
+			if planValue.Revision != stateValue.Revision && !slices.Contains(params.RemovedUsers, user) { // Password changed, update
 				err := UpdateUser(params.Ctx, planValue.Password, planValue.Id, params.Resource)
 				if err != nil {
 					return err
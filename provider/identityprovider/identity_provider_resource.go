@@ -93,6 +93,13 @@ func (r *IdentityProviderResource) Schema(ctx context.Context, req resource.Sche
 				},
 				Default: stringdefault.StaticString(defaultMappingMethod),
 			},
+			"deletion_policy": schema.StringAttribute{
+				Description: common.DeletionPolicyDescription("identity provider"),
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(common.DeletionPolicyDelete, common.DeletionPolicyRetain),
+				},
+			},
 			"htpasswd": schema.SingleNestedAttribute{
 				Description: "Details of the 'htpasswd' identity provider.",
 				Attributes:  htpasswdSchema,
@@ -182,6 +189,10 @@ func (r *IdentityProviderResource) Create(ctx context.Context, request resource.
 	if response.Diagnostics.HasError() {
 		return
 	}
+	response.Diagnostics.Append(copyWriteOnlyAttributes(ctx, request.Config, state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
 
 	resource := r.collection.Cluster(state.Cluster.ValueString())
 	// We expect the cluster to be already exist
@@ -216,64 +227,15 @@ func (r *IdentityProviderResource) Create(ctx context.Context, request resource.
 		return
 	}
 
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(state.Cluster.ValueString())
+	defer common.ClusterMutexKV.Unlock(state.Cluster.ValueString())
+
 	// Create the identity provider:
-	builder := cmv1.NewIdentityProvider()
-	builder.Name(state.Name.ValueString())
-	// handle mapping_method
-	mappingMethod := defaultMappingMethod
-	if common.HasValue(state.MappingMethod) {
-		mappingMethod = state.MappingMethod.ValueString()
-	}
-	builder.MappingMethod(cmv1.IdentityProviderMappingMethod(mappingMethod))
-	switch {
-	case state.HTPasswd != nil:
-		builder.Type(cmv1.IdentityProviderTypeHtpasswd)
-		htpasswdBuilder, err := CreateHTPasswdIDPBuilder(ctx, state.HTPasswd)
-		if err != nil {
-			response.Diagnostics.AddError(err.Error(), err.Error())
-			return
-		}
-		builder.Htpasswd(htpasswdBuilder)
-	case state.Gitlab != nil:
-		builder.Type(cmv1.IdentityProviderTypeGitlab)
-		gitlabBuilder, err := CreateGitlabIDPBuilder(ctx, state.Gitlab)
-		if err != nil {
-			response.Diagnostics.AddError(err.Error(), err.Error())
-			return
-		}
-		builder.Gitlab(gitlabBuilder)
-	case state.Github != nil:
-		builder.Type(cmv1.IdentityProviderTypeGithub)
-		githubBuilder, err := CreateGithubIDPBuilder(ctx, state.Github)
-		if err != nil {
-			response.Diagnostics.AddError(err.Error(), err.Error())
-			return
-		}
-		builder.Github(githubBuilder)
-	case state.Google != nil:
-		builder.Type(cmv1.IdentityProviderTypeGoogle)
-		googleBuilder, err := CreateGoogleIDPBuilder(ctx, mappingMethod, state.Google)
-		if err != nil {
-			response.Diagnostics.AddError(err.Error(), err.Error())
-			return
-		}
-		builder.Google(googleBuilder)
-	case state.LDAP != nil:
-		builder.Type(cmv1.IdentityProviderTypeLDAP)
-		ldapBuilder, err := CreateLDAPIDPBuilder(ctx, state.LDAP)
-		if err != nil {
-			response.Diagnostics.AddError(err.Error(), err.Error())
-			return
-		}
-		builder.LDAP(ldapBuilder)
-	case state.OpenID != nil:
-		builder.Type(cmv1.IdentityProviderTypeOpenID)
-		openidBuilder, err := CreateOpenIDIDPBuilder(ctx, state.OpenID)
-		if err != nil {
-			response.Diagnostics.AddError(err.Error(), err.Error())
-			return
-		}
-		builder.OpenID(openidBuilder)
+	builder, err := createIDPBuilder(ctx, state)
+	if err != nil {
+		response.Diagnostics.AddError(err.Error(), err.Error())
+		return
 	}
 	object, err := builder.Build()
 	if err != nil {
@@ -387,6 +349,8 @@ func (r *IdentityProviderResource) Read(ctx context.Context, request resource.Re
 			})
 		}
 	case gitlabObject != nil:
+		// The secret isn't copied to the state when it is given in the write-only attribute:
+		writeOnlySecret := state.Gitlab != nil && state.Gitlab.ClientSecret.IsNull()
 		if state.Gitlab == nil {
 			state.Gitlab = &GitlabIdentityProvider{}
 		}
@@ -399,7 +363,7 @@ func (r *IdentityProviderResource) Read(ctx context.Context, request resource.Re
 			state.Gitlab.ClientID = types.StringValue(client_id)
 		}
 		client_secret, ok := gitlabObject.GetClientSecret()
-		if ok {
+		if ok && !writeOnlySecret {
 			state.Gitlab.ClientSecret = types.StringValue(client_secret)
 		}
 		url, ok := gitlabObject.GetURL()
@@ -407,6 +371,7 @@ func (r *IdentityProviderResource) Read(ctx context.Context, request resource.Re
 			state.Gitlab.URL = types.StringValue(url)
 		}
 	case githubObject != nil:
+		writeOnlySecret := state.Github != nil && state.Github.ClientSecret.IsNull()
 		if state.Github == nil {
 			state.Github = &GithubIdentityProvider{}
 		}
@@ -419,7 +384,7 @@ func (r *IdentityProviderResource) Read(ctx context.Context, request resource.Re
 			state.Github.ClientID = types.StringValue(client_id)
 		}
 		client_secret, ok := githubObject.GetClientSecret()
-		if ok {
+		if ok && !writeOnlySecret {
 			state.Github.ClientSecret = types.StringValue(client_secret)
 		}
 		hostname, ok := githubObject.GetHostname()
@@ -445,19 +410,21 @@ func (r *IdentityProviderResource) Read(ctx context.Context, request resource.Re
 			state.Github.Organizations = types.ListNull(types.StringType)
 		}
 	case googleObject != nil:
+		writeOnlySecret := state.Google != nil && state.Google.ClientSecret.IsNull()
 		if state.Google == nil {
 			state.Google = &GoogleIdentityProvider{}
 		}
 		if client_id, ok := googleObject.GetClientID(); ok {
 			state.Google.ClientID = types.StringValue(client_id)
 		}
-		if client_secret, ok := googleObject.GetClientSecret(); ok {
+		if client_secret, ok := googleObject.GetClientSecret(); ok && !writeOnlySecret {
 			state.Google.ClientSecret = types.StringValue(client_secret)
 		}
 		if hosted_domain, ok := googleObject.GetHostedDomain(); ok {
 			state.Google.HostedDomain = types.StringValue(hosted_domain)
 		}
 	case ldapObject != nil:
+		writeOnlyPassword := state.LDAP != nil && state.LDAP.BindPassword.IsNull()
 		if state.LDAP == nil {
 			state.LDAP = &LDAPIdentityProvider{}
 		}
@@ -466,7 +433,7 @@ func (r *IdentityProviderResource) Read(ctx context.Context, request resource.Re
 			state.LDAP.BindDN = types.StringValue(bindDN)
 		}
 		bindPassword, ok := ldapObject.GetBindPassword()
-		if ok {
+		if ok && !writeOnlyPassword {
 			state.LDAP.BindPassword = types.StringValue(bindPassword)
 		}
 		ca, ok := ldapObject.GetCA()
@@ -518,6 +485,7 @@ func (r *IdentityProviderResource) Read(ctx context.Context, request resource.Re
 			}
 		}
 	case openidObject != nil:
+		writeOnlySecret := state.OpenID != nil && state.OpenID.ClientSecret.IsNull()
 		if state.OpenID == nil {
 			state.OpenID = &OpenIDIdentityProvider{}
 		}
@@ -530,7 +498,7 @@ func (r *IdentityProviderResource) Read(ctx context.Context, request resource.Re
 			state.OpenID.ClientID = types.StringValue(client_id)
 		}
 		client_secret, ok := openidObject.GetClientSecret()
-		if ok {
+		if ok && !writeOnlySecret {
 			state.OpenID.ClientSecret = types.StringValue(client_secret)
 		}
 		claims, ok := openidObject.GetClaims()
@@ -593,12 +561,6 @@ func (r *IdentityProviderResource) Update(ctx context.Context, request resource.
 		return
 	}
 
-	if state.HTPasswd == nil {
-		response.Diagnostics.AddError("IDP Update not supported for non-HTPasswd IDPs.",
-			"This RHCS provider version does not support updating an existing IDP")
-		return
-	}
-
 	// Get the plan:
 	plan := &IdentityProviderState{}
 	diags = request.Plan.Get(ctx, plan)
@@ -608,15 +570,137 @@ func (r *IdentityProviderResource) Update(ctx context.Context, request resource.
 	}
 	plan.ID = state.ID
 
+	// Changing only the deletion policy doesn't require sending anything to OCM:
+	onlyPolicy, err := onlyDeletionPolicyChanged(request, state, plan)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Can't compare identity provider state and plan",
+			fmt.Sprintf(
+				"Can't compare state and plan of identity provider with identifier '%s': %v",
+				state.ID.ValueString(), err,
+			),
+		)
+		return
+	}
+	if onlyPolicy {
+		state.DeletionPolicy = plan.DeletionPolicy
+		diags = response.State.Set(ctx, state)
+		response.Diagnostics.Append(diags...)
+		return
+	}
+
+	response.Diagnostics.Append(copyWriteOnlyAttributes(ctx, request.Config, plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// The only supported change of the identity providers that aren't `htpasswd` is sending
+	// their write-only secret again, so reject the plan if any other attribute changed, as it
+	// would otherwise be sent to OCM together with the secret:
+	if state.HTPasswd == nil {
+		// The deletion policy isn't sent to OCM, so it can change together with the secret:
+		ignored := append([]string{"deletion_policy"}, secretVersionAttributes...)
+		changed, err := changedAttributes(request.State, request.Plan, ignored...)
+		if err != nil {
+			response.Diagnostics.AddError(
+				"Can't compare identity provider state and plan",
+				fmt.Sprintf(
+					"Can't compare state and plan of identity provider with identifier '%s': %v",
+					state.ID.ValueString(), err,
+				),
+			)
+			return
+		}
+		if len(changed) > 0 {
+			response.Diagnostics.AddError("IDP Update not supported for non-HTPasswd IDPs.",
+				fmt.Sprintf(
+					"This RHCS provider version does not support updating an existing IDP, "+
+						"other than sending again its write-only secret, but these attributes "+
+						"changed: %s",
+					strings.Join(changed, ", "),
+				),
+			)
+			return
+		}
+		if !secretVersionChanged(state, plan) {
+			response.Diagnostics.AddError("IDP Update not supported for non-HTPasswd IDPs.",
+				"This RHCS provider version does not support updating an existing IDP")
+			return
+		}
+	}
+
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(state.Cluster.ValueString())
+	defer common.ClusterMutexKV.Unlock(state.Cluster.ValueString())
+
 	resource := r.collection.Cluster(state.Cluster.ValueString()).IdentityProviders().
 		IdentityProvider(state.ID.ValueString())
 
-	UpdateHTPasswd(ctx, resource, state, plan, response)
+	if state.HTPasswd != nil {
+		UpdateHTPasswd(ctx, resource, state, plan, response)
+	} else {
+		r.updateSecret(ctx, resource, plan, response)
+	}
 
 	diags = response.State.Set(ctx, plan)
 	response.Diagnostics.Append(diags...)
 }
 
+// onlyDeletionPolicyChanged checks if the deletion policy is the only difference between the state
+// and the plan. Attributes that are unknown in the plan aren't considered changes.
+func onlyDeletionPolicyChanged(request resource.UpdateRequest, state, plan *IdentityProviderState) (bool, error) {
+	if state.DeletionPolicy.Equal(plan.DeletionPolicy) {
+		return false, nil
+	}
+	changed, err := changedAttributes(request.State, request.Plan, "deletion_policy")
+	if err != nil {
+		return false, err
+	}
+	return len(changed) == 0, nil
+}
+
+// updateSecret sends to OCM the identity provider described by the plan, which contains the new
+// value of its write-only secret.
+func (r *IdentityProviderResource) updateSecret(ctx context.Context, resource *cmv1.IdentityProviderClient,
+	plan *IdentityProviderState, response *resource.UpdateResponse) {
+	builder, err := createIDPBuilder(ctx, plan)
+	if err != nil {
+		response.Diagnostics.AddError(err.Error(), err.Error())
+		return
+	}
+	object, err := builder.Build()
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Can't build identity provider",
+			fmt.Sprintf(
+				"Can't build identity provider with name '%s': %v",
+				plan.Name.ValueString(), err,
+			),
+		)
+		return
+	}
+	update, err := resource.Update().Body(object).SendContext(ctx)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Can't update identity provider",
+			fmt.Sprintf(
+				"Can't update identity provider with identifier '%s' for "+
+					"cluster '%s': %v",
+				plan.ID.ValueString(), plan.Cluster.ValueString(), err,
+			),
+		)
+		return
+	}
+	if plan.LDAP != nil {
+		insecure, ok := update.Body().LDAP().GetInsecure()
+		if ok {
+			plan.LDAP.Insecure = types.BoolValue(insecure)
+		} else if plan.LDAP.Insecure.IsUnknown() {
+			plan.LDAP.Insecure = types.BoolNull()
+		}
+	}
+}
+
 func (r *IdentityProviderResource) Delete(ctx context.Context, request resource.DeleteRequest,
 	response *resource.DeleteResponse) {
 	// Get the state:
@@ -627,6 +711,18 @@ func (r *IdentityProviderResource) Delete(ctx context.Context, request resource.
 		return
 	}
 
+	// Keep the identity provider in OCM if requested, only removing it from the state:
+	if common.ShouldRetain(state.DeletionPolicy) {
+		tflog.Info(ctx, fmt.Sprintf("Deletion policy is '%s', keeping identity provider '%s' of cluster '%s' in OCM",
+			common.DeletionPolicyRetain, state.ID.ValueString(), state.Cluster.ValueString()))
+		response.State.RemoveResource(ctx)
+		return
+	}
+
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(state.Cluster.ValueString())
+	defer common.ClusterMutexKV.Unlock(state.Cluster.ValueString())
+
 	// Send the request to delete the identity provider:
 	resource := r.collection.Cluster(state.Cluster.ValueString()).
 		IdentityProviders().
@@ -650,30 +746,30 @@ func (r *IdentityProviderResource) Delete(ctx context.Context, request resource.
 
 func (r *IdentityProviderResource) ImportState(ctx context.Context, request resource.ImportStateRequest,
 	response *resource.ImportStateResponse) {
-	// To import an identity provider, we need to know the cluster ID and the provider name.
+	// To import an identity provider, we need to know the cluster and the provider name.
 	fields := strings.Split(request.ID, ",")
 	if len(fields) != 2 || fields[0] == "" || fields[1] == "" {
 		response.Diagnostics.AddError(
 			"Invalid import identifier",
-			"Identity provider to import should be specified as <cluster_id>,<provider_name>",
+			"Identity provider to import should be specified as <cluster>,<provider_name>, where the "+
+				"cluster is its identifier, name or external identifier",
 		)
 		return
 	}
-	clusterID := fields[0]
 	providerName := fields[1]
 
-	resource := r.collection.Cluster(clusterID)
-	// We expect the cluster to be already exist
-	// Try to get it and if result with NotFound error, return error to user
-	if resp, err := resource.Get().SendContext(ctx); err != nil && resp.Status() == http.StatusNotFound {
-		message := fmt.Sprintf("Cluster %s not found, error: %v", clusterID, err)
-		tflog.Error(ctx, message)
+	// We expect the cluster to already exist, and it can be specified with its identifier, its
+	// name or its external identifier:
+	clusterID, err := common.ResolveClusterID(ctx, r.collection, fields[0])
+	if err != nil {
+		tflog.Error(ctx, err.Error())
 		response.Diagnostics.AddError(
 			"Can't poll cluster state",
-			message,
+			err.Error(),
 		)
 		return
 	}
+	resource := r.collection.Cluster(clusterID)
 
 	providerID, err := getIDPIDFromName(ctx, resource, providerName)
 	if err != nil {
@@ -688,6 +784,63 @@ func (r *IdentityProviderResource) ImportState(ctx context.Context, request reso
 	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("id"), providerID)...)
 }
 
+// createIDPBuilder creates the builder of the identity provider described by the given state.
+func createIDPBuilder(ctx context.Context, state *IdentityProviderState) (*cmv1.IdentityProviderBuilder, error) {
+	builder := cmv1.NewIdentityProvider()
+	builder.Name(state.Name.ValueString())
+	// handle mapping_method
+	mappingMethod := defaultMappingMethod
+	if common.HasValue(state.MappingMethod) {
+		mappingMethod = state.MappingMethod.ValueString()
+	}
+	builder.MappingMethod(cmv1.IdentityProviderMappingMethod(mappingMethod))
+	switch {
+	case state.HTPasswd != nil:
+		builder.Type(cmv1.IdentityProviderTypeHtpasswd)
+		htpasswdBuilder, err := CreateHTPasswdIDPBuilder(ctx, state.HTPasswd)
+		if err != nil {
+			return nil, err
+		}
+		builder.Htpasswd(htpasswdBuilder)
+	case state.Gitlab != nil:
+		builder.Type(cmv1.IdentityProviderTypeGitlab)
+		gitlabBuilder, err := CreateGitlabIDPBuilder(ctx, state.Gitlab)
+		if err != nil {
+			return nil, err
+		}
+		builder.Gitlab(gitlabBuilder)
+	case state.Github != nil:
+		builder.Type(cmv1.IdentityProviderTypeGithub)
+		githubBuilder, err := CreateGithubIDPBuilder(ctx, state.Github)
+		if err != nil {
+			return nil, err
+		}
+		builder.Github(githubBuilder)
+	case state.Google != nil:
+		builder.Type(cmv1.IdentityProviderTypeGoogle)
+		googleBuilder, err := CreateGoogleIDPBuilder(ctx, mappingMethod, state.Google)
+		if err != nil {
+			return nil, err
+		}
+		builder.Google(googleBuilder)
+	case state.LDAP != nil:
+		builder.Type(cmv1.IdentityProviderTypeLDAP)
+		ldapBuilder, err := CreateLDAPIDPBuilder(ctx, state.LDAP)
+		if err != nil {
+			return nil, err
+		}
+		builder.LDAP(ldapBuilder)
+	case state.OpenID != nil:
+		builder.Type(cmv1.IdentityProviderTypeOpenID)
+		openidBuilder, err := CreateOpenIDIDPBuilder(ctx, state.OpenID)
+		if err != nil {
+			return nil, err
+		}
+		builder.OpenID(openidBuilder)
+	}
+	return builder, nil
+}
+
 // getIDPIDFromName returns the ID of the identity provider with the given name.
 func getIDPIDFromName(ctx context.Context, client *cmv1.ClusterClient, name string) (string, error) {
 	tflog.Debug(ctx, "Converting IDP name to ID", map[string]interface{}{"name": name})
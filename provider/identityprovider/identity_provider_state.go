@@ -21,14 +21,15 @@ import (
 )
 
 type IdentityProviderState struct {
-	Cluster       types.String              `tfsdk:"cluster"`
-	ID            types.String              `tfsdk:"id"`
-	Name          types.String              `tfsdk:"name"`
-	MappingMethod types.String              `tfsdk:"mapping_method"`
-	HTPasswd      *HTPasswdIdentityProvider `tfsdk:"htpasswd"`
-	Gitlab        *GitlabIdentityProvider   `tfsdk:"gitlab"`
-	Github        *GithubIdentityProvider   `tfsdk:"github"`
-	Google        *GoogleIdentityProvider   `tfsdk:"google"`
-	LDAP          *LDAPIdentityProvider     `tfsdk:"ldap"`
-	OpenID        *OpenIDIdentityProvider   `tfsdk:"openid"`
+	Cluster        types.String              `tfsdk:"cluster"`
+	ID             types.String              `tfsdk:"id"`
+	Name           types.String              `tfsdk:"name"`
+	MappingMethod  types.String              `tfsdk:"mapping_method"`
+	DeletionPolicy types.String              `tfsdk:"deletion_policy"`
+	HTPasswd       *HTPasswdIdentityProvider `tfsdk:"htpasswd"`
+	Gitlab         *GitlabIdentityProvider   `tfsdk:"gitlab"`
+	Github         *GithubIdentityProvider   `tfsdk:"github"`
+	Google         *GoogleIdentityProvider   `tfsdk:"google"`
+	LDAP           *LDAPIdentityProvider     `tfsdk:"ldap"`
+	OpenID         *OpenIDIdentityProvider   `tfsdk:"openid"`
 }
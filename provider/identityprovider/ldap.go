@@ -2,7 +2,9 @@ package identityprovider
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -10,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common/attrvalidators"
 )
 
 var LDAPAttrDefaultID []string = []string{"dn"}
@@ -18,12 +21,14 @@ var LDAPAttrDefaultName []string = []string{"cn"}
 var LDAPAttrDefaultPrefferedUsername []string = []string{"uid"}
 
 type LDAPIdentityProvider struct {
-	BindDN       types.String                    `tfsdk:"bind_dn"`
-	BindPassword types.String                    `tfsdk:"bind_password"`
-	CA           types.String                    `tfsdk:"ca"`
-	Insecure     types.Bool                      `tfsdk:"insecure"`
-	URL          types.String                    `tfsdk:"url"`
-	Attributes   *LDAPIdentityProviderAttributes `tfsdk:"attributes"`
+	BindDN                types.String                    `tfsdk:"bind_dn"`
+	BindPassword          types.String                    `tfsdk:"bind_password"`
+	BindPasswordWO        types.String                    `tfsdk:"bind_password_wo"`
+	BindPasswordWOVersion types.Int64                     `tfsdk:"bind_password_wo_version"`
+	CA                    types.String                    `tfsdk:"ca"`
+	Insecure              types.Bool                      `tfsdk:"insecure"`
+	URL                   types.String                    `tfsdk:"url"`
+	Attributes            *LDAPIdentityProviderAttributes `tfsdk:"attributes"`
 }
 
 type LDAPIdentityProviderAttributes struct {
@@ -38,15 +43,32 @@ var ldapSchema = map[string]schema.Attribute{
 		Description: "DN to bind with during the search phase.",
 		Optional:    true,
 		Validators: []validator.String{
-			stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("bind_password")),
+			ldapBindDNValidator(),
 		},
 	},
 	"bind_password": schema.StringAttribute{
-		Description: "Password to bind with during the search phase.",
+		Description: "Password to bind with during the search phase. Conflicts with `bind_password_wo`.",
 		Optional:    true,
 		Sensitive:   true,
 		Validators: []validator.String{
 			stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("bind_dn")),
+			stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("bind_password_wo")),
+		},
+	},
+	"bind_password_wo": schema.StringAttribute{
+		Description: common.WriteOnlyDescription("Password to bind with during the search phase.", "bind_password_wo_version"),
+		Optional:    true,
+		Sensitive:   true,
+		WriteOnly:   true,
+		Validators: []validator.String{
+			stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("bind_dn")),
+		},
+	},
+	"bind_password_wo_version": schema.Int64Attribute{
+		Description: common.WriteOnlyVersionDescription("bind_password_wo"),
+		Optional:    true,
+		Validators: []validator.Int64{
+			int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("bind_password_wo")),
 		},
 	},
 	"ca": schema.StringAttribute{
@@ -96,13 +118,36 @@ var ldapAttrSchema = map[string]schema.Attribute{
 	},
 }
 
+// ldapBindDNValidator checks that the password to bind with is given, either in the `bind_password`
+// attribute or in the `bind_password_wo` attribute, when the DN to bind with is given.
+func ldapBindDNValidator() validator.String {
+	return attrvalidators.NewStringValidator("bind DN requires bind password", func(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+		if req.ConfigValue.IsNull() {
+			return
+		}
+		bindPasswordPath := req.Path.ParentPath().AtName("bind_password")
+		bindPasswordWOPath := req.Path.ParentPath().AtName("bind_password_wo")
+		var bindPassword, bindPasswordWO types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, bindPasswordPath, &bindPassword)...)
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, bindPasswordWOPath, &bindPasswordWO)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if bindPassword.IsNull() && bindPasswordWO.IsNull() {
+			resp.Diagnostics.AddAttributeError(req.Path, "Invalid Attribute Combination",
+				fmt.Sprintf("Attribute %q must be specified when %q is specified, unless %q is specified",
+					bindPasswordPath, req.Path, bindPasswordWOPath))
+		}
+	})
+}
+
 func CreateLDAPIDPBuilder(ctx context.Context, state *LDAPIdentityProvider) (*cmv1.LDAPIdentityProviderBuilder, error) {
 	builder := cmv1.NewLDAPIdentityProvider()
 	if !common.IsStringAttributeUnknownOrEmpty(state.BindDN) {
 		builder.BindDN(state.BindDN.ValueString())
 	}
-	if !common.IsStringAttributeUnknownOrEmpty(state.BindPassword) {
-		builder.BindPassword(state.BindPassword.ValueString())
+	if !common.IsStringAttributeUnknownOrEmpty(state.BindPassword) || common.HasValue(state.BindPasswordWO) {
+		builder.BindPassword(common.SecretValue(state.BindPassword, state.BindPasswordWO))
 	}
 	if !common.IsStringAttributeUnknownOrEmpty(state.CA) {
 		builder.CA(state.CA.ValueString())
@@ -13,6 +13,8 @@ type OpenIDIdentityProvider struct {
 	Claims                   *OpenIDIdentityProviderClaims `tfsdk:"claims"`
 	ClientID                 types.String                  `tfsdk:"client_id"`
 	ClientSecret             types.String                  `tfsdk:"client_secret"`
+	ClientSecretWO           types.String                  `tfsdk:"client_secret_wo"`
+	ClientSecretWOVersion    types.Int64                   `tfsdk:"client_secret_wo_version"`
 	ExtraScopes              types.List                    `tfsdk:"extra_scopes"`
 	ExtraAuthorizeParameters types.Map                     `tfsdk:"extra_authorize_parameters"`
 	Issuer                   types.String                  `tfsdk:"issuer"`
@@ -25,7 +27,7 @@ type OpenIDIdentityProviderClaims struct {
 	PreferredUsername types.List `tfsdk:"preferred_username"`
 }
 
-var openidSchema = map[string]schema.Attribute{
+var openidSchema = addClientSecretAttributes(map[string]schema.Attribute{
 	"ca": schema.StringAttribute{
 		Description: "Optional trusted certificate authority bundle.",
 		Optional:    true,
@@ -39,11 +41,6 @@ var openidSchema = map[string]schema.Attribute{
 		Description: "Client ID from the registered application.",
 		Required:    true,
 	},
-	"client_secret": schema.StringAttribute{
-		Description: "Client Secret from the registered application.",
-		Required:    true,
-		Sensitive:   true,
-	},
 	"extra_scopes": schema.ListAttribute{
 		Description: "List of scopes to request, in addition to the 'openid' scope, during the authorization token request.",
 		ElementType: types.StringType,
@@ -57,7 +54,7 @@ var openidSchema = map[string]schema.Attribute{
 		Description: "The URL that the OpenID Provider asserts as the Issuer Identifier. It must use the https scheme with no URL query parameters or fragment.",
 		Required:    true,
 	},
-}
+}, "Client Secret from the registered application.")
 
 var openidClaimsSchema = map[string]schema.Attribute{
 	"email": schema.ListAttribute{
@@ -124,8 +121,8 @@ func CreateOpenIDIDPBuilder(ctx context.Context, state *OpenIDIdentityProvider)
 	if !state.ClientID.IsNull() {
 		builder.ClientID(state.ClientID.ValueString())
 	}
-	if !state.ClientSecret.IsNull() {
-		builder.ClientSecret(state.ClientSecret.ValueString())
+	if !state.ClientSecret.IsNull() || common.HasValue(state.ClientSecretWO) {
+		builder.ClientSecret(common.SecretValue(state.ClientSecret, state.ClientSecretWO))
 	}
 	if common.HasValue(state.ExtraAuthorizeParameters) {
 		elements, err := common.OptionalMap(ctx, state.ExtraAuthorizeParameters)
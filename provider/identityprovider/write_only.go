@@ -0,0 +1,171 @@
+package identityprovider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+)
+
+// secretVersionAttributes are the names of the attributes that contain the version of the
+// write-only secrets of the identity providers.
+var secretVersionAttributes = []string{
+	"bind_password_wo_version",
+	"client_secret_wo_version",
+	"password_wo_version",
+}
+
+// addClientSecretAttributes adds to the given schema the `client_secret` attribute, its write-only
+// variant and the attribute that contains the version of the write-only variant. Exactly one of the
+// two secret attributes must be set.
+func addClientSecretAttributes(attributes map[string]schema.Attribute, description string) map[string]schema.Attribute {
+	attributes["client_secret"] = schema.StringAttribute{
+		Description: description + " Exactly one of `client_secret` and `client_secret_wo` must be set.",
+		Optional:    true,
+		Sensitive:   true,
+		Validators: []validator.String{
+			stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("client_secret_wo")),
+		},
+	}
+	attributes["client_secret_wo"] = schema.StringAttribute{
+		Description: common.WriteOnlyDescription(description, "client_secret_wo_version"),
+		Optional:    true,
+		Sensitive:   true,
+		WriteOnly:   true,
+	}
+	attributes["client_secret_wo_version"] = schema.Int64Attribute{
+		Description: common.WriteOnlyVersionDescription("client_secret_wo"),
+		Optional:    true,
+		Validators: []validator.Int64{
+			int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("client_secret_wo")),
+		},
+	}
+	return attributes
+}
+
+// copyWriteOnlyAttributes copies the values of the write-only attributes from the configuration to
+// the given plan, as they are always null in the plan.
+func copyWriteOnlyAttributes(ctx context.Context, config tfsdk.Config, plan *IdentityProviderState) diag.Diagnostics {
+	values := &IdentityProviderState{}
+	diags := config.Get(ctx, values)
+	if diags.HasError() {
+		return diags
+	}
+	switch {
+	case plan.HTPasswd != nil && values.HTPasswd != nil:
+		for i := range plan.HTPasswd.Users {
+			if i < len(values.HTPasswd.Users) {
+				plan.HTPasswd.Users[i].PasswordWO = values.HTPasswd.Users[i].PasswordWO
+			}
+		}
+	case plan.Gitlab != nil && values.Gitlab != nil:
+		plan.Gitlab.ClientSecretWO = values.Gitlab.ClientSecretWO
+	case plan.Github != nil && values.Github != nil:
+		plan.Github.ClientSecretWO = values.Github.ClientSecretWO
+	case plan.Google != nil && values.Google != nil:
+		plan.Google.ClientSecretWO = values.Google.ClientSecretWO
+	case plan.LDAP != nil && values.LDAP != nil:
+		plan.LDAP.BindPasswordWO = values.LDAP.BindPasswordWO
+	case plan.OpenID != nil && values.OpenID != nil:
+		plan.OpenID.ClientSecretWO = values.OpenID.ClientSecretWO
+	}
+	return diags
+}
+
+// secretVersionChanged checks if the version of the write-only secret of an identity provider
+// that isn't `htpasswd` has changed, which means that the secret has to be sent to OCM again.
+func secretVersionChanged(state, plan *IdentityProviderState) bool {
+	switch {
+	case state.Gitlab != nil && plan.Gitlab != nil:
+		return !state.Gitlab.ClientSecretWOVersion.Equal(plan.Gitlab.ClientSecretWOVersion)
+	case state.Github != nil && plan.Github != nil:
+		return !state.Github.ClientSecretWOVersion.Equal(plan.Github.ClientSecretWOVersion)
+	case state.Google != nil && plan.Google != nil:
+		return !state.Google.ClientSecretWOVersion.Equal(plan.Google.ClientSecretWOVersion)
+	case state.LDAP != nil && plan.LDAP != nil:
+		return !state.LDAP.BindPasswordWOVersion.Equal(plan.LDAP.BindPasswordWOVersion)
+	case state.OpenID != nil && plan.OpenID != nil:
+		return !state.OpenID.ClientSecretWOVersion.Equal(plan.OpenID.ClientSecretWOVersion)
+	}
+	return false
+}
+
+// changedAttributes returns the paths of the attributes that have different values in the state
+// and in the plan, for example `github.client_id`. Attributes that are unknown in the plan, and
+// attributes that have one of the given names, are ignored.
+func changedAttributes(state tfsdk.State, plan tfsdk.Plan, ignored ...string) ([]string, error) {
+	diffs, err := state.Raw.Diff(plan.Raw)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	seen := map[string]bool{}
+	for _, diff := range diffs {
+		// Collections whose size changed are reported, but so are the elements that changed:
+		if diff.Value1 != nil && diff.Value2 != nil &&
+			isKnownCollection(*diff.Value1) && isKnownCollection(*diff.Value2) {
+			continue
+		}
+		name, ignore := attributePath(diff.Path, ignored)
+		if ignore || seen[name] || unknownInPlan(plan.Raw, diff.Path) {
+			continue
+		}
+		seen[name] = true
+		result = append(result, name)
+	}
+	return result, nil
+}
+
+// attributePath returns the dotted names of the attributes of the given path, and a flag
+// indicating if any of them is one of the ignored names.
+func attributePath(path *tftypes.AttributePath, ignored []string) (string, bool) {
+	var names []string
+	for _, step := range path.Steps() {
+		name, ok := step.(tftypes.AttributeName)
+		if !ok {
+			continue
+		}
+		for _, item := range ignored {
+			if string(name) == item {
+				return "", true
+			}
+		}
+		names = append(names, string(name))
+	}
+	return strings.Join(names, "."), false
+}
+
+// unknownInPlan checks if the value of the given path, or of any of its parents, is unknown in
+// the plan, which means that it will be computed.
+func unknownInPlan(plan tftypes.Value, path *tftypes.AttributePath) bool {
+	steps := path.Steps()
+	for i := 0; i <= len(steps); i++ {
+		value, _, err := tftypes.WalkAttributePath(plan, tftypes.NewAttributePathWithSteps(steps[:i]))
+		if err != nil {
+			return false
+		}
+		if value, ok := value.(tftypes.Value); ok && !value.IsKnown() {
+			return true
+		}
+	}
+	return false
+}
+
+// isKnownCollection checks if the given value is a known and not null list, set, map or tuple.
+func isKnownCollection(value tftypes.Value) bool {
+	if !value.IsKnown() || value.IsNull() {
+		return false
+	}
+	kind := value.Type()
+	return kind.Is(tftypes.List{}) || kind.Is(tftypes.Set{}) || kind.Is(tftypes.Map{}) ||
+		kind.Is(tftypes.Tuple{})
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common/attrvalidators"
 )
 
@@ -146,6 +147,10 @@ func (r *ImageMirrorResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(clusterId)
+	defer common.ClusterMutexKV.Unlock(clusterId)
+
 	// Convert mirrors list
 	mirrors := make([]string, 0, len(plan.Mirrors.Elements()))
 	diags = plan.Mirrors.ElementsAs(ctx, &mirrors, false)
@@ -280,6 +285,10 @@ func (r *ImageMirrorResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(clusterId)
+	defer common.ClusterMutexKV.Unlock(clusterId)
+
 	// Update the image mirror
 	response, err := r.clustersClient.Cluster(clusterId).ImageMirrors().ImageMirror(imageMirrorId).Update().Body(imageMirror).SendContext(ctx)
 	if err != nil {
@@ -319,6 +328,10 @@ func (r *ImageMirrorResource) Delete(ctx context.Context, req resource.DeleteReq
 	clusterId := state.ClusterID.ValueString()
 	imageMirrorId := state.ID.ValueString()
 
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(clusterId)
+	defer common.ClusterMutexKV.Unlock(clusterId)
+
 	// Delete the image mirror
 	response, err := r.clustersClient.Cluster(clusterId).ImageMirrors().ImageMirror(imageMirrorId).Delete().SendContext(ctx)
 	if err != nil {
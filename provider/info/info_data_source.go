@@ -25,6 +25,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 )
 
 type OCMInfoDataSource struct {
@@ -84,6 +86,10 @@ func (d *OCMInfoDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				Description: "OCM AWS account ID",
 				Computed:    true,
 			},
+			"ocm_environment": schema.StringAttribute{
+				Description: "Name of the OCM environment resolved from the OCM API url, empty if the url doesn't belong to a known environment",
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -133,6 +139,7 @@ func (d *OCMInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest
 
 	state.OCMAPI = types.StringValue(d.ocmAPI)
 	state.OCMAWSAccountID = types.StringValue(extractOCMAWSAccount(d.ocmAPI))
+	state.OCMEnvironment = types.StringValue(common.EnvironmentFromURL(d.ocmAPI))
 
 	// Save the state:
 	diags = resp.State.Set(ctx, state)
@@ -44,8 +44,6 @@ const (
 	failedToReadSummary   = "Failed to read KubeletConfig"
 )
 
-var createMutexKV = common.NewMutexKV()
-
 type KubeletConfigResource struct {
 	clusterClient common.ClusterClient
 	configsClient client.KubeletConfigsClient
@@ -112,8 +110,8 @@ func (k *KubeletConfigResource) Create(ctx context.Context, req resource.CreateR
 
 	clusterId := plan.Cluster.ValueString()
 
-	createMutexKV.Lock(clusterId)
-	defer createMutexKV.Unlock(clusterId)
+	common.ClusterMutexKV.Lock(clusterId)
+	defer common.ClusterMutexKV.Unlock(clusterId)
 
 	isHCP, err := isHCP(ctx, clusterId, k.clusterClient)
 	if err != nil {
@@ -263,6 +261,9 @@ func (k *KubeletConfigResource) Update(ctx context.Context, req resource.UpdateR
 	}
 
 	clusterId := state.Cluster.ValueString()
+	common.ClusterMutexKV.Lock(clusterId)
+	defer common.ClusterMutexKV.Unlock(clusterId)
+
 	name := state.Name.ValueString()
 	kubeletConfigId, err := getKubeletConfigId(ctx, state, clusterId, name, k.configsClient)
 	if err != nil {
@@ -303,6 +304,9 @@ func (k *KubeletConfigResource) Delete(ctx context.Context, req resource.DeleteR
 	}
 
 	clusterId := state.Cluster.ValueString()
+	common.ClusterMutexKV.Lock(clusterId)
+	defer common.ClusterMutexKV.Unlock(clusterId)
+
 	name := state.Name.ValueString()
 	kubeletConfigId, err := getKubeletConfigId(ctx, state, clusterId, name, k.configsClient)
 	if err != nil {
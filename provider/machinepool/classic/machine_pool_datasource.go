@@ -28,6 +28,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 )
 
 type MachinePoolDatasource struct {
@@ -172,6 +174,10 @@ func (r *MachinePoolDatasource) Schema(ctx context.Context, req datasource.Schem
 					" This is not recommended to be set in other use cases",
 				Computed: true,
 			},
+			"deletion_policy": schema.StringAttribute{
+				Description: common.DeletionPolicyDescription("machine pool"),
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -186,7 +192,7 @@ func (r *MachinePoolDatasource) Read(ctx context.Context, req datasource.ReadReq
 	}
 	state.ID = state.Name
 
-	notFound, diags := readState(ctx, state, r.collection)
+	notFound, diags := readState(ctx, state, r.collection, nil)
 	if notFound {
 		diags.AddError(
 			"Failed to find machine pool",
@@ -203,6 +209,7 @@ func (r *MachinePoolDatasource) Read(ctx context.Context, req datasource.ReadReq
 	}
 
 	state.IgnoreDeletionError = types.BoolNull()
+	state.DeletionPolicy = types.StringNull()
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
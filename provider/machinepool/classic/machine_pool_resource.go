@@ -59,10 +59,12 @@ var machinepoolNameRE = regexp.MustCompile(
 type MachinePoolResource struct {
 	clusterCollection *cmv1.ClustersClient
 	clusterWait       common.ClusterWait
+	defaults          common.ProviderDefaults
 }
 
 var _ resource.ResourceWithConfigure = &MachinePoolResource{}
 var _ resource.ResourceWithImportState = &MachinePoolResource{}
+var _ resource.ResourceWithUpgradeState = &MachinePoolResource{}
 var _ resource.ResourceWithConfigValidators = &MachinePoolResource{}
 
 func New() resource.Resource {
@@ -75,6 +77,7 @@ func (r *MachinePoolResource) Metadata(ctx context.Context, req resource.Metadat
 
 func (r *MachinePoolResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     schemaVersion,
 		Description: "Machine pool.",
 		Attributes: map[string]schema.Attribute{
 			"cluster": schema.StringAttribute{
@@ -226,6 +229,13 @@ func (r *MachinePoolResource) Schema(ctx context.Context, req resource.SchemaReq
 				Computed: true,
 				Default:  booldefault.StaticBool(false),
 			},
+			"deletion_policy": schema.StringAttribute{
+				Description: common.DeletionPolicyDescription("machine pool"),
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(common.DeletionPolicyDelete, common.DeletionPolicyRetain),
+				},
+			},
 		},
 	}
 }
@@ -255,6 +265,7 @@ func (r *MachinePoolResource) Configure(ctx context.Context, req resource.Config
 
 	r.clusterCollection = connection.ClustersMgmt().V1().Clusters()
 	r.clusterWait = common.NewClusterWait(r.clusterCollection, connection)
+	r.defaults = common.GetProviderDefaults(connection)
 }
 
 func (r *MachinePoolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -291,6 +302,10 @@ func (r *MachinePoolResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(plan.Cluster.ValueString())
+	defer common.ClusterMutexKV.Unlock(plan.Cluster.ValueString())
+
 	// The default machine pool is created automatically when the cluster is created.
 	// We want to import it instead of creating it.
 	if machinepoolName == defaultMachinePoolName {
@@ -380,20 +395,21 @@ func (r *MachinePoolResource) Create(ctx context.Context, req resource.CreateReq
 		}
 		awsMachinePoolBuilder.AdditionalSecurityGroupIds(additionalSecurityGroupIds...)
 	}
-	if common.HasValue(plan.AwsTags) {
+	awsTags, err := common.OptionalMap(ctx, plan.AwsTags)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cannot convert AWS tags map object to string map",
+			fmt.Sprintf(
+				"Cannot convert AWS tags map object to string map for cluster '%s: %v'", plan.Cluster.ValueString(), err,
+			),
+		)
+		return
+	}
+	awsTags = common.MergeDefaults(r.defaults.Tags, awsTags)
+	if common.HasValue(plan.AwsTags) || len(awsTags) > 0 {
 		if awsMachinePoolBuilder == nil {
 			awsMachinePoolBuilder = cmv1.NewAWSMachinePool()
 		}
-		awsTags, err := common.OptionalMap(ctx, plan.AwsTags)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Cannot convert AWS tags map object to string map",
-				fmt.Sprintf(
-					"Cannot convert AWS tags map object to string map for cluster '%s: %v'", plan.Cluster.ValueString(), err,
-				),
-			)
-			return
-		}
 		awsMachinePoolBuilder.Tags(awsTags)
 	}
 	if awsMachinePoolBuilder != nil {
@@ -484,7 +500,7 @@ func (r *MachinePoolResource) Create(ctx context.Context, req resource.CreateReq
 	object = add.Body()
 
 	// Save the state:
-	err = populateState(ctx, object, plan, cluster)
+	err = populateState(ctx, object, plan, cluster, r.defaults.Tags)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Can't populate machine pool state",
@@ -511,7 +527,7 @@ func (r *MachinePoolResource) magicImport(ctx context.Context, plan *MachinePool
 	plan.ID = types.StringValue(machinepoolName)
 	adjustInitialStateToPlan(state, plan)
 
-	notFound, diags := readState(ctx, state, r.clusterCollection)
+	notFound, diags := readState(ctx, state, r.clusterCollection, r.defaults.Tags)
 	if notFound {
 		// We disallow creating a machine pool with the default name. This
 		// case can only happen if the default machine pool was deleted and
@@ -549,7 +565,7 @@ func (r *MachinePoolResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	notFound, diags := readState(ctx, state, r.clusterCollection)
+	notFound, diags := readState(ctx, state, r.clusterCollection, r.defaults.Tags)
 	if notFound {
 		// If we can't find the machine pool, it was deleted. Remove if from the
 		// state and don't return an error so the TF apply() will automatically
@@ -590,7 +606,8 @@ func fetchCluster(ctx context.Context, state *MachinePoolState, collection *cmv1
 	return getCluster.Body()
 }
 
-func readState(ctx context.Context, state *MachinePoolState, collection *cmv1.ClustersClient) (poolNotFound bool, diags diag.Diagnostics) {
+func readState(ctx context.Context, state *MachinePoolState, collection *cmv1.ClustersClient,
+	defaultTags map[string]string) (poolNotFound bool, diags diag.Diagnostics) {
 	diags = diag.Diagnostics{}
 
 	clusterObject := fetchCluster(ctx, state, collection, &diags)
@@ -618,7 +635,7 @@ func readState(ctx context.Context, state *MachinePoolState, collection *cmv1.Cl
 	}
 
 	object := get.Body()
-	err = populateState(ctx, object, state, clusterObject)
+	err = populateState(ctx, object, state, clusterObject, defaultTags)
 	if err != nil {
 		diags.AddError(
 			"Can't populate machine pool state",
@@ -676,6 +693,10 @@ func (r *MachinePoolResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(state.Cluster.ValueString())
+	defer common.ClusterMutexKV.Unlock(state.Cluster.ValueString())
+
 	diags = r.doUpdate(ctx, state, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -820,7 +841,7 @@ func (r *MachinePoolResource) doUpdate(ctx context.Context, state *MachinePoolSt
 
 	adjustInitialStateToPlan(state, plan)
 	// Save the state:
-	err = populateState(ctx, object, state, clusterObject)
+	err = populateState(ctx, object, state, clusterObject, r.defaults.Tags)
 	if err != nil {
 		diags.AddError(
 			"Can't populate machine pool state",
@@ -840,6 +861,7 @@ func adjustInitialStateToPlan(state, plan *MachinePoolState) {
 	state.Replicas = plan.Replicas
 
 	state.IgnoreDeletionError = plan.IgnoreDeletionError
+	state.DeletionPolicy = plan.DeletionPolicy
 
 	if common.HasValue(plan.AwsTags) {
 		state.AwsTags = plan.AwsTags
@@ -958,6 +980,18 @@ func (r *MachinePoolResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	// Keep the machine pool in OCM if requested, only removing it from the state:
+	if common.ShouldRetain(state.DeletionPolicy) {
+		tflog.Info(ctx, fmt.Sprintf("Deletion policy is '%s', keeping machine pool '%s' of cluster '%s' in OCM",
+			common.DeletionPolicyRetain, state.ID.ValueString(), state.Cluster.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(state.Cluster.ValueString())
+	defer common.ClusterMutexKV.Unlock(state.Cluster.ValueString())
+
 	// Send the request to delete the machine pool:
 	resource := r.clusterCollection.Cluster(state.Cluster.ValueString()).
 		MachinePools().
@@ -1025,23 +1059,32 @@ func (r *MachinePoolResource) countPools(ctx context.Context, clusterID string)
 }
 
 func (r *MachinePoolResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// To import a machine pool, we need to know the cluster ID and the machine pool ID
+	// To import a machine pool, we need to know the cluster and the machine pool ID
 	fields := strings.Split(req.ID, ",")
 	if len(fields) != 2 || fields[0] == "" || fields[1] == "" {
 		resp.Diagnostics.AddError(
 			"Invalid import identifier",
-			"Machine pool to import should be specified as <cluster_id>,<machine_pool_id>",
+			"Machine pool to import should be specified as <cluster>,<machine_pool_id>, where the "+
+				"cluster is its identifier, name or external identifier",
+		)
+		return
+	}
+	clusterID, err := common.ResolveClusterID(ctx, r.clusterCollection, fields[0])
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Can't import machine pool",
+			err.Error(),
 		)
 		return
 	}
-	clusterID := fields[0]
 	machinePoolID := fields[1]
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster"), clusterID)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), machinePoolID)...)
 }
 
 // populateState copies the data from the API object to the Terraform state.
-func populateState(ctx context.Context, object *cmv1.MachinePool, state *MachinePoolState, cluster *cmv1.Cluster) error {
+func populateState(ctx context.Context, object *cmv1.MachinePool, state *MachinePoolState, cluster *cmv1.Cluster,
+	defaultTags map[string]string) error {
 	state.ID = types.StringValue(object.ID())
 	state.Name = types.StringValue(object.ID())
 
@@ -1161,7 +1204,7 @@ func populateState(ctx context.Context, object *cmv1.MachinePool, state *Machine
 		state.AwsTags = types.MapNull(types.StringType)
 	}
 	if awsTags, ok := object.AWS().GetTags(); ok {
-		filteredAwsTags, err := filterClusterTagsNotPresentInNpInput(ctx, state, cluster, awsTags)
+		filteredAwsTags, err := filterClusterTagsNotPresentInNpInput(ctx, state, cluster, awsTags, defaultTags)
 		if err != nil {
 			return err
 		}
@@ -1176,10 +1219,17 @@ func populateState(ctx context.Context, object *cmv1.MachinePool, state *Machine
 	return nil
 }
 
-func filterClusterTagsNotPresentInNpInput(ctx context.Context, state *MachinePoolState, cluster *cmv1.Cluster, awsTags map[string]string) (map[string]string, error) {
+func filterClusterTagsNotPresentInNpInput(ctx context.Context, state *MachinePoolState, cluster *cmv1.Cluster,
+	awsTags map[string]string, defaultTags map[string]string) (map[string]string, error) {
 	if len(awsTags) == 0 {
 		return awsTags, nil
 	}
+	currentNpTfTags, err := common.OptionalMap(ctx, state.AwsTags)
+	if err != nil {
+		return awsTags, err
+	}
+	// The tags that come from the provider defaults aren't part of the configuration:
+	awsTags = common.RemoveDefaults(defaultTags, awsTags, currentNpTfTags)
 	if cluster.AWS() == nil || len(cluster.AWS().Tags()) == 0 {
 		return awsTags, nil
 	}
@@ -1187,10 +1237,6 @@ func filterClusterTagsNotPresentInNpInput(ctx context.Context, state *MachinePoo
 	for k, v := range awsTags {
 		filteredTags[k] = v
 	}
-	currentNpTfTags, err := common.OptionalMap(ctx, state.AwsTags)
-	if err != nil {
-		return filteredTags, err
-	}
 	clusterTags := cluster.AWS().Tags()
 	for k := range clusterTags {
 		if _, ok := currentNpTfTags[k]; !ok {
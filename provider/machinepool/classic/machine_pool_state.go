@@ -42,6 +42,7 @@ type MachinePoolState struct {
 	AdditionalSecurityGroupIds types.List    `tfsdk:"aws_additional_security_group_ids"`
 	AwsTags                    types.Map     `tfsdk:"aws_tags"`
 	IgnoreDeletionError        types.Bool    `tfsdk:"ignore_deletion_error"`
+	DeletionPolicy             types.String  `tfsdk:"deletion_policy"`
 }
 
 type Taints struct {
@@ -179,6 +179,10 @@ func (r *HcpMachinePoolDatasource) Schema(ctx context.Context, req datasource.Sc
 					" This is not recommended to be set in other use cases",
 				Computed: true,
 			},
+			"deletion_policy": schema.StringAttribute{
+				Description: common.DeletionPolicyDescription("machine pool"),
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -193,7 +197,7 @@ func (r *HcpMachinePoolDatasource) Read(ctx context.Context, req datasource.Read
 	}
 	state.ID = state.Name
 
-	notFound, diags := readState(ctx, state, r.collection)
+	notFound, diags := readState(ctx, state, r.collection, nil)
 	if notFound {
 		diags.AddError(
 			"Failed to find machine pool",
@@ -212,6 +216,7 @@ func (r *HcpMachinePoolDatasource) Read(ctx context.Context, req datasource.Read
 	state.UpgradeAcksFor = types.StringNull()
 	state.Version = types.StringNull()
 	state.IgnoreDeletionError = types.BoolNull()
+	state.DeletionPolicy = types.StringNull()
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
@@ -65,10 +65,12 @@ type HcpMachinePoolResource struct {
 	clusterCollection *cmv1.ClustersClient
 	versionCollection *cmv1.VersionsClient
 	clusterWait       common.ClusterWait
+	defaults          common.ProviderDefaults
 }
 
 var _ resource.ResourceWithConfigure = &HcpMachinePoolResource{}
 var _ resource.ResourceWithImportState = &HcpMachinePoolResource{}
+var _ resource.ResourceWithUpgradeState = &HcpMachinePoolResource{}
 var _ resource.ResourceWithConfigValidators = &HcpMachinePoolResource{}
 
 func New() resource.Resource {
@@ -81,6 +83,7 @@ func (r *HcpMachinePoolResource) Metadata(ctx context.Context, req resource.Meta
 
 func (r *HcpMachinePoolResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     schemaVersion,
 		Description: "Machine pool.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -217,6 +220,13 @@ func (r *HcpMachinePoolResource) Schema(ctx context.Context, req resource.Schema
 				Computed: true,
 				Default:  booldefault.StaticBool(false),
 			},
+			"deletion_policy": schema.StringAttribute{
+				Description: common.DeletionPolicyDescription("machine pool"),
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(common.DeletionPolicyDelete, common.DeletionPolicyRetain),
+				},
+			},
 		},
 	}
 }
@@ -246,6 +256,7 @@ func (r *HcpMachinePoolResource) Configure(ctx context.Context, req resource.Con
 	r.clusterCollection = connection.ClustersMgmt().V1().Clusters()
 	r.versionCollection = connection.ClustersMgmt().V1().Versions()
 	r.clusterWait = common.NewClusterWait(r.clusterCollection, connection)
+	r.defaults = common.GetProviderDefaults(connection)
 }
 
 func (r *HcpMachinePoolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -281,6 +292,10 @@ func (r *HcpMachinePoolResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(plan.Cluster.ValueString())
+	defer common.ClusterMutexKV.Unlock(plan.Cluster.ValueString())
+
 	// The default machine pool is created automatically when the cluster is created.
 	// We want to import it instead of creating it.
 	if standardNodePoolRegex.MatchString(nodePoolName) {
@@ -299,6 +314,7 @@ func (r *HcpMachinePoolResource) Create(ctx context.Context, req resource.Create
 		if err != nil {
 			return
 		}
+		awsTags = common.MergeDefaults(r.defaults.Tags, awsTags)
 		if len(awsTags) > 0 {
 			awsNodePoolBuilder.Tags(awsTags)
 		}
@@ -458,7 +474,7 @@ func (r *HcpMachinePoolResource) Create(ctx context.Context, req resource.Create
 	object = add.Body()
 
 	// Save the state:
-	err = populateState(ctx, object, plan, clusterObject)
+	err = populateState(ctx, object, plan, clusterObject, r.defaults.Tags)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Can't populate machine pool state",
@@ -485,7 +501,7 @@ func (r *HcpMachinePoolResource) magicImport(ctx context.Context, plan *HcpMachi
 	plan.ID = types.StringValue(nodePoolName)
 	adjustInitialStateToPlan(state, plan)
 
-	notFound, diags := readState(ctx, state, r.clusterCollection)
+	notFound, diags := readState(ctx, state, r.clusterCollection, r.defaults.Tags)
 	if notFound {
 		// We disallow creating a machine pool with the default name. This
 		// case can only happen if the default machine pool was deleted and
@@ -523,7 +539,7 @@ func (r *HcpMachinePoolResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	notFound, diags := readState(ctx, state, r.clusterCollection)
+	notFound, diags := readState(ctx, state, r.clusterCollection, r.defaults.Tags)
 	if notFound {
 		// If we can't find the machine pool, it was deleted. Remove if from the
 		// state and don't return an error so the TF apply() will automatically
@@ -564,7 +580,8 @@ func fetchCluster(ctx context.Context, state *HcpMachinePoolState, collection *c
 	return getCluster.Body()
 }
 
-func readState(ctx context.Context, state *HcpMachinePoolState, collection *cmv1.ClustersClient) (poolNotFound bool, diags diag.Diagnostics) {
+func readState(ctx context.Context, state *HcpMachinePoolState, collection *cmv1.ClustersClient,
+	defaultTags map[string]string) (poolNotFound bool, diags diag.Diagnostics) {
 	diags = diag.Diagnostics{}
 
 	clusterObject := fetchCluster(ctx, state, collection, &diags)
@@ -593,7 +610,7 @@ func readState(ctx context.Context, state *HcpMachinePoolState, collection *cmv1
 	}
 
 	npObject := getNp.Body()
-	err = populateState(ctx, npObject, state, clusterObject)
+	err = populateState(ctx, npObject, state, clusterObject, defaultTags)
 	if err != nil {
 		diags.AddError(
 			"Can't populate machine pool state",
@@ -652,6 +669,10 @@ func (r *HcpMachinePoolResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(state.Cluster.ValueString())
+	defer common.ClusterMutexKV.Unlock(state.Cluster.ValueString())
+
 	diags = r.doUpdate(ctx, state, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -822,7 +843,7 @@ func (r *HcpMachinePoolResource) doUpdate(ctx context.Context, state *HcpMachine
 	adjustInitialStateToPlan(state, plan)
 
 	// Save the state:
-	err = populateState(ctx, object, state, clusterObject)
+	err = populateState(ctx, object, state, clusterObject, r.defaults.Tags)
 	if err != nil {
 		diags.AddError(
 			"Can't populate machine pool state",
@@ -846,6 +867,7 @@ func adjustInitialStateToPlan(state, plan *HcpMachinePoolState) {
 	state.NodePoolStatus = plan.NodePoolStatus
 	state.Version = plan.Version
 	state.IgnoreDeletionError = plan.IgnoreDeletionError
+	state.DeletionPolicy = plan.DeletionPolicy
 
 	if state.AWSNodePool == nil {
 		state.AWSNodePool = new(AWSNodePool)
@@ -993,7 +1015,7 @@ func scheduleUpgrade(ctx context.Context, client *cmv1.ClustersClient,
 			userAckRequired = true
 		}
 	}
-	targetMinorVersion := getOcmVersionMinor(desiredVersion.String())
+	targetMinorVersion := common.GetOcmVersionMinor(desiredVersion.String())
 	if userAckRequired && userAckString != targetMinorVersion { // User has not acknowledged mandatory gates, stop here.
 		return fmt.Errorf("%s\nTo acknowledge these items, please add \"upgrade_acknowledgements_for = %s\""+
 			" and re-apply the changes", description, targetMinorVersion)
@@ -1031,17 +1053,6 @@ func scheduleUpgrade(ctx context.Context, client *cmv1.ClustersClient,
 	return nil
 }
 
-// TODO: move to ocm commons
-func getOcmVersionMinor(ver string) string {
-	version, err := semver.NewVersion(ver)
-	if err != nil {
-		segments := strings.Split(ver, ".")
-		return fmt.Sprintf("%s.%s", segments[0], segments[1])
-	}
-	segments := version.Segments()
-	return fmt.Sprintf("%d.%d", segments[0], segments[1])
-}
-
 func getAutoscaling(state *HcpMachinePoolState, mpBuilder *cmv1.NodePoolBuilder) (
 	autoscalingEnabled bool, errMsg string) {
 	autoscalingEnabled = false
@@ -1082,6 +1093,18 @@ func (r *HcpMachinePoolResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
+	// Keep the machine pool in OCM if requested, only removing it from the state:
+	if common.ShouldRetain(state.DeletionPolicy) {
+		tflog.Info(ctx, fmt.Sprintf("Deletion policy is '%s', keeping machine pool '%s' of cluster '%s' in OCM",
+			common.DeletionPolicyRetain, state.ID.ValueString(), state.Cluster.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(state.Cluster.ValueString())
+	defer common.ClusterMutexKV.Unlock(state.Cluster.ValueString())
+
 	// Send the request to delete the machine pool:
 	resource := r.clusterCollection.Cluster(state.Cluster.ValueString()).
 		NodePools().
@@ -1149,23 +1172,32 @@ func (r *HcpMachinePoolResource) countPools(ctx context.Context, clusterID strin
 }
 
 func (r *HcpMachinePoolResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// To import a machine pool, we need to know the cluster ID and the machine pool ID
+	// To import a machine pool, we need to know the cluster and the machine pool ID
 	fields := strings.Split(req.ID, ",")
 	if len(fields) != 2 || fields[0] == "" || fields[1] == "" {
 		resp.Diagnostics.AddError(
 			"Invalid import identifier",
-			"Machine pool to import should be specified as <cluster_id>,<machine_pool_id>",
+			"Machine pool to import should be specified as <cluster>,<machine_pool_id>, where the "+
+				"cluster is its identifier, name or external identifier",
+		)
+		return
+	}
+	clusterID, err := common.ResolveClusterID(ctx, r.clusterCollection, fields[0])
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Can't import machine pool",
+			err.Error(),
 		)
 		return
 	}
-	clusterID := fields[0]
 	nodePoolId := fields[1]
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster"), clusterID)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), nodePoolId)...)
 }
 
 // populateState copies the data from the API object to the Terraform state.
-func populateState(ctx context.Context, object *cmv1.NodePool, state *HcpMachinePoolState, cluster *cmv1.Cluster) error {
+func populateState(ctx context.Context, object *cmv1.NodePool, state *HcpMachinePoolState, cluster *cmv1.Cluster,
+	defaultTags map[string]string) error {
 	state.ID = types.StringValue(object.ID())
 	state.Name = types.StringValue(object.ID())
 
@@ -1183,7 +1215,7 @@ func populateState(ctx context.Context, object *cmv1.NodePool, state *HcpMachine
 			state.AWSNodePool.Tags = types.MapNull(types.StringType)
 		}
 		if awsTags, ok := awsNodePool.GetTags(); ok {
-			filteredAwsTags, err := filterClusterTagsNotPresentInNpInput(ctx, state, cluster, awsTags)
+			filteredAwsTags, err := filterClusterTagsNotPresentInNpInput(ctx, state, cluster, awsTags, defaultTags)
 			if err != nil {
 				return err
 			}
@@ -1311,10 +1343,17 @@ func populateState(ctx context.Context, object *cmv1.NodePool, state *HcpMachine
 	return nil
 }
 
-func filterClusterTagsNotPresentInNpInput(ctx context.Context, state *HcpMachinePoolState, cluster *cmv1.Cluster, awsTags map[string]string) (map[string]string, error) {
+func filterClusterTagsNotPresentInNpInput(ctx context.Context, state *HcpMachinePoolState, cluster *cmv1.Cluster,
+	awsTags map[string]string, defaultTags map[string]string) (map[string]string, error) {
 	if len(awsTags) == 0 {
 		return awsTags, nil
 	}
+	currentNpTfTags, err := common.OptionalMap(ctx, state.AWSNodePool.Tags)
+	if err != nil {
+		return awsTags, err
+	}
+	// The tags that come from the provider defaults aren't part of the configuration:
+	awsTags = common.RemoveDefaults(defaultTags, awsTags, currentNpTfTags)
 	if cluster.AWS() == nil || len(cluster.AWS().Tags()) == 0 {
 		return awsTags, nil
 	}
@@ -1322,10 +1361,6 @@ func filterClusterTagsNotPresentInNpInput(ctx context.Context, state *HcpMachine
 	for k, v := range awsTags {
 		filteredTags[k] = v
 	}
-	currentNpTfTags, err := common.OptionalMap(ctx, state.AWSNodePool.Tags)
-	if err != nil {
-		return filteredTags, err
-	}
 	clusterTags := cluster.AWS().Tags()
 	for k := range clusterTags {
 		if _, ok := currentNpTfTags[k]; !ok {
@@ -43,7 +43,8 @@ type HcpMachinePoolState struct {
 	KubeletConfigs types.String `tfsdk:"kubelet_configs"`
 	AutoRepair     types.Bool   `tfsdk:"auto_repair"`
 
-	IgnoreDeletionError types.Bool `tfsdk:"ignore_deletion_error"`
+	IgnoreDeletionError types.Bool   `tfsdk:"ignore_deletion_error"`
+	DeletionPolicy      types.String `tfsdk:"deletion_policy"`
 }
 
 type Taints struct {
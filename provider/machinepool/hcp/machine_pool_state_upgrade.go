@@ -0,0 +1,43 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hcp
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+)
+
+// schemaVersion is the current version of the schema of the resource. It needs to be incremented,
+// and a state upgrader from the previous version added, when attributes are renamed or moved.
+const schemaVersion = 1
+
+func (r *HcpMachinePoolResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 is used by all the releases before schema versioning was introduced. Some of
+		// them kept the autoscaling settings in top level attributes instead of in the nested
+		// `autoscaling` attribute.
+		0: common.RawStateUpgrader(
+			common.MoveStateAttributes("autoscaling", map[string]string{
+				"autoscaling_enabled": "enabled",
+				"min_replicas":        "min_replicas",
+				"max_replicas":        "max_replicas",
+			}),
+		),
+	}
+}
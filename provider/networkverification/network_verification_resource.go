@@ -0,0 +1,394 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkverification
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+)
+
+const (
+	resourceTypeName      = "_network_verification"
+	failedToVerifySummary = "Network verification failed"
+
+	defaultWaitTimeoutInMinutes = int64(30)
+	pollInterval                = 10 * time.Second
+
+	// States of the verification of a subnet:
+	subnetStatePending = "pending"
+	subnetStateRunning = "running"
+	subnetStatePassed  = "passed"
+)
+
+var subnetAttrTypes = map[string]attr.Type{
+	"id":      types.StringType,
+	"state":   types.StringType,
+	"details": types.ListType{ElemType: types.StringType},
+}
+
+type NetworkVerificationResource struct {
+	collection *cmv1.NetworkVerificationsClient
+}
+
+// Interface checks
+var _ resource.Resource = &NetworkVerificationResource{}
+var _ resource.ResourceWithConfigure = &NetworkVerificationResource{}
+
+func New() resource.Resource {
+	return &NetworkVerificationResource{}
+}
+
+func (r *NetworkVerificationResource) Metadata(_ context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + resourceTypeName
+}
+
+func (r *NetworkVerificationResource) Schema(_ context.Context, _ resource.SchemaRequest,
+	resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Verifies that the subnets of an existing VPC have the network configuration that " +
+			"is required to install a ROSA cluster. The verification runs when the resource is created, and " +
+			"the apply fails with the details of each failed subnet, so clusters can depend on this resource " +
+			"to avoid starting installations that would fail. Changing any of the attributes runs the " +
+			"verification again. The OCM network verification API doesn't support proxies, so the " +
+			"verification of subnets that only have egress through a proxy fails.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier of the verification, the identifiers of the subnets separated by commas.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cloud_region": schema.StringAttribute{
+				Description: "AWS region identifier, for example 'us-east-1'." +
+					common.ValueCannotBeChangedStringDescription,
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role_arn": schema.StringAttribute{
+				Description: "ARN of the installer role that OCM assumes to run the verification in the " +
+					"AWS account." + common.ValueCannotBeChangedStringDescription,
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^arn:aws[\w-]*:iam::\d{12}:role/\S+$`),
+						"role ARN must be a valid AWS IAM role ARN"),
+				},
+			},
+			"aws_subnet_ids": schema.ListAttribute{
+				Description: "AWS subnet IDs to verify." + common.ValueCannotBeChangedStringDescription,
+				ElementType: types.StringType,
+				Required:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				},
+			},
+			"aws_additional_compute_security_group_ids": schema.ListAttribute{
+				Description: "AWS additional compute security group ids that will be used by the " +
+					"cluster." + common.ValueCannotBeChangedStringDescription,
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"tags": schema.MapAttribute{
+				Description: "Tags applied to the AWS resources created during the verification." +
+					common.ValueCannotBeChangedStringDescription,
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"hosted_cp": schema.BoolAttribute{
+				Description: "Verify the subnets for a ROSA cluster with hosted control planes instead of " +
+					"a ROSA classic cluster. The default value is false." +
+					common.ValueCannotBeChangedStringDescription,
+				Optional: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"max_wait_timeout_in_minutes": schema.Int64Attribute{
+				Description: fmt.Sprintf("This value sets the maximum duration in minutes to wait for "+
+					"the verification to finish. The default value is %d.", defaultWaitTimeoutInMinutes),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"subnets": schema.ListNestedAttribute{
+				Description: "Results of the verification of each subnet.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "AWS subnet ID.",
+							Computed:    true,
+						},
+						"state": schema.StringAttribute{
+							Description: "State of the verification of the subnet, for example " +
+								"`passed` or `failed`.",
+							Computed: true,
+						},
+						"details": schema.ListAttribute{
+							Description: "Details of the checks that failed.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *NetworkVerificationResource) Configure(_ context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connection, ok := req.ProviderData.(*sdk.Connection)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.collection = connection.ClustersMgmt().V1().NetworkVerifications()
+}
+
+func (r *NetworkVerificationResource) Create(ctx context.Context, req resource.CreateRequest,
+	resp *resource.CreateResponse) {
+	plan := &NetworkVerificationState{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subnetIds, err := common.StringListToArray(ctx, plan.AWSSubnetIDs)
+	if err != nil {
+		resp.Diagnostics.AddError(failedToVerifySummary, err.Error())
+		return
+	}
+	tags, err := common.OptionalMap(ctx, plan.Tags)
+	if err != nil {
+		resp.Diagnostics.AddError(failedToVerifySummary, err.Error())
+		return
+	}
+
+	awsBuilder := cmv1.NewAWS().
+		STS(cmv1.NewSTS().RoleARN(plan.RoleARN.ValueString()))
+	if securityGroupIds := common.OptionalList(plan.AWSAdditionalComputeSecurityGroupIds); len(securityGroupIds) > 0 {
+		awsBuilder.AdditionalComputeSecurityGroupIds(securityGroupIds...)
+	}
+	if len(tags) > 0 {
+		awsBuilder.Tags(tags)
+	}
+	builder := cmv1.NewNetworkVerification().
+		CloudProviderData(cmv1.NewCloudProviderData().
+			AWS(awsBuilder).
+			Region(cmv1.NewCloudRegion().ID(plan.CloudRegion.ValueString())).
+			Subnets(subnetIds...))
+	if common.BoolWithFalseDefault(plan.HostedCP) {
+		builder.Platform(cmv1.PlatformAwsHostedCp)
+	} else {
+		builder.Platform(cmv1.PlatformAwsClassic)
+	}
+	object, err := builder.Build()
+	if err != nil {
+		resp.Diagnostics.AddError(failedToVerifySummary,
+			fmt.Sprintf("Failed to build network verification request: %v", err))
+		return
+	}
+
+	addResp, err := r.collection.Add().Body(object).SendContext(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(failedToVerifySummary,
+			fmt.Sprintf("Failed to start the network verification of subnets %v: %v",
+				subnetIds, common.HandleErr(addResp.Error(), err)))
+		return
+	}
+
+	timeout, err := common.ValidateTimeout(common.OptionalInt64(plan.MaxWaitTimeoutInMinutes),
+		defaultWaitTimeoutInMinutes)
+	if err != nil {
+		resp.Diagnostics.AddError(failedToVerifySummary, err.Error())
+		return
+	}
+	pollCtx, cancel := context.WithTimeout(ctx, time.Duration(*timeout)*time.Minute)
+	defer cancel()
+
+	// The results are returned in the same order as the subnets, each subnet is polled till its
+	// verification has finished:
+	results := []*cmv1.SubnetNetworkVerification{}
+	for _, subnet := range addResp.Body().Items() {
+		result, err := r.waitForSubnet(pollCtx, subnet)
+		if err != nil {
+			resp.Diagnostics.AddError(failedToVerifySummary,
+				fmt.Sprintf("Failed to wait for the network verification of subnet '%s': %v",
+					subnet.ID(), err))
+			return
+		}
+		results = append(results, result)
+	}
+
+	failures := []string{}
+	for _, result := range results {
+		if result.State() != subnetStatePassed {
+			failures = append(failures, fmt.Sprintf("- %s (%s): %s", result.ID(), result.State(),
+				strings.Join(result.Details(), "; ")))
+		}
+	}
+	if len(failures) > 0 {
+		// Nothing is saved in the state, so that the verification runs again in the next apply:
+		resp.Diagnostics.AddError(failedToVerifySummary,
+			fmt.Sprintf("Network verification failed for the following subnets:\n%s",
+				strings.Join(failures, "\n")))
+		return
+	}
+
+	plan.ID = types.StringValue(strings.Join(subnetIds, ","))
+	plan.Subnets, err = subnetsToList(results)
+	if err != nil {
+		resp.Diagnostics.AddError(failedToVerifySummary, err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read keeps the state as it is, as the result of the verification is only meaningful for the moment
+// when it ran.
+func (r *NetworkVerificationResource) Read(ctx context.Context, req resource.ReadRequest,
+	resp *resource.ReadResponse) {
+	state := &NetworkVerificationState{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update is only called when the timeout changes, as all the other attributes require replacement.
+func (r *NetworkVerificationResource) Update(ctx context.Context, req resource.UpdateRequest,
+	resp *resource.UpdateResponse) {
+	plan := &NetworkVerificationState{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete only removes the resource from the state, there is nothing to delete in OCM.
+func (r *NetworkVerificationResource) Delete(ctx context.Context, _ resource.DeleteRequest,
+	resp *resource.DeleteResponse) {
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *NetworkVerificationResource) waitForSubnet(ctx context.Context,
+	subnet *cmv1.SubnetNetworkVerification) (*cmv1.SubnetNetworkVerification, error) {
+	if !isRunning(subnet.State()) {
+		return subnet, nil
+	}
+	result := subnet
+	_, err := r.collection.NetworkVerification(subnet.ID()).Poll().
+		Interval(pollInterval).
+		Status(http.StatusOK).
+		Predicate(func(getResp *cmv1.NetworkVerificationGetResponse) bool {
+			result = getResp.Body()
+			tflog.Debug(ctx, "polled subnet network verification", map[string]interface{}{
+				"subnet": result.ID(),
+				"state":  result.State(),
+			})
+			return !isRunning(result.State())
+		}).
+		StartContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func isRunning(state string) bool {
+	return state == subnetStatePending || state == subnetStateRunning
+}
+
+func subnetsToList(results []*cmv1.SubnetNetworkVerification) (types.List, error) {
+	objectType := types.ObjectType{AttrTypes: subnetAttrTypes}
+	values := []attr.Value{}
+	for _, result := range results {
+		details, err := common.StringArrayToList(result.Details())
+		if err != nil {
+			return types.ListNull(objectType), err
+		}
+		value, diags := types.ObjectValue(subnetAttrTypes, map[string]attr.Value{
+			"id":      types.StringValue(result.ID()),
+			"state":   types.StringValue(result.State()),
+			"details": details,
+		})
+		if diags.HasError() {
+			return types.ListNull(objectType), fmt.Errorf("failed to convert the result of subnet '%s'",
+				result.ID())
+		}
+		values = append(values, value)
+	}
+	list, diags := types.ListValue(objectType, values)
+	if diags.HasError() {
+		return types.ListNull(objectType), fmt.Errorf("failed to convert the results of the subnets")
+	}
+	return list, nil
+}
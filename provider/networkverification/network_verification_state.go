@@ -0,0 +1,37 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkverification
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type NetworkVerificationState struct {
+	ID                                   types.String `tfsdk:"id"`
+	CloudRegion                          types.String `tfsdk:"cloud_region"`
+	RoleARN                              types.String `tfsdk:"role_arn"`
+	AWSSubnetIDs                         types.List   `tfsdk:"aws_subnet_ids"`
+	AWSAdditionalComputeSecurityGroupIds types.List   `tfsdk:"aws_additional_compute_security_group_ids"`
+	Tags                                 types.Map    `tfsdk:"tags"`
+	HostedCP                             types.Bool   `tfsdk:"hosted_cp"`
+	MaxWaitTimeoutInMinutes              types.Int64  `tfsdk:"max_wait_timeout_in_minutes"`
+	Subnets                              types.List   `tfsdk:"subnets"`
+}
+
+type SubnetVerificationState struct {
+	ID      types.String `tfsdk:"id"`
+	State   types.String `tfsdk:"state"`
+	Details types.List   `tfsdk:"details"`
+}
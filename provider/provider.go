@@ -19,28 +19,51 @@ package provider
 import (
 	"context"
 	"crypto/x509"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	tfprovider "github.com/hashicorp/terraform-plugin-framework/provider"
 	tfpschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	sdk "github.com/openshift-online/ocm-sdk-go"
 
 	"github.com/terraform-redhat/terraform-provider-rhcs/build"
 	"github.com/terraform-redhat/terraform-provider-rhcs/logging"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/accesstoken"
 	classicAutoscaler "github.com/terraform-redhat/terraform-provider-rhcs/provider/autoscaler/classic"
 	hcpAutoscaler "github.com/terraform-redhat/terraform-provider-rhcs/provider/autoscaler/hcp"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/breakglasscredential"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/cloudprovider"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/cluster"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/clusteraddon"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/clusteradmincredentials"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/classic"
+	rosa "github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/common"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/hcp"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/clusters"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterupgradepolicy"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterwaiter"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common/transport"
 	defaultingress "github.com/terraform-redhat/terraform-provider-rhcs/provider/defaultingress/classic"
 	hcpingress "github.com/terraform-redhat/terraform-provider-rhcs/provider/defaultingress/hcp"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/dnsdomain"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/externalauthprovider"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/functions"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/group"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/groupmembership"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/identityprovider"
@@ -50,6 +73,7 @@ import (
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/machine_types"
 	machinepool "github.com/terraform-redhat/terraform-provider-rhcs/provider/machinepool/classic"
 	nodepool "github.com/terraform-redhat/terraform-provider-rhcs/provider/machinepool/hcp"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/networkverification"
 	classicStsPolicies "github.com/terraform-redhat/terraform-provider-rhcs/provider/ocm_policies/classic"
 	hcpStsPolicies "github.com/terraform-redhat/terraform-provider-rhcs/provider/ocm_policies/hcp"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/oidcconfig"
@@ -59,12 +83,15 @@ import (
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/trusted_ip_addresses"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/tuningconfigs"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/versions"
+	"github.com/terraform-redhat/terraform-provider-rhcs/tracing"
 )
 
 // Provider is the implementation of the Provider.
 type Provider struct{}
 
 var _ tfprovider.Provider = &Provider{}
+var _ tfprovider.ProviderWithEphemeralResources = &Provider{}
+var _ tfprovider.ProviderWithFunctions = &Provider{}
 
 // Config contains the configuration of the provider.
 type Config struct {
@@ -76,6 +103,28 @@ type Config struct {
 	ClientSecret types.String `tfsdk:"client_secret"`
 	TrustedCAs   types.String `tfsdk:"trusted_cas"`
 	Insecure     types.Bool   `tfsdk:"insecure"`
+	ConfigFile   types.String `tfsdk:"config_file"`
+	Environment  types.String `tfsdk:"environment"`
+	Retry        *RetryConfig `tfsdk:"retry"`
+
+	MaxConcurrentRequests types.Int64  `tfsdk:"max_concurrent_requests"`
+	AuditLogPath          types.String `tfsdk:"audit_log_path"`
+	ReadOnly              types.Bool   `tfsdk:"read_only"`
+
+	DefaultTags       *DefaultTagsConfig `tfsdk:"default_tags"`
+	DefaultProperties types.Map          `tfsdk:"default_properties"`
+}
+
+// DefaultTagsConfig contains the tags that are added to all the clusters and machine pools.
+type DefaultTagsConfig struct {
+	Tags types.Map `tfsdk:"tags"`
+}
+
+// RetryConfig contains the retry settings of the requests sent to the OCM API.
+type RetryConfig struct {
+	MaxAttempts types.Int64  `tfsdk:"max_attempts"`
+	MinBackoff  types.String `tfsdk:"min_backoff"`
+	MaxBackoff  types.String `tfsdk:"max_backoff"`
 }
 
 // New creates the provider.
@@ -134,6 +183,100 @@ func (p *Provider) Schema(ctx context.Context, req tfprovider.SchemaRequest, res
 					"for production environments.",
 				Optional: true,
 			},
+			"config_file": tfpschema.StringAttribute{
+				Description: "Path of the configuration file written by `ocm login` or `rosa login`. " +
+					"Values explicitly set in the provider configuration or in environment variables take " +
+					"precedence over the ones in the file. When no credentials are provided, the provider " +
+					"falls back to the file pointed by the `OCM_CONFIG` environment variable or to " +
+					"`~/.config/ocm/ocm.json`.",
+				Optional: true,
+			},
+			"environment": tfpschema.StringAttribute{
+				Description: "Name of the OCM environment to connect to. It sets the API URL and the token URL " +
+					"of the environment, and conflicts with `url`. " +
+					"Valid values are: " + strings.Join(common.EnvironmentNames(), ", ") + ".",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(common.EnvironmentNames()...),
+					stringvalidator.ConflictsWith(path.MatchRoot("url")),
+				},
+			},
+			"max_concurrent_requests": tfpschema.Int64Attribute{
+				Description: "Maximum number of requests sent to the OCM API at the same time. Use it to stay " +
+					"below the rate limits of the organization when many resources are applied in parallel. " +
+					"By default the number of requests isn't limited. Independently of this setting, the " +
+					"changes to the resources that belong to the same cluster, like machine pools or " +
+					"identity providers, are always sent one at a time.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"audit_log_path": tfpschema.StringAttribute{
+				Description: "Path of a file where a JSON line is appended for each request sent to the OCM " +
+					"API, containing the method, path, status, duration, operation identifier and the type of " +
+					"the resource that sent it, as well as the request and response bodies. Tokens, passwords " +
+					"and other secrets are replaced by `REDACTED`. Can also be set with the " +
+					"`RHCS_AUDIT_LOG_PATH` environment variable.",
+				Optional: true,
+			},
+			"read_only": tfpschema.BoolAttribute{
+				Description: "When set to `true` the provider never modifies OCM: all the requests that " +
+					"would create, update or delete objects are rejected, while data sources and the " +
+					"reading of the state of resources keep working. Use it to run `terraform plan` and " +
+					"drift detection safely with credentials that can modify objects. Can also be set " +
+					"with the `RHCS_READ_ONLY` environment variable.",
+				Optional: true,
+			},
+			"default_properties": tfpschema.MapAttribute{
+				Description: "Properties added to all the clusters managed by the provider. The properties set " +
+					"in the `properties` attribute of a cluster take precedence over these.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+		Blocks: map[string]tfpschema.Block{
+			"default_tags": tfpschema.SingleNestedBlock{
+				Description: "Tags added to all the clusters and machine pools managed by the provider. " +
+					"They are merged with the `tags` of the clusters and the AWS tags of the machine pools, " +
+					"which take precedence over these. The tags that come from the defaults don't appear as " +
+					"differences in the plan. Like the tags of the resources, they are only applied when " +
+					"the resources are created.",
+				Attributes: map[string]tfpschema.Attribute{
+					"tags": tfpschema.MapAttribute{
+						Description: "Tags added to all the resources.",
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+				},
+			},
+			"retry": tfpschema.SingleNestedBlock{
+				Description: "Retry settings for the requests sent to the OCM API. Requests that fail with a " +
+					"network error or with a 502, 503 or 504 status code are retried when they are idempotent, " +
+					"and requests rejected with a 429 status code are always retried. The `Retry-After` header " +
+					"sent by the server is honoured.",
+				Attributes: map[string]tfpschema.Attribute{
+					"max_attempts": tfpschema.Int64Attribute{
+						Description: fmt.Sprintf("Maximum number of times a request is sent, including the first attempt. "+
+							"The default value is %d.", transport.DefaultMaxAttempts),
+						Optional: true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"min_backoff": tfpschema.StringAttribute{
+						Description: fmt.Sprintf("Minimum time to wait before retrying a request, for example `500ms` "+
+							"or `2s`. The delay grows exponentially with each attempt. The default value is `%s`.",
+							transport.DefaultMinBackoff),
+						Optional: true,
+					},
+					"max_backoff": tfpschema.StringAttribute{
+						Description: fmt.Sprintf("Maximum time to wait before retrying a request, for example `1m`. "+
+							"The default value is `%s`.", transport.DefaultMaxBackoff),
+						Optional: true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -148,6 +291,127 @@ func (p *Provider) getAttrValueOrConfig(attr types.String, envSuffix string) (st
 	return "", false
 }
 
+// getReadOnly returns the value of the `read_only` attribute, or of the `RHCS_READ_ONLY`
+// environment variable if the attribute isn't set.
+func (p *Provider) getReadOnly(config Config) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if !config.ReadOnly.IsNull() {
+		return config.ReadOnly.ValueBool(), diags
+	}
+	value, ok := os.LookupEnv("RHCS_READ_ONLY")
+	if !ok || value == "" {
+		return false, diags
+	}
+	readOnly, err := strconv.ParseBool(value)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("read_only"),
+			"Invalid read-only mode",
+			fmt.Sprintf("Value '%s' of environment variable 'RHCS_READ_ONLY' isn't a valid boolean", value),
+		)
+	}
+	return readOnly, diags
+}
+
+// loadOCMConfig returns the content of the OCM configuration file. A file set explicitly with the
+// `config_file` attribute or the `RHCS_CONFIG_FILE` environment variable must exist. Otherwise the
+// default location is only checked when no credentials were provided, and nil is returned if there
+// is no file there.
+func (p *Provider) loadOCMConfig(config Config, hasCredentials bool) (*OCMConfig, error) {
+	if location, ok := p.getAttrValueOrConfig(config.ConfigFile, "CONFIG_FILE"); ok {
+		return LoadOCMConfig(location)
+	}
+	if hasCredentials {
+		return nil, nil
+	}
+	location, err := DefaultOCMConfigLocation()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(location); errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	ocmConfig, err := LoadOCMConfig(location)
+	if err != nil {
+		return nil, err
+	}
+	if !ocmConfig.HasCredentials() {
+		return nil, nil
+	}
+	return ocmConfig, nil
+}
+
+// getRetryOptions converts the retry settings of the provider configuration into the options of
+// the retry transport wrapper.
+func (p *Provider) getRetryOptions(config *RetryConfig) (transport.RetryOptions, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	options := transport.RetryOptions{
+		MaxAttempts: transport.DefaultMaxAttempts,
+		MinBackoff:  transport.DefaultMinBackoff,
+		MaxBackoff:  transport.DefaultMaxBackoff,
+	}
+	if common.HasValue(config.MaxAttempts) {
+		options.MaxAttempts = int(config.MaxAttempts.ValueInt64())
+	}
+	parseBackoff := func(attr types.String, name string, value *time.Duration) {
+		if !common.HasValue(attr) {
+			return
+		}
+		duration, err := time.ParseDuration(attr.ValueString())
+		if err != nil || duration <= 0 {
+			diags.AddAttributeError(
+				path.Root("retry").AtName(name),
+				"Invalid retry backoff",
+				fmt.Sprintf("The value of '%s' must be a positive duration, for example '2s', but got '%s'",
+					name, attr.ValueString()),
+			)
+			return
+		}
+		*value = duration
+	}
+	parseBackoff(config.MinBackoff, "min_backoff", &options.MinBackoff)
+	parseBackoff(config.MaxBackoff, "max_backoff", &options.MaxBackoff)
+	if !diags.HasError() && options.MaxBackoff < options.MinBackoff {
+		diags.AddAttributeError(
+			path.Root("retry").AtName("max_backoff"),
+			"Invalid retry backoff",
+			fmt.Sprintf("The value of 'max_backoff' (%s) can't be lower than the value of 'min_backoff' (%s)",
+				options.MaxBackoff, options.MinBackoff),
+		)
+	}
+	return options, diags
+}
+
+// getProviderDefaults returns the default tags and properties of the provider configuration.
+func (p *Provider) getProviderDefaults(ctx context.Context, config Config) (common.ProviderDefaults, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	defaults := common.ProviderDefaults{}
+	if config.DefaultTags != nil {
+		tags, err := common.OptionalMap(ctx, config.DefaultTags.Tags)
+		if err != nil {
+			diags.AddAttributeError(path.Root("default_tags").AtName("tags"), "Invalid default tags", err.Error())
+			return defaults, diags
+		}
+		defaults.Tags = tags
+	}
+	properties, err := common.OptionalMap(ctx, config.DefaultProperties)
+	if err != nil {
+		diags.AddAttributeError(path.Root("default_properties"), "Invalid default properties", err.Error())
+		return defaults, diags
+	}
+	for k := range properties {
+		if _, isReserved := rosa.OCMProperties[k]; isReserved {
+			diags.AddAttributeError(
+				path.Root("default_properties"),
+				"Invalid default properties",
+				fmt.Sprintf("Can not override reserved property '%s'", k),
+			)
+		}
+	}
+	defaults.Properties = properties
+	return defaults, diags
+}
+
 // configure is the configuration function of the provider. It is responsible for checking the
 // connection parameters and creating the connection that will be used by the resources.
 func (p *Provider) Configure(ctx context.Context, req tfprovider.ConfigureRequest,
@@ -170,23 +434,72 @@ func (p *Provider) Configure(ctx context.Context, req tfprovider.ConfigureReques
 	builder.Logger(logger)
 	builder.Agent(fmt.Sprintf("OCM-TF/%s-%s", build.Version, build.Commit))
 
+	// Check the credentials given explicitly, as the OCM configuration file is only used as a
+	// fallback when there are none:
+	token, tokenExists := p.getAttrValueOrConfig(config.Token, "TOKEN")
+	refreshToken, refreshTokenExists := p.getAttrValueOrConfig(config.RefreshToken, "REFRESH_TOKEN")
+	clientID, clientIdExists := p.getAttrValueOrConfig(config.ClientID, "CLIENT_ID")
+	clientSecret, _ := p.getAttrValueOrConfig(config.ClientSecret, "CLIENT_SECRET")
+	ocmConfig, err := p.loadOCMConfig(config, tokenExists || refreshTokenExists || clientIdExists)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to load OCM configuration file", err.Error())
+		return
+	}
+	if ocmConfig == nil {
+		ocmConfig = &OCMConfig{}
+	}
+
+	// Resolve the environment preset, which takes precedence over the configuration file:
+	url, urlExists := p.getAttrValueOrConfig(config.URL, "URL")
+	if envName, ok := p.getAttrValueOrConfig(config.Environment, "ENVIRONMENT"); ok {
+		preset, found := common.Environments[envName]
+		if !found {
+			resp.Diagnostics.AddError(
+				"Invalid OCM environment",
+				fmt.Sprintf("Unknown environment '%s', valid values are: %s",
+					envName, strings.Join(common.EnvironmentNames(), ", ")),
+			)
+			return
+		}
+		if urlExists {
+			resp.Diagnostics.AddError(
+				"Conflicting OCM environment and URL",
+				fmt.Sprintf("The environment '%s' can't be used together with an explicit URL '%s'", envName, url),
+			)
+			return
+		}
+		ocmConfig.URL = preset.URL
+		ocmConfig.TokenURL = preset.TokenURL
+		if preset.ClientID != "" {
+			ocmConfig.ClientID = preset.ClientID
+			ocmConfig.ClientSecret = ""
+		}
+	}
+
 	// Copy the settings:
-	if url, ok := p.getAttrValueOrConfig(config.URL, "URL"); ok {
+	if urlExists {
 		builder.URL(url)
+	} else if ocmConfig.URL != "" {
+		builder.URL(ocmConfig.URL)
 	}
 	if tokenURL, ok := p.getAttrValueOrConfig(config.TokenURL, "TOKEN_URL"); ok {
 		builder.TokenURL(tokenURL)
+	} else if ocmConfig.TokenURL != "" {
+		builder.TokenURL(ocmConfig.TokenURL)
 	}
-	if token, ok := p.getAttrValueOrConfig(config.Token, "TOKEN"); ok {
+	if tokenExists {
 		builder.Tokens(token)
 	}
-	if refreshToken, ok := p.getAttrValueOrConfig(config.RefreshToken, "REFRESH_TOKEN"); ok {
+	if refreshTokenExists {
 		builder.Tokens(refreshToken)
 	}
-	clientID, clientIdExists := p.getAttrValueOrConfig(config.ClientID, "CLIENT_ID")
-	clientSecret, _ := p.getAttrValueOrConfig(config.ClientSecret, "CLIENT_SECRET")
+	if !tokenExists && !refreshTokenExists {
+		builder.Tokens(ocmConfig.Tokens()...)
+	}
 	if clientIdExists {
 		builder.Client(clientID, clientSecret)
+	} else if ocmConfig.ClientID != "" {
+		builder.Client(ocmConfig.ClientID, ocmConfig.ClientSecret)
 	}
 	if trustedCAs, ok := p.getAttrValueOrConfig(config.TrustedCAs, "TRUSTED_CAS"); ok {
 		pool := x509.NewCertPool()
@@ -201,6 +514,58 @@ func (p *Provider) Configure(ctx context.Context, req tfprovider.ConfigureReques
 	}
 	if !config.Insecure.IsNull() {
 		builder.Insecure(config.Insecure.ValueBool())
+	} else if ocmConfig.Insecure {
+		builder.Insecure(true)
+	}
+
+	readOnly, diags := p.getReadOnly(config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// The read-only wrapper is added first so that the blocked requests are never retried:
+	if readOnly {
+		builder.TransportWrapper(transport.NewReadOnlyWrapper())
+	}
+
+	if config.Retry != nil {
+		options, diags := p.getRetryOptions(config.Retry)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		// The retries are handled by the transport wrapper, so the ones of the SDK are disabled to
+		// avoid multiplying the number of attempts:
+		builder.RetryLimit(0)
+		builder.TransportWrapper(transport.NewRetryWrapper(options, logger))
+	}
+	// The limiter is added after the retry wrapper so that requests waiting to be retried don't
+	// hold a slot:
+	if common.HasValue(config.MaxConcurrentRequests) {
+		builder.TransportWrapper(transport.NewLimiterWrapper(int(config.MaxConcurrentRequests.ValueInt64())))
+	}
+	// The audit log is added last so that each request actually sent, including retries, is
+	// written:
+	if auditLogPath, ok := p.getAttrValueOrConfig(config.AuditLogPath, "AUDIT_LOG_PATH"); ok && auditLogPath != "" {
+		auditLog, err := os.OpenFile(auditLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("audit_log_path"),
+				"Can't open audit log",
+				fmt.Sprintf("Can't open audit log file '%s': %v", auditLogPath, err),
+			)
+			return
+		}
+		builder.TransportWrapper(transport.NewAuditWrapper(auditLog))
+	}
+	if tracing.Enabled() {
+		builder.TransportWrapper(transport.NewTracingWrapper())
+	}
+
+	defaults, diags := p.getProviderDefaults(ctx, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Create the connection:
@@ -209,10 +574,12 @@ func (p *Provider) Configure(ctx context.Context, req tfprovider.ConfigureReques
 		resp.Diagnostics.AddError(err.Error(), "")
 		return
 	}
+	common.SetProviderDefaults(connection, defaults)
 
 	// Save the connection:
 	resp.DataSourceData = connection
 	resp.ResourceData = connection
+	resp.EphemeralResourceData = connection
 }
 
 // Resources returns the resources supported by the provider.
@@ -236,6 +603,11 @@ func (p *Provider) Resources(ctx context.Context) []func() resource.Resource {
 		hcpingress.New,
 		tuningconfigs.New,
 		hcpAutoscaler.New,
+		clusterupgradepolicy.New,
+		externalauthprovider.New,
+		breakglasscredential.New,
+		clusteraddon.New,
+		networkverification.New,
 	}
 }
 
@@ -256,5 +628,25 @@ func (p *Provider) DataSources(ctx context.Context) []func() datasource.DataSour
 		hcpStsPolicies.New,
 		trusted_ip_addresses.New,
 		imagemirror.NewDataSource,
+		breakglasscredential.NewDataSource,
+		clusteraddon.NewDataSource,
+		clusters.New,
+	}
+}
+
+// EphemeralResources returns the ephemeral resources supported by the provider.
+func (p *Provider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		accesstoken.New,
+		clusteradmincredentials.New,
+	}
+}
+
+func (p *Provider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		functions.NewOperatorRoleARNs,
+		functions.NewCIDRsOverlap,
+		functions.NewVersionCompare,
+		functions.NewMinorVersion,
 	}
 }
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"os"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common/transport"
+)
+
+var _ = Describe("Provider configuration", func() {
+	p := &Provider{}
+
+	Context("getRetryOptions", func() {
+		It("Should use the defaults for the settings that aren't set", func() {
+			options, diags := p.getRetryOptions(&RetryConfig{
+				MaxAttempts: types.Int64Null(),
+				MinBackoff:  types.StringNull(),
+				MaxBackoff:  types.StringNull(),
+			})
+			Expect(diags.HasError()).To(BeFalse())
+			Expect(options).To(Equal(transport.RetryOptions{
+				MaxAttempts: transport.DefaultMaxAttempts,
+				MinBackoff:  transport.DefaultMinBackoff,
+				MaxBackoff:  transport.DefaultMaxBackoff,
+			}))
+		})
+		It("Should parse the durations", func() {
+			options, diags := p.getRetryOptions(&RetryConfig{
+				MaxAttempts: types.Int64Value(5),
+				MinBackoff:  types.StringValue("500ms"),
+				MaxBackoff:  types.StringValue("1m"),
+			})
+			Expect(diags.HasError()).To(BeFalse())
+			Expect(options).To(Equal(transport.RetryOptions{
+				MaxAttempts: 5,
+				MinBackoff:  500 * time.Millisecond,
+				MaxBackoff:  time.Minute,
+			}))
+		})
+		It("Should fail with invalid durations", func() {
+			_, diags := p.getRetryOptions(&RetryConfig{
+				MaxAttempts: types.Int64Null(),
+				MinBackoff:  types.StringValue("often"),
+				MaxBackoff:  types.StringNull(),
+			})
+			Expect(diags.HasError()).To(BeTrue())
+		})
+		It("Should fail when the maximum backoff is lower than the minimum", func() {
+			_, diags := p.getRetryOptions(&RetryConfig{
+				MaxAttempts: types.Int64Null(),
+				MinBackoff:  types.StringValue("10s"),
+				MaxBackoff:  types.StringValue("1s"),
+			})
+			Expect(diags.HasError()).To(BeTrue())
+		})
+	})
+
+	Context("getReadOnly", func() {
+		BeforeEach(func() {
+			if value, ok := os.LookupEnv("RHCS_READ_ONLY"); ok {
+				DeferCleanup(os.Setenv, "RHCS_READ_ONLY", value)
+			} else {
+				DeferCleanup(os.Unsetenv, "RHCS_READ_ONLY")
+			}
+			Expect(os.Unsetenv("RHCS_READ_ONLY")).To(Succeed())
+		})
+		It("Should be disabled by default", func() {
+			readOnly, diags := p.getReadOnly(Config{ReadOnly: types.BoolNull()})
+			Expect(diags.HasError()).To(BeFalse())
+			Expect(readOnly).To(BeFalse())
+		})
+		It("Should use the environment variable when the attribute isn't set", func() {
+			Expect(os.Setenv("RHCS_READ_ONLY", "true")).To(Succeed())
+			readOnly, diags := p.getReadOnly(Config{ReadOnly: types.BoolNull()})
+			Expect(diags.HasError()).To(BeFalse())
+			Expect(readOnly).To(BeTrue())
+		})
+		It("Should give precedence to the attribute", func() {
+			Expect(os.Setenv("RHCS_READ_ONLY", "true")).To(Succeed())
+			readOnly, diags := p.getReadOnly(Config{ReadOnly: types.BoolValue(false)})
+			Expect(diags.HasError()).To(BeFalse())
+			Expect(readOnly).To(BeFalse())
+		})
+		It("Should fail with an invalid environment variable", func() {
+			Expect(os.Setenv("RHCS_READ_ONLY", "maybe")).To(Succeed())
+			_, diags := p.getReadOnly(Config{ReadOnly: types.BoolNull()})
+			Expect(diags.HasError()).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,172 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common/transport"
+	"github.com/terraform-redhat/terraform-provider-rhcs/tracing"
+)
+
+// Server wraps the protocol server of the provider in order to add to the context of each
+// request the type of the resource, data source or ephemeral resource that it is for, and to
+// create a span for each operation. Terraform doesn't send the address of the resource to the
+// provider, so the type is what the audit log and the traces use to tell which resource sent
+// each request to the OCM API.
+type Server struct {
+	tfprotov6.ProviderServer
+}
+
+// NewServer returns the function that creates the protocol server of the provider.
+func NewServer() func() tfprotov6.ProviderServer {
+	return func() tfprotov6.ProviderServer {
+		return &Server{
+			ProviderServer: providerserver.NewProtocol6(New())(),
+		}
+	}
+}
+
+func (s *Server) UpgradeResourceState(ctx context.Context,
+	req *tfprotov6.UpgradeResourceStateRequest) (*tfprotov6.UpgradeResourceStateResponse, error) {
+	ctx, span := startSpan(ctx, req.TypeName, "upgrade")
+	resp, err := s.ProviderServer.UpgradeResourceState(ctx, req)
+	if resp != nil {
+		endSpan(span, resp.Diagnostics, err)
+	} else {
+		endSpan(span, nil, err)
+	}
+	return resp, err
+}
+
+func (s *Server) ReadResource(ctx context.Context,
+	req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	ctx, span := startSpan(ctx, req.TypeName, "read")
+	resp, err := s.ProviderServer.ReadResource(ctx, req)
+	if resp != nil {
+		endSpan(span, resp.Diagnostics, err)
+	} else {
+		endSpan(span, nil, err)
+	}
+	return resp, err
+}
+
+func (s *Server) PlanResourceChange(ctx context.Context,
+	req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	ctx, span := startSpan(ctx, req.TypeName, "plan")
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+	if resp != nil {
+		endSpan(span, resp.Diagnostics, err)
+	} else {
+		endSpan(span, nil, err)
+	}
+	return resp, err
+}
+
+func (s *Server) ApplyResourceChange(ctx context.Context,
+	req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	ctx, span := startSpan(ctx, req.TypeName, applyOperation(req))
+	resp, err := s.ProviderServer.ApplyResourceChange(ctx, req)
+	if resp != nil {
+		endSpan(span, resp.Diagnostics, err)
+	} else {
+		endSpan(span, nil, err)
+	}
+	return resp, err
+}
+
+func (s *Server) ImportResourceState(ctx context.Context,
+	req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+	ctx, span := startSpan(ctx, req.TypeName, "import")
+	resp, err := s.ProviderServer.ImportResourceState(ctx, req)
+	if resp != nil {
+		endSpan(span, resp.Diagnostics, err)
+	} else {
+		endSpan(span, nil, err)
+	}
+	return resp, err
+}
+
+func (s *Server) ReadDataSource(ctx context.Context,
+	req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	ctx, span := startSpan(ctx, req.TypeName, "read")
+	resp, err := s.ProviderServer.ReadDataSource(ctx, req)
+	if resp != nil {
+		endSpan(span, resp.Diagnostics, err)
+	} else {
+		endSpan(span, nil, err)
+	}
+	return resp, err
+}
+
+func (s *Server) OpenEphemeralResource(ctx context.Context,
+	req *tfprotov6.OpenEphemeralResourceRequest) (*tfprotov6.OpenEphemeralResourceResponse, error) {
+	ctx, span := startSpan(ctx, req.TypeName, "open")
+	resp, err := s.ProviderServer.OpenEphemeralResource(ctx, req)
+	if resp != nil {
+		endSpan(span, resp.Diagnostics, err)
+	} else {
+		endSpan(span, nil, err)
+	}
+	return resp, err
+}
+
+// startSpan adds the type of the resource to the context and starts the span of the operation.
+func startSpan(ctx context.Context, typeName string, operation string) (context.Context, trace.Span) {
+	ctx = transport.WithResource(ctx, typeName, operation)
+	return tracing.StartSpan(
+		tracing.ParentContext(ctx),
+		fmt.Sprintf("%s %s", typeName, operation),
+		tracing.ResourceTypeKey.String(typeName),
+		tracing.ResourceOperationKey.String(operation),
+	)
+}
+
+// endSpan ends the span of an operation, marking it as failed if the response contains errors.
+func endSpan(span trace.Span, diagnostics []*tfprotov6.Diagnostic, err error) {
+	if err == nil {
+		for _, diagnostic := range diagnostics {
+			if diagnostic.Severity == tfprotov6.DiagnosticSeverityError {
+				err = errors.New(diagnostic.Summary)
+				break
+			}
+		}
+	}
+	tracing.EndSpan(span, err)
+}
+
+// applyOperation returns the operation performed by an apply request: the resource is created
+// when there is no prior state and deleted when there is no planned state.
+func applyOperation(req *tfprotov6.ApplyResourceChangeRequest) string {
+	if req.PriorState != nil {
+		if null, err := req.PriorState.IsNull(); err == nil && null {
+			return "create"
+		}
+	}
+	if req.PlannedState != nil {
+		if null, err := req.PlannedState.IsNull(); err == nil && null {
+			return "delete"
+		}
+	}
+	return "update"
+}
@@ -112,6 +112,11 @@ func (r *TuningConfigResource) Create(ctx context.Context, req resource.CreateRe
 		)
 		return
 	}
+
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(plan.Cluster.ValueString())
+	defer common.ClusterMutexKV.Unlock(plan.Cluster.ValueString())
+
 	err = r.createTuningConfig(ctx, plan, plan.Cluster.ValueString(), r.collection)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -180,6 +185,10 @@ func (r *TuningConfigResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(state.Cluster.ValueString())
+	defer common.ClusterMutexKV.Unlock(state.Cluster.ValueString())
+
 	err := r.updateTuningConfig(ctx, state, plan, plan.Cluster.ValueString(), r.collection)
 	if err != nil {
 		diags.AddError(
@@ -218,7 +227,12 @@ func (r *TuningConfigResource) Delete(ctx context.Context, req resource.DeleteRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	// Send the request to delete the machine pool:
+
+	// Serialize the changes sent to the cluster:
+	common.ClusterMutexKV.Lock(state.Cluster.ValueString())
+	defer common.ClusterMutexKV.Unlock(state.Cluster.ValueString())
+
+	// Send the request to delete the tuning config:
 	resource := r.collection.Cluster(state.Cluster.ValueString()).
 		TuningConfigs().
 		TuningConfig(state.Id.ValueString())
@@ -0,0 +1,483 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package classic
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"                      // nolint
+	. "github.com/onsi/gomega"                         // nolint
+	. "github.com/onsi/gomega/ghttp"                   // nolint
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+	. "github.com/terraform-redhat/terraform-provider-rhcs/subsystem/framework"
+)
+
+var _ = Describe("Cluster add-on", func() {
+	const clusterReady = `{
+		"kind": "Cluster",
+		"id": "123",
+		"href": "/api/clusters_mgmt/v1/clusters/123",
+		"name": "cluster",
+		"state": "ready"
+	}`
+	const addon = `{
+		"kind": "AddOn",
+		"id": "logging",
+		"href": "/api/clusters_mgmt/v1/addons/logging",
+		"name": "Logging",
+		"description": "Cluster logging",
+		"enabled": true,
+		"version": {
+			"id": "1.2.0"
+		},
+		"parameters": {
+			"items": [
+				{
+					"id": "retention",
+					"name": "Retention",
+					"value_type": "number",
+					"required": true,
+					"editable": true,
+					"enabled": true
+				},
+				{
+					"id": "storage",
+					"name": "Storage class",
+					"value_type": "string",
+					"editable": false,
+					"enabled": true,
+					"default_value": "gp3",
+					"options": [
+						{
+							"name": "GP2",
+							"value": "gp2"
+						},
+						{
+							"name": "GP3",
+							"value": "gp3"
+						}
+					]
+				}
+			]
+		}
+	}`
+	const installation = `{
+		"kind": "AddOnInstallation",
+		"id": "logging",
+		"href": "/api/clusters_mgmt/v1/clusters/123/addons/logging",
+		"addon": {
+			"kind": "AddOnLink",
+			"id": "logging"
+		},
+		"addon_version": {
+			"id": "1.2.0"
+		},
+		"state": "ready",
+		"parameters": {
+			"items": [
+				{
+					"id": "retention",
+					"value": "7"
+				},
+				{
+					"id": "storage",
+					"value": "gp3"
+				}
+			]
+		}
+	}`
+
+	BeforeEach(func() {
+		TestServer.RouteToHandler(http.MethodGet, "/api/clusters_mgmt/v1/addons/logging",
+			RespondWithJSON(http.StatusOK, addon))
+	})
+
+	// installAddon creates the add-on with a retention of seven days.
+	installAddon := func() {
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, clusterReady),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters/123/addons"),
+				VerifyJQ(".addon.id", "logging"),
+				VerifyJQ(".parameters.items[0].id", "retention"),
+				VerifyJQ(".parameters.items[0].value", "7"),
+				RespondWithPatchedJSON(http.StatusCreated, installation, `[
+					{
+						"op": "replace",
+						"path": "/state",
+						"value": "installing"
+					}
+				]`),
+			),
+		)
+		Terraform.Source(`
+			resource "rhcs_cluster_addon" "logging" {
+				cluster    = "123"
+				addon      = "logging"
+				parameters = {
+					retention = "7"
+				}
+			}
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).To(BeZero())
+	}
+
+	It("installs an add-on and waits till it is ready", func() {
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, clusterReady),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters/123/addons"),
+				VerifyJQ(".addon.id", "logging"),
+				VerifyJQ(".parameters.items | length", 1),
+				VerifyJQ(".parameters.items[0].id", "retention"),
+				VerifyJQ(".parameters.items[0].value", "7"),
+				RespondWithPatchedJSON(http.StatusCreated, installation, `[
+					{
+						"op": "replace",
+						"path": "/state",
+						"value": "installing"
+					}
+				]`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/addons/logging"),
+				RespondWithJSON(http.StatusOK, installation),
+			),
+		)
+		Terraform.Source(`
+			resource "rhcs_cluster_addon" "logging" {
+				cluster        = "123"
+				addon          = "logging"
+				wait_for_ready = true
+				parameters = {
+					retention = "7"
+				}
+			}
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).To(BeZero())
+
+		resource := Terraform.Resource("rhcs_cluster_addon", "logging")
+		Expect(resource).To(MatchJQ(".attributes.id", "logging"))
+		Expect(resource).To(MatchJQ(".attributes.state", "ready"))
+		Expect(resource).To(MatchJQ(".attributes.version", "1.2.0"))
+		Expect(resource).To(MatchJQ(".attributes.parameters", map[string]interface{}{
+			"retention": "7",
+		}))
+	})
+
+	It("fails if the add-on doesn't reach the ready state", func() {
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, clusterReady),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters/123/addons"),
+				RespondWithJSON(http.StatusCreated, installation),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/addons/logging"),
+				RespondWithPatchedJSON(http.StatusOK, installation, `[
+					{
+						"op": "replace",
+						"path": "/state",
+						"value": "failed"
+					},
+					{
+						"op": "add",
+						"path": "/state_description",
+						"value": "not enough capacity"
+					}
+				]`),
+			),
+		)
+		Terraform.Source(`
+			resource "rhcs_cluster_addon" "logging" {
+				cluster        = "123"
+				addon          = "logging"
+				wait_for_ready = true
+				parameters = {
+					retention = "7"
+				}
+			}
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).ToNot(BeZero())
+		runOutput.VerifyErrorContainsSubstring("not enough capacity")
+
+		// The add-on is kept in the state so that it can be uninstalled:
+		resource := Terraform.Resource("rhcs_cluster_addon", "logging")
+		Expect(resource).To(MatchJQ(".attributes.id", "logging"))
+	})
+
+	It("rejects parameters that the add-on doesn't have", func() {
+		Terraform.Source(`
+			resource "rhcs_cluster_addon" "logging" {
+				cluster    = "123"
+				addon      = "logging"
+				parameters = {
+					retention = "7"
+					junk      = "yes"
+				}
+			}
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).ToNot(BeZero())
+		runOutput.VerifyErrorContainsSubstring("doesn't have a parameter named 'junk'")
+	})
+
+	It("rejects values that aren't valid options", func() {
+		Terraform.Source(`
+			resource "rhcs_cluster_addon" "logging" {
+				cluster    = "123"
+				addon      = "logging"
+				parameters = {
+					retention = "7"
+					storage   = "io1"
+				}
+			}
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).ToNot(BeZero())
+		runOutput.VerifyErrorContainsSubstring("isn't one of the valid options")
+	})
+
+	It("rejects missing required parameters", func() {
+		Terraform.Source(`
+			resource "rhcs_cluster_addon" "logging" {
+				cluster = "123"
+				addon   = "logging"
+			}
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).ToNot(BeZero())
+		runOutput.VerifyErrorContainsSubstring("requires parameters [retention]")
+	})
+
+	It("updates editable parameters in place", func() {
+		installAddon()
+
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/addons/logging"),
+				RespondWithJSON(http.StatusOK, installation),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/123/addons/logging"),
+				VerifyJQ(".parameters.items[0].id", "retention"),
+				VerifyJQ(".parameters.items[0].value", "14"),
+				RespondWithPatchedJSON(http.StatusOK, installation, `[
+					{
+						"op": "replace",
+						"path": "/parameters/items/0/value",
+						"value": "14"
+					}
+				]`),
+			),
+		)
+		Terraform.Source(`
+			resource "rhcs_cluster_addon" "logging" {
+				cluster    = "123"
+				addon      = "logging"
+				parameters = {
+					retention = "14"
+				}
+			}
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).To(BeZero())
+
+		resource := Terraform.Resource("rhcs_cluster_addon", "logging")
+		Expect(resource).To(MatchJQ(".attributes.parameters.retention", "14"))
+	})
+
+	It("rejects changes to parameters that aren't editable", func() {
+		installAddon()
+
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/addons/logging"),
+				RespondWithJSON(http.StatusOK, installation),
+			),
+		)
+		Terraform.Source(`
+			resource "rhcs_cluster_addon" "logging" {
+				cluster    = "123"
+				addon      = "logging"
+				parameters = {
+					retention = "7"
+					storage   = "gp2"
+				}
+			}
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).ToNot(BeZero())
+		runOutput.VerifyErrorContainsSubstring("can't be changed after installation")
+	})
+
+	It("uninstalls the add-on and waits till it is removed", func() {
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, clusterReady),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters/123/addons"),
+				RespondWithJSON(http.StatusCreated, installation),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/addons/logging"),
+				RespondWithJSON(http.StatusOK, installation),
+			),
+		)
+		Terraform.Source(`
+			resource "rhcs_cluster_addon" "logging" {
+				cluster        = "123"
+				addon          = "logging"
+				wait_for_ready = true
+				parameters = {
+					retention = "7"
+				}
+			}
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).To(BeZero())
+
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/addons/logging"),
+				RespondWithJSON(http.StatusOK, installation),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodDelete, "/api/clusters_mgmt/v1/clusters/123/addons/logging"),
+				RespondWithJSON(http.StatusNoContent, "{}"),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/addons/logging"),
+				RespondWithJSON(http.StatusNotFound, "{}"),
+			),
+		)
+		runOutput = Terraform.Destroy()
+		Expect(runOutput.ExitCode).To(BeZero())
+	})
+
+	It("imports an add-on with all its parameters", func() {
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/addons/logging"),
+				RespondWithJSON(http.StatusOK, installation),
+			),
+		)
+		Terraform.Source(`
+			resource "rhcs_cluster_addon" "logging" {
+				cluster = "123"
+				addon   = "logging"
+			}
+		`)
+		runOutput := Terraform.Import("rhcs_cluster_addon.logging", "123,logging")
+		Expect(runOutput.ExitCode).To(BeZero())
+
+		resource := Terraform.Resource("rhcs_cluster_addon", "logging")
+		Expect(resource).To(MatchJQ(".attributes.addon", "logging"))
+		Expect(resource).To(MatchJQ(".attributes.parameters", map[string]interface{}{
+			"retention": "7",
+			"storage":   "gp3",
+		}))
+	})
+
+	It("removes the add-on from the state when it has been uninstalled", func() {
+		installAddon()
+
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/addons/logging"),
+				RespondWithJSON(http.StatusNotFound, "{}"),
+			),
+		)
+		runOutput := Terraform.Refresh()
+		Expect(runOutput.ExitCode).To(BeZero())
+		Expect(Terraform.State()).To(MatchJQ(`[.resources[] | select(.type == "rhcs_cluster_addon")] | length`, 0))
+	})
+})
+
+var _ = Describe("Add-ons data source", func() {
+	It("lists the add-ons with their parameters", func() {
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/addons"),
+				VerifyFormKV("search", "enabled = 't'"),
+				RespondWithJSON(http.StatusOK, `{
+					"page": 1,
+					"size": 1,
+					"total": 1,
+					"items": [
+						{
+							"kind": "AddOn",
+							"id": "logging",
+							"name": "Logging",
+							"description": "Cluster logging",
+							"enabled": true,
+							"version": {
+								"id": "1.2.0"
+							},
+							"parameters": {
+								"items": [
+									{
+										"id": "retention",
+										"name": "Retention",
+										"value_type": "number",
+										"required": true,
+										"editable": true,
+										"enabled": true
+									},
+									{
+										"id": "legacy",
+										"name": "Legacy",
+										"value_type": "string",
+										"enabled": false
+									}
+								]
+							}
+						}
+					]
+				}`),
+			),
+		)
+		Terraform.Source(`
+			data "rhcs_addons" "all" {
+			}
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).To(BeZero())
+
+		resource := Terraform.Resource("rhcs_addons", "all")
+		Expect(resource).To(MatchJQ(".attributes.items | length", 1))
+		Expect(resource).To(MatchJQ(".attributes.item.id", "logging"))
+		Expect(resource).To(MatchJQ(".attributes.item.version", "1.2.0"))
+		Expect(resource).To(MatchJQ(".attributes.item.parameters | length", 1))
+		Expect(resource).To(MatchJQ(".attributes.item.parameters[0].id", "retention"))
+		Expect(resource).To(MatchJQ(".attributes.item.parameters[0].required", true))
+	})
+})
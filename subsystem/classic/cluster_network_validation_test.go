@@ -0,0 +1,101 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package classic
+
+import (
+	. "github.com/onsi/ginkgo/v2" // nolint
+	. "github.com/onsi/gomega"    // nolint
+	. "github.com/terraform-redhat/terraform-provider-rhcs/subsystem/framework"
+)
+
+var _ = Describe("Cluster network validation", func() {
+	It("rejects overlapping CIDRs", func() {
+		Terraform.Source(`
+			resource "rhcs_cluster_rosa_classic" "my_cluster" {
+				name           = "my-cluster"
+				cloud_region   = "us-west-1"
+				aws_account_id = "123456789012"
+				machine_cidr   = "10.0.0.0/16"
+				service_cidr   = "10.0.128.0/20"
+				sts = {
+					operator_role_prefix = "test"
+					role_arn             = ""
+					support_role_arn     = ""
+					instance_iam_roles = {
+						master_role_arn = ""
+						worker_role_arn = ""
+					}
+				}
+			}
+		`)
+		runOutput := Terraform.Validate()
+		Expect(runOutput.ExitCode).ToNot(BeZero())
+		runOutput.VerifyErrorContainsSubstring("'service_cidr' 10.0.128.0/20 overlaps with 'machine_cidr'")
+	})
+
+	It("rejects a pod CIDR that is too small for the maximum number of nodes", func() {
+		Terraform.Source(`
+			resource "rhcs_cluster_rosa_classic" "my_cluster" {
+				name           = "my-cluster"
+				cloud_region   = "us-west-1"
+				aws_account_id = "123456789012"
+				autoscaling_enabled = true
+				min_replicas   = 2
+				max_replicas   = 20
+				pod_cidr       = "10.128.0.0/20"
+				host_prefix    = 23
+				sts = {
+					operator_role_prefix = "test"
+					role_arn             = ""
+					support_role_arn     = ""
+					instance_iam_roles = {
+						master_role_arn = ""
+						worker_role_arn = ""
+					}
+				}
+			}
+		`)
+		runOutput := Terraform.Validate()
+		Expect(runOutput.ExitCode).ToNot(BeZero())
+		runOutput.VerifyErrorContainsSubstring("only has room for 8 nodes")
+	})
+
+	It("rejects availability zones inconsistent with multi_az and the subnets", func() {
+		Terraform.Source(`
+			resource "rhcs_cluster_rosa_classic" "my_cluster" {
+				name               = "my-cluster"
+				cloud_region       = "us-west-1"
+				aws_account_id     = "123456789012"
+				multi_az           = true
+				availability_zones = ["us-west-1a"]
+				aws_subnet_ids     = ["id1", "id2"]
+				sts = {
+					operator_role_prefix = "test"
+					role_arn             = ""
+					support_role_arn     = ""
+					instance_iam_roles = {
+						master_role_arn = ""
+						worker_role_arn = ""
+					}
+				}
+			}
+		`)
+		runOutput := Terraform.Validate()
+		Expect(runOutput.ExitCode).ToNot(BeZero())
+		runOutput.VerifyErrorContainsSubstring("availability zones for a multi AZ cluster should be 3")
+	})
+})
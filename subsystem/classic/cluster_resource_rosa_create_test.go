@@ -836,6 +836,70 @@ var _ = Describe("rhcs_cluster_rosa_classic - create", func() {
 			Expect(resource).To(MatchJQ(".attributes.admin_credentials.password", "1234AbB2341234"))
 		})
 
+		It("Creates basic cluster with admin user - write-only password", func() {
+			// Prepare the server:
+			TestServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions"),
+					RespondWithJSON(http.StatusOK, versionListPage1),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
+					VerifyJQ(`.htpasswd.users.items[0].username`, "test-admin"),
+					VerifyJQ(`.htpasswd.users.items[0].hashed_password`, "hash(1234AbB2341234)"),
+					RespondWithPatchedJSON(http.StatusCreated, template, `[
+					{
+					  "op": "add",
+					  "path": "/aws",
+					  "value": {
+						  "ec2_metadata_http_tokens": "optional",
+						  "sts" : {
+							  "oidc_endpoint_url": "https://127.0.0.1",
+							  "thumbprint": "111111",
+							  "role_arn": "",
+							  "support_role_arn": "",
+							  "instance_iam_roles" : {
+								"master_role_arn" : "",
+								"worker_role_arn" : ""
+							  },
+							  "operator_role_prefix" : "test"
+						  }
+					  }
+					}]`),
+				),
+			)
+
+			// Run the apply command:
+			Terraform.Source(`
+		  resource "rhcs_cluster_rosa_classic" "my_cluster" {
+		    name           = "my-cluster"
+		    cloud_region   = "us-west-1"
+			aws_account_id = "123456789012"
+            admin_credentials = {
+                username = "test-admin"
+            }
+            admin_credentials_password_wo = "1234AbB2341234"
+            admin_credentials_password_wo_version = 1
+			sts = {
+				operator_role_prefix = "test"
+				role_arn = "",
+				support_role_arn = "",
+				instance_iam_roles = {
+					master_role_arn = "",
+					worker_role_arn = "",
+				}
+			}
+		  }
+		`)
+			runOutput := Terraform.Apply()
+			Expect(runOutput.ExitCode).To(BeZero())
+			resource := Terraform.Resource("rhcs_cluster_rosa_classic", "my_cluster")
+			Expect(resource).To(MatchJQ(".attributes.admin_credentials.username", "test-admin"))
+			Expect(resource).To(MatchJQ(".attributes.admin_credentials.password", nil))
+			Expect(resource).To(MatchJQ(".attributes.admin_credentials_password_wo", nil))
+			Expect(resource).To(MatchJQ(".attributes.admin_credentials_password_wo_version", 1.0))
+		})
+
 		It("Creates basic cluster with empty admincredentials and update the clustrer w/o updates on it", func() {
 			// Prepare the server:
 			TestServer.AppendHandlers(
@@ -1045,7 +1109,7 @@ var _ = Describe("rhcs_cluster_rosa_classic - create", func() {
 			Expect(runOutput.ExitCode).To(BeZero())
 			resource := Terraform.Resource("rhcs_cluster_rosa_classic", "my_cluster")
 			Expect(strings.Contains(fmt.Sprintf("%v", resource), "username")).Should(BeFalse())
-			Expect(strings.Contains(fmt.Sprintf("%v", resource), "password")).Should(BeFalse())
+			Expect(resource).To(MatchJQ(".attributes.admin_credentials", nil))
 
 			// Prepare server for update
 			TestServer.AppendHandlers(
@@ -2070,6 +2134,292 @@ var _ = Describe("rhcs_cluster_rosa_classic - create", func() {
 			})
 		})
 
+		const stsPatch = `[
+		{
+		  "op": "add",
+		  "path": "/aws",
+		  "value": {
+		    "ec2_metadata_http_tokens": "optional",
+		    "sts" : {
+		      "oidc_endpoint_url": "https://127.0.0.1",
+		      "thumbprint": "111111",
+		      "role_arn": "",
+		      "support_role_arn": "",
+		      "instance_iam_roles" : {
+		        "master_role_arn" : "",
+		        "worker_role_arn" : ""
+		      },
+		      "operator_role_prefix" : "test"
+		    }
+		  }
+		}]`
+
+		Context("Delete protection", func() {
+			const protectedPatch = `[
+			{
+			  "op": "add",
+			  "path": "/aws",
+			  "value": {
+			    "ec2_metadata_http_tokens": "optional",
+			    "sts" : {
+			      "oidc_endpoint_url": "https://127.0.0.1",
+			      "thumbprint": "111111",
+			      "role_arn": "",
+			      "support_role_arn": "",
+			      "instance_iam_roles" : {
+			        "master_role_arn" : "",
+			        "worker_role_arn" : ""
+			      },
+			      "operator_role_prefix" : "test"
+			    }
+			  }
+			},
+			{
+			  "op": "add",
+			  "path": "/delete_protection",
+			  "value": {
+			    "enabled": true
+			  }
+			}]`
+
+			source := func(deleteProtection bool) string {
+				return fmt.Sprintf(`
+				  resource "rhcs_cluster_rosa_classic" "my_cluster" {
+					name           = "my-cluster"
+					cloud_region   = "us-west-1"
+					aws_account_id = "123456789012"
+					delete_protection = %t
+					disable_waiting_in_destroy = true
+					sts = {
+						operator_role_prefix = "test"
+						role_arn = "",
+						support_role_arn = "",
+						instance_iam_roles = {
+							master_role_arn = "",
+							worker_role_arn = "",
+						}
+					}
+				  }
+				`, deleteProtection)
+			}
+
+			BeforeEach(func() {
+				TestServer.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions"),
+						RespondWithJSON(http.StatusOK, versionListPage1),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
+						VerifyJQ(`.name`, "my-cluster"),
+						RespondWithPatchedJSON(http.StatusCreated, template, stsPatch),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/123/delete_protection"),
+						VerifyJQ(`.enabled`, true),
+						RespondWithJSON(http.StatusOK, "{}"),
+					),
+				)
+				Terraform.Source(source(true))
+				Expect(Terraform.Apply().ExitCode).To(BeZero())
+				resource := Terraform.Resource("rhcs_cluster_rosa_classic", "my_cluster")
+				Expect(resource).To(MatchJQ(`.attributes.delete_protection`, true))
+			})
+
+			It("Refuses to destroy the cluster while protected", func() {
+				TestServer.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+						RespondWithPatchedJSON(http.StatusOK, template, protectedPatch),
+					),
+				)
+				runOutput := Terraform.Destroy()
+				Expect(runOutput.ExitCode).ToNot(BeZero())
+				runOutput.VerifyErrorContainsSubstring("has delete protection enabled")
+				runOutput.VerifyOutputContainsSubstring("Cluster has delete protection enabled")
+			})
+
+			It("Doesn't warn about the protection when an attribute that can't be updated changes", func() {
+				// The name can't be updated, but changing it doesn't replace the cluster, the
+				// update fails instead:
+				TestServer.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+						RespondWithPatchedJSON(http.StatusOK, template, protectedPatch),
+					),
+				)
+				Terraform.Source(strings.Replace(source(true), "my-cluster", "your-cluster", 1))
+				runOutput := Terraform.Apply()
+				Expect(runOutput.ExitCode).ToNot(BeZero())
+				runOutput.VerifyErrorContainsSubstring("Attribute value cannot be changed")
+				runOutput.VerifyOutputDoesNotContainSubstring("Cluster has delete protection enabled")
+			})
+
+			It("Disables the delete protection and then destroys the cluster", func() {
+				TestServer.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+						RespondWithPatchedJSON(http.StatusOK, template, protectedPatch),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/123/delete_protection"),
+						VerifyJQ(`.enabled`, false),
+						RespondWithJSON(http.StatusOK, "{}"),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/123"),
+						RespondWithPatchedJSON(http.StatusOK, template, stsPatch),
+					),
+				)
+				Terraform.Source(source(false))
+				runOutput := Terraform.Apply()
+				Expect(runOutput.ExitCode).To(BeZero())
+				runOutput.VerifyOutputDoesNotContainSubstring("Cluster has delete protection enabled")
+				resource := Terraform.Resource("rhcs_cluster_rosa_classic", "my_cluster")
+				Expect(resource).To(MatchJQ(`.attributes.delete_protection`, false))
+
+				TestServer.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+						RespondWithPatchedJSON(http.StatusOK, template, stsPatch),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodDelete, "/api/clusters_mgmt/v1/clusters/123"),
+						RespondWithJSON(http.StatusOK, templateReadyState),
+					),
+				)
+				Expect(Terraform.Destroy().ExitCode).To(BeZero())
+			})
+		})
+
+		Context("Deletion policy", func() {
+			It("Keeps the cluster in OCM when it is retained", func() {
+				TestServer.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions"),
+						RespondWithJSON(http.StatusOK, versionListPage1),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
+						VerifyJQ(`.name`, "my-cluster"),
+						RespondWithPatchedJSON(http.StatusCreated, template, stsPatch),
+					),
+				)
+				Terraform.Source(`
+				  resource "rhcs_cluster_rosa_classic" "my_cluster" {
+					name           = "my-cluster"
+					cloud_region   = "us-west-1"
+					aws_account_id = "123456789012"
+					deletion_policy = "retain"
+					sts = {
+						operator_role_prefix = "test"
+						role_arn = "",
+						support_role_arn = "",
+						instance_iam_roles = {
+							master_role_arn = "",
+							worker_role_arn = "",
+						}
+					}
+				  }
+				`)
+				Expect(Terraform.Apply().ExitCode).To(BeZero())
+
+				// The cluster is only read, there is no delete request and no wait:
+				TestServer.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+						RespondWithPatchedJSON(http.StatusOK, template, stsPatch),
+					),
+				)
+				Expect(Terraform.Destroy().ExitCode).To(BeZero())
+				Expect(Terraform.State()).To(MatchJQ(`.resources | length`, 0))
+			})
+		})
+
+		Context("State upgrade", func() {
+			It("Keeps the attributes saved by old versions", func() {
+				// Save the state as it was written by a version that didn't have a schema
+				// version:
+				Terraform.ResourceState("rhcs_cluster_rosa_classic", "my_cluster", 0, `{
+				  "id": "123",
+				  "external_id": "123",
+				  "name": "my-cluster",
+				  "cloud_region": "us-west-1",
+				  "aws_account_id": "123456789012",
+				  "availability_zones": ["us-west-1a"],
+				  "aws_private_link": true,
+				  "private": true,
+				  "multi_az": true,
+				  "ccs_enabled": false,
+				  "etcd_encryption": false,
+				  "channel_group": "stable",
+				  "current_version": "openshift-4.8.0",
+				  "api_url": "https://my-api.example.com",
+				  "console_url": "https://my-console.example.com",
+				  "domain": "mydomainprefix.mycluster-api.example.com",
+				  "domain_prefix": "mydomainprefix",
+				  "base_dns_domain": "mycluster-api.example.com",
+				  "infra_id": "my-cluster-123",
+				  "ec2_metadata_http_tokens": "optional",
+				  "host_prefix": 23,
+				  "machine_cidr": "10.0.0.0/16",
+				  "pod_cidr": "10.128.0.0/14",
+				  "service_cidr": "172.30.0.0/16",
+				  "delete_protection": false,
+				  "disable_waiting_in_destroy": true,
+				  "state": "ready",
+				  "properties": {
+				    "rosa_creator_arn:": "arn:aws:iam::123456789012:user/dummy"
+				  },
+				  "ocm_properties": {
+				    "rosa_creator_arn:": "arn:aws:iam::123456789012:user/dummy",
+				    "rosa_tf_commit": "",
+				    "rosa_tf_version": ""
+				  },
+				  "sts": {
+				    "instance_iam_roles": {
+				      "master_role_arn": "",
+				      "worker_role_arn": ""
+				    },
+				    "oidc_endpoint_url": "127.0.0.1",
+				    "operator_role_prefix": "test",
+				    "role_arn": "",
+				    "support_role_arn": "",
+				    "thumbprint": ""
+				  }
+				}`, "admin_credentials_password_wo")
+				Terraform.Source(`
+				  resource "rhcs_cluster_rosa_classic" "my_cluster" {
+					name           = "my-cluster"
+					cloud_region   = "us-west-1"
+					aws_account_id = "123456789012"
+					delete_protection = false
+					disable_waiting_in_destroy = true
+					sts = {
+						operator_role_prefix = "test"
+						role_arn = "",
+						support_role_arn = "",
+						instance_iam_roles = {
+							master_role_arn = "",
+							worker_role_arn = "",
+						}
+					}
+				  }
+				`)
+
+				// The plan is computed from the upgraded state without reading the cluster, so
+				// any attribute lost by the upgrade would appear as a change:
+				runOutput := Terraform.UpgradeState()
+				Expect(runOutput.ExitCode).To(BeZero())
+				runOutput.VerifyOutputContainsSubstring("No changes.")
+				resource := Terraform.Resource("rhcs_cluster_rosa_classic", "my_cluster")
+				Expect(resource).To(MatchJQ(".schema_version", 1.0))
+				Expect(resource).To(MatchJQ(".attributes.id", "123"))
+				Expect(resource).To(MatchJQ(".attributes.sts.operator_role_prefix", "test"))
+				Expect(resource).To(MatchJQ(".attributes.host_prefix", 23.0))
+			})
+		})
+
 		It("Disable workload monitor and update it", func() {
 			// Prepare the server:
 			TestServer.AppendHandlers(
@@ -2770,7 +3120,7 @@ var _ = Describe("rhcs_cluster_rosa_classic - create", func() {
 					  "path": "/aws",
 					  "value": {
 						  "private_link": false,
-						  "subnet_ids": ["id1", "id2", "id3"],
+						  "subnet_ids": ["id1", "id2"],
 						  "ec2_metadata_http_tokens": "optional",
 						  "sts" : {
 							  "oidc_endpoint_url": "https://127.0.0.1",
@@ -2823,7 +3173,7 @@ var _ = Describe("rhcs_cluster_rosa_classic - create", func() {
 			aws_private_link = false
 			private = true
 			aws_subnet_ids = [
-				"id1", "id2", "id3"
+				"id1", "id2"
 			]
 			sts = {
 				operator_role_prefix = "test"
@@ -2862,7 +3212,7 @@ var _ = Describe("rhcs_cluster_rosa_classic - create", func() {
 					  "path": "/aws",
 					  "value": {
 						  "private_link": true,
-						  "subnet_ids": ["id1", "id2", "id3"],
+						  "subnet_ids": ["id1"],
 						  "ec2_metadata_http_tokens": "optional",
 						  "sts" : {
 							  "oidc_endpoint_url": "https://127.0.0.1",
@@ -2910,7 +3260,7 @@ var _ = Describe("rhcs_cluster_rosa_classic - create", func() {
 			private = true
 			aws_private_link = true
 			aws_subnet_ids = [
-				"id1", "id2", "id3"
+				"id1"
 			]
 			sts = {
 				operator_role_prefix = "test"
@@ -3010,7 +3360,7 @@ var _ = Describe("rhcs_cluster_rosa_classic - create", func() {
 					  "op": "add",
 					  "path": "/aws",
 					  "value": {
-						  "subnet_ids": ["id1", "id2", "id3"],
+						  "subnet_ids": ["id1", "id2"],
 						  "ec2_metadata_http_tokens": "optional",
                           "private_hosted_zone_id": "1234",
                           "private_hosted_zone_role_arn": "arn:aws:iam::111111111111:role/test-shared-vpc",
@@ -3059,7 +3409,7 @@ var _ = Describe("rhcs_cluster_rosa_classic - create", func() {
 			aws_account_id = "123456789012"
 			availability_zones = ["us-west-1a"]
 			aws_subnet_ids = [
-				"id1", "id2", "id3"
+				"id1", "id2"
 			]
 			sts = {
 				operator_role_prefix = "test"
@@ -3501,7 +3851,7 @@ var _ = Describe("rhcs_cluster_rosa_classic - create", func() {
 					  "path": "/aws",
 					  "value": {
 						  "private_link": false,
-						  "subnet_ids": ["id1", "id2", "id3"],
+						  "subnet_ids": ["id1", "id2"],
 						  "additional_compute_security_group_ids": ["id1"],
 						  "additional_infra_security_group_ids": ["id2"],
 						  "additional_control_plane_security_group_ids": ["id3"],
@@ -3557,7 +3907,7 @@ var _ = Describe("rhcs_cluster_rosa_classic - create", func() {
 			aws_private_link = false
 			private = true
 			aws_subnet_ids = [
-				"id1", "id2", "id3"
+				"id1", "id2"
 			]
 			aws_additional_compute_security_group_ids = [
 				"id1"
@@ -76,16 +76,7 @@ var _ = Describe("rhcs_cluster_rosa_classic - import", func() {
 		}
 	}`
 	Context("rhcs_cluster_rosa_classic - import", func() {
-		It("can import a cluster", func() {
-			// Prepare the server:
-			TestServer.AppendHandlers(
-				// CombineHandlers(
-				// 	VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions"),
-				// 	RespondWithJSON(http.StatusOK, versionListPage1),
-				// ),
-				CombineHandlers(
-					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
-					RespondWithPatchedJSON(http.StatusOK, template, `[
+		const importPatch = `[
 						{
 						  "op": "add",
 						  "path": "/aws",
@@ -117,7 +108,20 @@ var _ = Describe("rhcs_cluster_rosa_classic - import", func() {
 								"id": "r5.xlarge"
 							}
 						  }
-						}]`),
+						}]`
+
+		It("can import a cluster", func() {
+			// Prepare the server:
+			TestServer.AppendHandlers(
+				// Get is for resolving the import identifier
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+					RespondWithPatchedJSON(http.StatusOK, template, importPatch),
+				),
+				// Get is for the Read function
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+					RespondWithPatchedJSON(http.StatusOK, template, importPatch),
 				),
 			)
 
@@ -131,5 +135,112 @@ var _ = Describe("rhcs_cluster_rosa_classic - import", func() {
 			Expect(resource).To(MatchJQ(".attributes.current_version", "4.10.0"))
 		})
 
+		It("can import a cluster by name", func() {
+			// Prepare the server:
+			TestServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/my-cluster"),
+					RespondWithJSON(http.StatusNotFound, "{}"),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters"),
+					VerifyFormKV("search", "name = 'my-cluster' or external_id = 'my-cluster'"),
+					RespondWithJSON(http.StatusOK, `{
+						"kind": "ClusterList",
+						"page": 1,
+						"size": 1,
+						"total": 1,
+						"items": [
+							{
+								"kind": "Cluster",
+								"id": "123",
+								"name": "my-cluster"
+							}
+						]
+					}`),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+					RespondWithPatchedJSON(http.StatusOK, template, importPatch),
+				),
+			)
+
+			// Run the import command:
+			Terraform.Source(`
+			  resource "rhcs_cluster_rosa_classic" "my_cluster" { }
+			`)
+			runOutput := Terraform.Import("rhcs_cluster_rosa_classic.my_cluster", "my-cluster")
+			Expect(runOutput.ExitCode).To(BeZero())
+			resource := Terraform.Resource("rhcs_cluster_rosa_classic", "my_cluster")
+			Expect(resource).To(MatchJQ(".attributes.id", "123"))
+			Expect(resource).To(MatchJQ(".attributes.name", "my-cluster"))
+		})
+
+	})
+
+	Context("rhcs_cluster_rosa_classic - move from rhcs_cluster", func() {
+		const legacyState = `{
+		  "id": "123",
+		  "name": "my-cluster",
+		  "product": "{{ .Product }}",
+		  "cloud_provider": "aws",
+		  "cloud_region": "us-west-1",
+		  "aws_account_id": "123456789012",
+		  "aws_access_key_id": "my-key",
+		  "aws_secret_access_key": "my-secret",
+		  "ccs_enabled": true,
+		  "multi_az": true,
+		  "compute_nodes": 3,
+		  "compute_machine_type": "r5.xlarge",
+		  "version": "openshift-v4.10.0",
+		  "state": "ready",
+		  "wait": false,
+		  "deletion_policy": "retain"
+		}`
+
+		const config = `
+		  moved {
+		    from = rhcs_cluster.my_cluster
+		    to   = rhcs_cluster_rosa_classic.my_cluster
+		  }
+
+		  resource "rhcs_cluster_rosa_classic" "my_cluster" {
+		    name           = "my-cluster"
+		    cloud_region   = "us-west-1"
+		    aws_account_id = "123456789012"
+		  }
+		`
+
+		It("can move the state of a legacy cluster", func() {
+			TestServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+					RespondWithJSON(http.StatusOK, template),
+				),
+			)
+
+			Terraform.ResourceState("rhcs_cluster", "my_cluster", 0,
+				EvaluateTemplate(legacyState, "Product", "rosa"))
+			Terraform.Source(config)
+			runOutput := Terraform.Refresh()
+			Expect(runOutput.ExitCode).To(BeZero())
+			runOutput.VerifyErrorContainsSubstring("Attribute 'aws_access_key_id' isn't supported")
+			resource := Terraform.Resource("rhcs_cluster_rosa_classic", "my_cluster")
+			Expect(resource).To(MatchJQ(".attributes.id", "123"))
+			Expect(resource).To(MatchJQ(".attributes.version", "4.10.0"))
+			Expect(resource).To(MatchJQ(".attributes.current_version", "4.10.0"))
+			Expect(resource).To(MatchJQ(".attributes.disable_waiting_in_destroy", true))
+			Expect(resource).To(MatchJQ(".attributes.multi_az", true))
+			Expect(resource).To(MatchJQ(".attributes.deletion_policy", "retain"))
+		})
+
+		It("fails to move the state of a cluster that isn't ROSA", func() {
+			Terraform.ResourceState("rhcs_cluster", "my_cluster", 0,
+				EvaluateTemplate(legacyState, "Product", "osd"))
+			Terraform.Source(config)
+			runOutput := Terraform.Refresh()
+			Expect(runOutput.ExitCode).ToNot(BeZero())
+			runOutput.VerifyErrorContainsSubstring("only 'rosa' clusters can be moved")
+		})
 	})
 })
@@ -657,6 +657,105 @@ var _ = Describe("rhcs_cluster_rosa_classic - upgrade", func() {
 			Expect(runOutput.ExitCode).To(BeZero())
 		})
 
+		It("Keeps the automatic upgrade policy of the cluster & schedules new", func() {
+			TestServer.AppendHandlers(
+				// Refresh cluster state
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+					RespondWithJSON(http.StatusOK, template),
+				),
+				// Validate upgrade versions
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions/openshift-v4.10.0"),
+					RespondWithJSON(http.StatusOK, v4_10_0Info),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions/openshift-v4.10.1"),
+					RespondWithJSON(http.StatusOK, v4_10_1Info),
+				),
+				// Look for existing upgrade policies
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies"),
+					RespondWithJSON(http.StatusOK, `{
+					"kind": "UpgradePolicyState",
+					"page": 1,
+					"size": 0,
+					"total": 0,
+					"items": [
+						{
+							"kind": "UpgradePolicy",
+							"id": "456",
+							"href": "/api/clusters_mgmt/v1/clusters/123/upgrade_policies/123",
+							"schedule_type": "automatic",
+							"upgrade_type": "OSD",
+							"schedule": "0 2 * * 1",
+							"next_run": "2023-06-09T20:59:00Z",
+							"cluster_id": "123",
+							"enable_minor_version_upgrades": false
+						}
+					]
+				}`),
+				),
+				// The automatic policy doesn't have a version, and it isn't checked or deleted
+				// Look for gate agreements by posting an upgrade policy w/ dryRun (no gates necessary)
+				CombineHandlers(
+					VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies", "dryRun=true"),
+					VerifyJQ(".version", "4.10.1"),
+					RespondWithJSON(http.StatusNoContent, ""),
+				),
+				// Create an upgrade policy
+				CombineHandlers(
+					VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies"),
+					VerifyJQ(".version", "4.10.1"),
+					RespondWithJSON(http.StatusCreated, `
+				{
+					"kind": "UpgradePolicy",
+					"id": "123",
+					"href": "/api/clusters_mgmt/v1/clusters/123/upgrade_policies/123",
+					"schedule_type": "manual",
+					"upgrade_type": "OSD",
+					"version": "4.10.1",
+					"next_run": "2023-06-09T20:59:00Z",
+					"cluster_id": "123",
+					"enable_minor_version_upgrades": true
+				}`),
+				),
+				// Patch the cluster (w/ no changes)
+				CombineHandlers(
+					VerifyRequest(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/123"),
+					RespondWithPatchedJSON(http.StatusCreated, template, `[
+					{
+					  "op": "add",
+					  "path": "/properties",
+					  "value": {
+						"rosa_tf_commit": "123",
+						"rosa_tf_version": "123"
+					  }
+					}
+				]`),
+				),
+			)
+			// Perform try the upgrade
+			Terraform.Source(`
+		  resource "rhcs_cluster_rosa_classic" "my_cluster" {
+			name           = "my-cluster"
+			cloud_region   = "us-west-1"
+			aws_account_id = "123456789012"
+			sts = {
+				operator_role_prefix = "test"
+				role_arn = "",
+				support_role_arn = "",
+				instance_iam_roles = {
+					master_role_arn = "",
+					worker_role_arn = "",
+				}
+			}
+			version = "4.10.1"
+		}`)
+			runOutput := Terraform.Apply()
+			Expect(runOutput.ExitCode).To(BeZero())
+		})
+
 		It("Cancels upgrade if version=current_version", func() {
 			TestServer.AppendHandlers(
 				// Refresh cluster state
@@ -975,4 +1074,160 @@ var _ = Describe("rhcs_cluster_rosa_classic - upgrade", func() {
 			})
 		})
 	})
+
+	Context("rhcs_cluster_rosa_classic - wait for upgrade", func() {
+		const upgradedSource = `
+		  resource "rhcs_cluster_rosa_classic" "my_cluster" {
+			name           = "my-cluster"
+			cloud_region   = "us-west-1"
+			aws_account_id = "123456789012"
+			sts = {
+				operator_role_prefix = "test"
+				role_arn = ""
+				support_role_arn = ""
+				instance_iam_roles = {
+					master_role_arn = ""
+					worker_role_arn = ""
+				}
+			}
+			version = "4.10.1"
+			wait_for_upgrade_complete = true
+		}`
+		const upgradedPatch = `[
+			{
+			  "op": "replace",
+			  "path": "/version",
+			  "value": {
+				"id": "openshift-v4.10.1"
+			  }
+			},
+			{
+			  "op": "add",
+			  "path": "/properties",
+			  "value": {
+				"rosa_tf_commit": "123",
+				"rosa_tf_version": "123"
+			  }
+			}
+		]`
+
+		// prepareUpgradeSchedule prepares the requests that refresh the cluster and schedule the upgrade
+		prepareUpgradeSchedule := func() {
+			TestServer.AppendHandlers(
+				// Refresh cluster state
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+					RespondWithJSON(http.StatusOK, template),
+				),
+				// Validate upgrade versions
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions/openshift-v4.10.0"),
+					RespondWithJSON(http.StatusOK, v4_10_0Info),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions/openshift-v4.10.1"),
+					RespondWithJSON(http.StatusOK, v4_10_1Info),
+				),
+				// Look for existing upgrade policies
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies"),
+					RespondWithJSON(http.StatusOK, upgradePoliciesEmpty),
+				),
+				// Look for gate agreements by posting an upgrade policy w/ dryRun (no gates necessary)
+				CombineHandlers(
+					VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies", "dryRun=true"),
+					RespondWithJSON(http.StatusNoContent, ""),
+				),
+				// Create an upgrade policy
+				CombineHandlers(
+					VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies"),
+					VerifyJQ(".version", "4.10.1"),
+					RespondWithJSON(http.StatusCreated, `
+				{
+					"kind": "UpgradePolicy",
+					"id": "123",
+					"href": "/api/clusters_mgmt/v1/clusters/123/upgrade_policies/123",
+					"schedule_type": "manual",
+					"upgrade_type": "OSD",
+					"version": "4.10.1",
+					"next_run": "2023-06-09T20:59:00Z",
+					"cluster_id": "123",
+					"enable_minor_version_upgrades": true
+				}`),
+				),
+			)
+		}
+
+		It("Waits till the cluster has the new version", func() {
+			prepareUpgradeSchedule()
+			TestServer.AppendHandlers(
+				// Check the version of the cluster while waiting
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+					RespondWithPatchedJSON(http.StatusOK, template, upgradedPatch),
+				),
+				// Patch the cluster (w/ no changes)
+				CombineHandlers(
+					VerifyRequest(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/123"),
+					RespondWithPatchedJSON(http.StatusOK, template, upgradedPatch),
+				),
+			)
+			Terraform.Source(upgradedSource)
+			runOutput := Terraform.Apply()
+			Expect(runOutput.ExitCode).To(BeZero())
+			resource := Terraform.Resource("rhcs_cluster_rosa_classic", "my_cluster")
+			Expect(resource).To(MatchJQ(".attributes.version", "4.10.1"))
+			Expect(resource).To(MatchJQ(".attributes.current_version", "4.10.1"))
+		})
+
+		It("Fails with the description of the upgrade policy state if the upgrade fails", func() {
+			prepareUpgradeSchedule()
+			TestServer.AppendHandlers(
+				// Check the version of the cluster while waiting
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+					RespondWithJSON(http.StatusOK, template),
+				),
+				// Check the state of the upgrade policy
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies"),
+					RespondWithJSON(http.StatusOK, `{
+					"kind": "UpgradePolicyList",
+					"page": 1,
+					"size": 1,
+					"total": 1,
+					"items": [
+						{
+							"kind": "UpgradePolicy",
+							"id": "123",
+							"href": "/api/clusters_mgmt/v1/clusters/123/upgrade_policies/123",
+							"schedule_type": "manual",
+							"upgrade_type": "OSD",
+							"version": "4.10.1",
+							"next_run": "2023-06-09T20:59:00Z",
+							"cluster_id": "123"
+						}
+					]
+				}`),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies/123/state"),
+					RespondWithJSON(http.StatusOK, `{
+					"kind": "UpgradePolicyState",
+					"id": "123",
+					"href": "/api/clusters_mgmt/v1/clusters/123/upgrade_policies/123/state",
+					"description": "Upgrade failed: machine config pool is degraded",
+					"value": "failed"
+				}`),
+				),
+			)
+			Terraform.Source(upgradedSource)
+			runOutput := Terraform.Apply()
+			Expect(runOutput.ExitCode).ToNot(BeZero())
+			runOutput.VerifyErrorContainsSubstring("the upgrade to version 4.10.1 ended in state 'failed'")
+			runOutput.VerifyErrorContainsSubstring("Upgrade failed: machine config pool is degraded")
+			resource := Terraform.Resource("rhcs_cluster_rosa_classic", "my_cluster")
+			Expect(resource).To(MatchJQ(".attributes.version", "4.10.0"))
+		})
+	})
 })
@@ -319,4 +319,38 @@ var _ = Describe("Cluster creation", func() {
 		Expect(runOutput.ExitCode).ToNot(BeZero())
 		runOutput.VerifyErrorContainsSubstring("Cluster 'my-cluster' already exists")
 	})
+
+	It("Keeps the cluster in OCM when it is retained", func() {
+		// Prepare the server:
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
+				RespondWithJSON(http.StatusCreated, template),
+			),
+		)
+
+		// Run the apply command:
+		Terraform.Source(`
+		  resource "rhcs_cluster" "my_cluster" {
+		    name            = "my-cluster"
+			product		    = "osd"
+		    cloud_provider  = "aws"
+		    cloud_region    = "us-west-1"
+		    deletion_policy = "retain"
+		  }
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).To(BeZero())
+
+		// Destroy the cluster, it is only read and there is no delete request:
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, template),
+			),
+		)
+		runOutput = Terraform.Destroy()
+		Expect(runOutput.ExitCode).To(BeZero())
+		Expect(Terraform.State()).To(MatchJQ(`.resources | length`, 0))
+	})
 })
@@ -0,0 +1,136 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package classic
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"                      // nolint
+	. "github.com/onsi/gomega"                         // nolint
+	. "github.com/onsi/gomega/ghttp"                   // nolint
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+	. "github.com/terraform-redhat/terraform-provider-rhcs/subsystem/framework"
+)
+
+var _ = Describe("Cluster upgrade policy", func() {
+	const clusterReady = `{
+		"kind": "Cluster",
+		"id": "123",
+		"href": "/api/clusters_mgmt/v1/clusters/123",
+		"name": "cluster",
+		"state": "ready"
+	}`
+	const policy = `{
+		"kind": "UpgradePolicy",
+		"id": "456",
+		"href": "/api/clusters_mgmt/v1/clusters/123/upgrade_policies/456",
+		"schedule_type": "automatic",
+		"upgrade_type": "OSD",
+		"schedule": "0 2 * * 1",
+		"enable_minor_version_upgrades": true,
+		"next_run": "2026-10-19T02:00:00Z"
+	}`
+
+	It("creates an upgrade policy for a classic cluster", func() {
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, clusterReady),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies"),
+				RespondWithJSON(http.StatusOK, `{
+					"page": 1,
+					"size": 0,
+					"total": 0,
+					"items": []
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies"),
+				VerifyJQ(".schedule_type", "automatic"),
+				VerifyJQ(".upgrade_type", "OSD"),
+				VerifyJQ(".schedule", "0 2 * * 1"),
+				VerifyJQ(".enable_minor_version_upgrades", true),
+				RespondWithJSON(http.StatusCreated, policy),
+			),
+		)
+		Terraform.Source(`
+			resource "rhcs_cluster_upgrade_policy" "policy" {
+				cluster                       = "123"
+				schedule                      = "0 2 * * 1"
+				enable_minor_version_upgrades = true
+			}
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).To(BeZero())
+
+		resource := Terraform.Resource("rhcs_cluster_upgrade_policy", "policy")
+		Expect(resource).To(MatchJQ(".attributes.id", "456"))
+		Expect(resource).To(MatchJQ(".attributes.next_run", "2026-10-19T02:00:00Z"))
+	})
+
+	It("imports an upgrade policy using the cluster and policy identifiers", func() {
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, clusterReady),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies/456"),
+				RespondWithJSON(http.StatusOK, policy),
+			),
+		)
+		Terraform.Source(`
+			resource "rhcs_cluster_upgrade_policy" "policy" {
+			}
+		`)
+		runOutput := Terraform.Import("rhcs_cluster_upgrade_policy.policy", "123,456")
+		Expect(runOutput.ExitCode).To(BeZero())
+
+		resource := Terraform.Resource("rhcs_cluster_upgrade_policy", "policy")
+		Expect(resource).To(MatchJQ(".attributes.id", "456"))
+		Expect(resource).To(MatchJQ(".attributes.schedule", "0 2 * * 1"))
+		Expect(resource).To(MatchJQ(".attributes.enable_minor_version_upgrades", true))
+	})
+
+	It("fails to import a manual upgrade policy", func() {
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, clusterReady),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies/456"),
+				RespondWithPatchedJSON(http.StatusOK, policy, `[
+					{
+						"op": "replace",
+						"path": "/schedule_type",
+						"value": "manual"
+					}
+				]`),
+			),
+		)
+		Terraform.Source(`
+			resource "rhcs_cluster_upgrade_policy" "policy" {
+			}
+		`)
+		runOutput := Terraform.Import("rhcs_cluster_upgrade_policy.policy", "123,456")
+		Expect(runOutput.ExitCode).ToNot(BeZero())
+		runOutput.VerifyErrorContainsSubstring("only 'automatic' upgrade policies are supported")
+	})
+})
@@ -0,0 +1,216 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package classic
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"             // nolint
+	. "github.com/onsi/gomega"                         // nolint
+	. "github.com/onsi/gomega/ghttp"                   // nolint
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+	. "github.com/terraform-redhat/terraform-provider-rhcs/subsystem/framework"
+)
+
+var _ = Describe("Clusters data source", func() {
+	const clusterList = `{
+	  "kind": "ClusterList",
+	  "page": 1,
+	  "size": 2,
+	  "total": 2,
+	  "items": [
+	    {
+	      "kind": "Cluster",
+	      "id": "123",
+	      "external_id": "0a9c3b7e-5f4d-4c2b-9e1a-7d6f8b2c4e10",
+	      "name": "my-classic",
+	      "product": {
+	        "id": "rosa"
+	      },
+	      "state": "ready",
+	      "version": {
+	        "raw_id": "4.14.10"
+	      },
+	      "region": {
+	        "id": "us-east-1"
+	      },
+	      "aws": {
+	        "account_id": "123456789012"
+	      }
+	    },
+	    {
+	      "kind": "Cluster",
+	      "id": "456",
+	      "external_id": "5e2d1c0b-9a8f-4e7d-6c5b-4a3f2e1d0c9b",
+	      "name": "my-hcp",
+	      "product": {
+	        "id": "rosa"
+	      },
+	      "hypershift": {
+	        "enabled": true
+	      },
+	      "state": "installing",
+	      "version": {
+	        "raw_id": "4.15.2"
+	      },
+	      "region": {
+	        "id": "us-west-2"
+	      },
+	      "aws": {
+	        "account_id": "123456789012"
+	      }
+	    }
+	  ]
+	}`
+
+	It("Can list clusters", func() {
+		// Prepare the server:
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters"),
+				VerifyFormKV("page", "1"),
+				VerifyFormKV("size", "100"),
+				RespondWithJSON(http.StatusOK, clusterList),
+			),
+		)
+
+		// Run the apply command:
+		Terraform.Source(`
+		  data "rhcs_clusters" "my_clusters" {
+		  }
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).To(BeZero())
+
+		// Check the state:
+		resource := Terraform.Resource("rhcs_clusters", "my_clusters")
+		Expect(resource).To(MatchJQ(`.attributes.total`, 2.0))
+		Expect(resource).To(MatchJQ(`.attributes.items | length`, 2))
+		Expect(resource).To(MatchJQ(`.attributes.item`, nil))
+		Expect(resource).To(MatchJQ(`.attributes.items[0].id`, "123"))
+		Expect(resource).To(MatchJQ(`.attributes.items[0].external_id`, "0a9c3b7e-5f4d-4c2b-9e1a-7d6f8b2c4e10"))
+		Expect(resource).To(MatchJQ(`.attributes.items[0].name`, "my-classic"))
+		Expect(resource).To(MatchJQ(`.attributes.items[0].product`, "rosa"))
+		Expect(resource).To(MatchJQ(`.attributes.items[0].topology`, "classic"))
+		Expect(resource).To(MatchJQ(`.attributes.items[0].state`, "ready"))
+		Expect(resource).To(MatchJQ(`.attributes.items[0].version`, "4.14.10"))
+		Expect(resource).To(MatchJQ(`.attributes.items[0].cloud_region`, "us-east-1"))
+		Expect(resource).To(MatchJQ(`.attributes.items[0].aws_account_id`, "123456789012"))
+		Expect(resource).To(MatchJQ(`.attributes.items[1].id`, "456"))
+		Expect(resource).To(MatchJQ(`.attributes.items[1].topology`, "hcp"))
+		Expect(resource).To(MatchJQ(`.attributes.items[1].state`, "installing"))
+		Expect(resource).To(MatchJQ(`.attributes.items[1].version`, "4.15.2"))
+		Expect(resource).To(MatchJQ(`.attributes.items[1].cloud_region`, "us-west-2"))
+	})
+
+	It("Can search clusters and return a single page", func() {
+		// Prepare the server:
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters"),
+				VerifyFormKV("search", "aws.account_id = '123456789012'"),
+				VerifyFormKV("order", "name asc"),
+				VerifyFormKV("page", "3"),
+				VerifyFormKV("size", "2"),
+				RespondWithPatchedJSON(http.StatusOK, clusterList, `[
+				  {
+				    "op": "replace",
+				    "path": "/page",
+				    "value": 3
+				  },
+				  {
+				    "op": "replace",
+				    "path": "/total",
+				    "value": 7
+				  }
+				]`),
+			),
+		)
+
+		// Run the apply command:
+		Terraform.Source(`
+		  data "rhcs_clusters" "my_clusters" {
+		    search = "aws.account_id = '123456789012'"
+		    order  = "name asc"
+		    page   = 3
+		    size   = 2
+		  }
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).To(BeZero())
+
+		// Check the state:
+		resource := Terraform.Resource("rhcs_clusters", "my_clusters")
+		Expect(resource).To(MatchJQ(`.attributes.total`, 7.0))
+		Expect(resource).To(MatchJQ(`.attributes.items | length`, 2))
+		Expect(resource).To(MatchJQ(`.attributes.items[0].name`, "my-classic"))
+		Expect(resource).To(MatchJQ(`.attributes.items[1].name`, "my-hcp"))
+	})
+
+	It("Populates `item` if there is exactly one result", func() {
+		// Prepare the server:
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters"),
+				VerifyFormKV("search", "name = 'my-hcp'"),
+				RespondWithJSON(http.StatusOK, `{
+				  "kind": "ClusterList",
+				  "page": 1,
+				  "size": 1,
+				  "total": 1,
+				  "items": [
+				    {
+				      "kind": "Cluster",
+				      "id": "456",
+				      "name": "my-hcp",
+				      "hypershift": {
+				        "enabled": true
+				      }
+				    }
+				  ]
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		Terraform.Source(`
+		  data "rhcs_clusters" "my_clusters" {
+		    search = "name = 'my-hcp'"
+		  }
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).To(BeZero())
+
+		// Check the state:
+		resource := Terraform.Resource("rhcs_clusters", "my_clusters")
+		Expect(resource).To(MatchJQ(`.attributes.item.id`, "456"))
+		Expect(resource).To(MatchJQ(`.attributes.item.name`, "my-hcp"))
+		Expect(resource).To(MatchJQ(`.attributes.item.topology`, "hcp"))
+	})
+
+	It("Fails if the size isn't positive", func() {
+		// Run the apply command:
+		Terraform.Source(`
+		  data "rhcs_clusters" "my_clusters" {
+		    size = 0
+		  }
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).ToNot(BeZero())
+		runOutput.VerifyErrorContainsSubstring("Attribute size value must be at least 1")
+	})
+})
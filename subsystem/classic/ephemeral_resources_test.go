@@ -0,0 +1,235 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package classic
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"             // nolint
+	. "github.com/onsi/gomega"                         // nolint
+	. "github.com/onsi/gomega/ghttp"                   // nolint
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+	. "github.com/terraform-redhat/terraform-provider-rhcs/subsystem/framework"
+)
+
+var _ = Describe("Ephemeral resources", func() {
+	const currentAccount = `{
+	  "id": "my-account",
+	  "username": "my-user",
+	  "organization": {
+	    "id": "my-org"
+	  }
+	}`
+
+	const cluster = `{
+	  "id": "123",
+	  "name": "my-cluster",
+	  "state": "ready",
+	  "api": {
+	    "url": "https://my-api.example.com"
+	  }
+	}`
+
+	// recordAuthorization returns a handler that records the authorization headers of the
+	// requests that it receives.
+	recordAuthorization := func(headers *[]string) http.HandlerFunc {
+		lock := &sync.Mutex{}
+		return func(w http.ResponseWriter, r *http.Request) {
+			lock.Lock()
+			defer lock.Unlock()
+			*headers = append(*headers, r.Header.Get("Authorization"))
+		}
+	}
+
+	It("Can configure another provider with the access token", func() {
+		var headers []string
+		TestServer.RouteToHandler(
+			http.MethodGet, "/api/accounts_mgmt/v1/current_account",
+			CombineHandlers(
+				recordAuthorization(&headers),
+				RespondWithJSON(http.StatusOK, currentAccount),
+			),
+		)
+
+		Terraform.Source(EvaluateTemplate(`
+		  ephemeral "rhcs_access_token" "current" {
+		    min_validity = "1m"
+		  }
+
+		  provider "rhcs" {
+		    alias    = "other"
+		    url      = "{{ .URL }}"
+		    token    = ephemeral.rhcs_access_token.current.access_token
+		    insecure = true
+		  }
+
+		  data "rhcs_info" "default" {
+		  }
+
+		  data "rhcs_info" "other" {
+		    provider = rhcs.other
+		  }
+		`, "URL", TestServer.URL()))
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).To(BeZero())
+
+		// Both providers should have used the same token:
+		Expect(headers).To(HaveLen(2))
+		Expect(headers[0]).To(HavePrefix("Bearer "))
+		Expect(headers[1]).To(Equal(headers[0]))
+
+		// The token shouldn't be in the state:
+		state, err := json.Marshal(Terraform.State())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(state)).ToNot(ContainSubstring(headers[0][len("Bearer "):]))
+	})
+
+	It("Can't use an invalid minimum validity", func() {
+		Terraform.Source(`
+		  ephemeral "rhcs_access_token" "current" {
+		    min_validity = "junk"
+		  }
+
+		  provider "rhcs" {
+		    alias = "other"
+		    token = ephemeral.rhcs_access_token.current.access_token
+		  }
+
+		  data "rhcs_info" "other" {
+		    provider = rhcs.other
+		  }
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).ToNot(BeZero())
+		runOutput.VerifyErrorContainsSubstring("Value 'junk' isn't a valid duration")
+	})
+
+	It("Can configure another provider with the cluster admin credentials", func() {
+		// The kubeconfig points to the test server, so that the requests sent with the
+		// credentials can be checked:
+		adminToken := MakeTokenString("Bearer", 10*time.Minute)
+		kubeconfig := EvaluateTemplate(`
+		  apiVersion: v1
+		  kind: Config
+		  current-context: admin
+		  clusters:
+		  - name: my-cluster
+		    cluster:
+		      server: {{ .URL }}
+		      certificate-authority-data: {{ .CA }}
+		  contexts:
+		  - name: admin
+		    context:
+		      cluster: my-cluster
+		      user: admin
+		  users:
+		  - name: admin
+		    user:
+		      token: {{ .Token }}
+		`,
+			"URL", TestServer.URL(),
+			"CA", base64.StdEncoding.EncodeToString([]byte("my-ca")),
+			"Token", adminToken,
+		)
+		credentials, err := json.Marshal(map[string]string{
+			"kubeconfig": kubeconfig,
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		TestServer.RouteToHandler(
+			http.MethodGet, "/api/clusters_mgmt/v1/clusters/123",
+			RespondWithJSON(http.StatusOK, cluster),
+		)
+		TestServer.RouteToHandler(
+			http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/credentials",
+			RespondWithJSON(http.StatusOK, string(credentials)),
+		)
+		var headers []string
+		TestServer.RouteToHandler(
+			http.MethodGet, "/api/accounts_mgmt/v1/current_account",
+			CombineHandlers(
+				recordAuthorization(&headers),
+				RespondWithJSON(http.StatusOK, currentAccount),
+			),
+		)
+
+		Terraform.Source(`
+		  ephemeral "rhcs_cluster_admin_credentials" "admin" {
+		    cluster = "123"
+		  }
+
+		  provider "rhcs" {
+		    alias    = "admin"
+		    url      = ephemeral.rhcs_cluster_admin_credentials.admin.host
+		    token    = ephemeral.rhcs_cluster_admin_credentials.admin.token
+		    insecure = true
+		  }
+
+		  data "rhcs_info" "admin" {
+		    provider = rhcs.admin
+		  }
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).To(BeZero())
+
+		// The provider should have used the token from the kubeconfig:
+		Expect(headers).To(HaveLen(1))
+		Expect(headers[0]).To(Equal("Bearer " + adminToken))
+
+		// The credentials shouldn't be in the state:
+		state, err := json.Marshal(Terraform.State())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(state)).ToNot(ContainSubstring(adminToken))
+	})
+
+	It("Can't get the admin credentials of a cluster that isn't ready", func() {
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithPatchedJSON(http.StatusOK, cluster, `[
+				  {
+				    "op": "replace",
+				    "path": "/state",
+				    "value": "installing"
+				  }
+				]`),
+			),
+		)
+
+		Terraform.Source(`
+		  ephemeral "rhcs_cluster_admin_credentials" "admin" {
+		    cluster = "123"
+		  }
+
+		  provider "rhcs" {
+		    alias = "admin"
+		    token = ephemeral.rhcs_cluster_admin_credentials.admin.token
+		  }
+
+		  data "rhcs_info" "admin" {
+		    provider = rhcs.admin
+		  }
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).ToNot(BeZero())
+		runOutput.VerifyErrorContainsSubstring("Cluster '123' is in state 'installing'")
+	})
+})
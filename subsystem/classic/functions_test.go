@@ -0,0 +1,66 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package classic
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"             // nolint
+	. "github.com/onsi/gomega"                         // nolint
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+	. "github.com/terraform-redhat/terraform-provider-rhcs/subsystem/framework"
+)
+
+var _ = Describe("Provider functions", func() {
+	It("Computes the values in outputs", func() {
+		Terraform.Source(`
+		  output "role_arns" {
+		    value = provider::rhcs::operator_role_arns("my-prefix", "123456789012", "4.14.2", true)
+		  }
+
+		  output "overlap" {
+		    value = provider::rhcs::cidrs_overlap("10.0.0.0/16", "172.30.0.0/16", "10.0.0.0/14")
+		  }
+
+		  output "compare" {
+		    value = provider::rhcs::version_compare("4.14.10", "openshift-v4.14.2")
+		  }
+
+		  output "minor" {
+		    value = provider::rhcs::minor_version("openshift-v4.14.2")
+		  }
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).To(BeZero())
+		state := Terraform.State()
+		Expect(state).To(MatchJQ(`.outputs.role_arns.value | length`, 8))
+		Expect(state).To(MatchJQ(`.outputs.role_arns.value[0]`,
+			"arn:aws:iam::123456789012:role/my-prefix-kube-system-capa-controller-manager"))
+		Expect(state).To(MatchJQ(`.outputs.overlap.value`, true))
+		Expect(state).To(MatchJQ(`.outputs.compare.value`, 1.0))
+		Expect(state).To(MatchJQ(`.outputs.minor.value`, "4.14"))
+	})
+
+	It("Fails with an invalid version", func() {
+		Terraform.Source(`
+		  output "minor" {
+		    value = provider::rhcs::minor_version("junk")
+		  }
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).ToNot(BeZero())
+		runOutput.VerifyErrorContainsSubstring("Value 'junk' isn't a valid version")
+	})
+})
@@ -17,7 +17,12 @@ limitations under the License.
 package classic
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/terraform-redhat/terraform-provider-rhcs/build"
 
@@ -298,6 +303,60 @@ var _ = Describe("Identity provider creation", func() {
 			Expect(runOutput.ExitCode).To(BeZero())
 		})
 
+		It("Writes the requests to the audit log without the passwords", func() {
+			// Prepare the server:
+			TestServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(
+						http.MethodPost,
+						"/api/clusters_mgmt/v1/clusters/123/identity_providers",
+					),
+					RespondWithJSON(http.StatusOK, `{
+			    	  "id": "456",
+			    	  "name": "my-ip",
+                      "mapping_method": "claim",
+			    	  "htpasswd": {
+                        "users": {"items":[{"username": "my-user", "password": "`+htpasswdValidPass+`"}]}
+			    	  }
+			    	}`),
+				),
+			)
+
+			// Run the apply command:
+			auditLogPath := filepath.Join(GinkgoT().TempDir(), "audit.log")
+			Terraform.ProviderSettings(`audit_log_path = "` + filepath.ToSlash(auditLogPath) + `"`)
+			Terraform.Source(`
+	    	  resource "rhcs_identity_provider" "my_idp" {
+	    	    cluster = "123"
+	    	    name    = "my-ip"
+	    	    htpasswd = {
+                  users = [{
+	    	        username = "my-user"
+	    	        password = "` + htpasswdValidPass + `"
+                  }]
+	    	    }
+	    	  }
+	    	`)
+			runOutput := Terraform.Apply()
+			Expect(runOutput.ExitCode).To(BeZero())
+
+			// Check the audit log:
+			data, err := os.ReadFile(auditLogPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).ToNot(ContainSubstring(htpasswdValidPass))
+			Expect(string(data)).ToNot(ContainSubstring(hashedPass))
+			var record interface{}
+			for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+				Expect(json.Unmarshal([]byte(line), &record)).To(Succeed())
+			}
+			Expect(record).To(MatchJQ(".method", http.MethodPost))
+			Expect(record).To(MatchJQ(".path", "/api/clusters_mgmt/v1/clusters/123/identity_providers"))
+			Expect(record).To(MatchJQ(".status", 200.0))
+			Expect(record).To(MatchJQ(".resource", "rhcs_identity_provider"))
+			Expect(record).To(MatchJQ(".request_body.htpasswd.users.items[0].hashed_password", "REDACTED"))
+			Expect(record).To(MatchJQ(".response_body.htpasswd.users.items[0].password", "REDACTED"))
+		})
+
 		It("Reconcile an 'htpasswd' identity provider, when state exists but 404 from server", func() {
 			// Prepare the server:
 			TestServer.AppendHandlers(
@@ -746,6 +805,81 @@ var _ = Describe("Identity provider creation", func() {
 				runOutput := Terraform.Apply()
 				Expect(runOutput.ExitCode).To(BeZero())
 			})
+			It("Can retain the identity provider when it is removed", func() {
+				const ldapIDP = `{
+				  "id": "456",
+				  "name": "my-ip",
+				  "mapping_method": "claim",
+				  "ldap": {
+				    "ca": "my-ca",
+				    "insecure": false,
+				    "url": "ldap://my-server.com",
+				    "attributes": {
+				      "id": ["dn"],
+				      "email": ["mail"],
+				      "name": ["cn"],
+				      "preferred_username": ["uid"]
+				    }
+				  }
+				}`
+				source := func(deletionPolicy string) string {
+					return fmt.Sprintf(`
+					  resource "rhcs_identity_provider" "my_idp" {
+					    cluster         = "123"
+					    name            = "my-ip"
+					    deletion_policy = "%s"
+					    ldap = {
+					      insecure   = false
+					      ca         = "my-ca"
+					      url        = "ldap://my-server.com"
+					      attributes = {}
+					    }
+					  }
+					`, deletionPolicy)
+				}
+
+				// Create the identity provider:
+				TestServer.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(
+							http.MethodPost,
+							"/api/clusters_mgmt/v1/clusters/123/identity_providers",
+						),
+						RespondWithJSON(http.StatusOK, ldapIDP),
+					),
+				)
+				Terraform.Source(source("delete"))
+				Expect(Terraform.Apply().ExitCode).To(BeZero())
+
+				// Changing the deletion policy only reads the identity provider:
+				TestServer.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(
+							http.MethodGet,
+							"/api/clusters_mgmt/v1/clusters/123/identity_providers/456",
+						),
+						RespondWithJSON(http.StatusOK, ldapIDP),
+					),
+				)
+				Terraform.Source(source("retain"))
+				Expect(Terraform.Apply().ExitCode).To(BeZero())
+				resource := Terraform.Resource("rhcs_identity_provider", "my_idp")
+				Expect(resource).To(MatchJQ(`.attributes.deletion_policy`, "retain"))
+
+				// Removing the identity provider doesn't delete it:
+				TestServer.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(
+							http.MethodGet,
+							"/api/clusters_mgmt/v1/clusters/123/identity_providers/456",
+						),
+						RespondWithJSON(http.StatusOK, ldapIDP),
+					),
+				)
+				Terraform.Source("")
+				Expect(Terraform.Apply().ExitCode).To(BeZero())
+				Expect(Terraform.State()).To(MatchJQ(`.resources | length`, 0))
+			})
 			It("Happy flow with bind values", func() {
 				// Prepare the server:
 				TestServer.AppendHandlers(
@@ -1535,6 +1669,18 @@ var _ = Describe("Identity provider import", func() {
 				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
 				RespondWithJSON(http.StatusNotFound, template),
 			),
+			// Search the cluster by name or external identifier:
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters"),
+				VerifyFormKV("search", "name = '123' or external_id = '123'"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "ClusterList",
+					"page": 1,
+					"size": 0,
+					"total": 0,
+					"items": []
+				}`),
+			),
 		)
 
 		Terraform.Source(`
@@ -1544,6 +1690,199 @@ var _ = Describe("Identity provider import", func() {
 		`)
 		runOutput := Terraform.Import("rhcs_identity_provider.my-ip", "123,notfound")
 		Expect(runOutput.ExitCode).NotTo(BeZero())
-		runOutput.VerifyErrorContainsSubstring("Cluster 123 not found, error: status is 404 and identifier is '123'")
+		runOutput.VerifyErrorContainsSubstring("cluster '123' not found")
+	})
+})
+
+var _ = Describe("Identity provider write-only secrets", func() {
+	const cluster = `{
+	  "id": "123",
+	  "name": "my-cluster",
+	  "state": "ready"
+	}`
+
+	const githubIDP = `{
+	  "id": "456",
+	  "name": "my-ip",
+	  "mapping_method": "claim",
+	  "github": {
+	    "client_id": "test-client",
+	    "organizations": ["my-org"]
+	  }
+	}`
+
+	BeforeEach(func() {
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, cluster),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, cluster),
+			),
+			CombineHandlers(
+				VerifyRequest(
+					http.MethodPost,
+					"/api/clusters_mgmt/v1/clusters/123/identity_providers",
+				),
+				VerifyJSON(`{
+				  "kind": "IdentityProvider",
+				  "type": "GithubIdentityProvider",
+				  "mapping_method": "claim",
+				  "name": "my-ip",
+				  "github": {
+				    "client_id": "test-client",
+				    "client_secret": "test-secret",
+				    "organizations": ["my-org"]
+				  }
+				}`),
+				RespondWithJSON(http.StatusOK, githubIDP),
+			),
+		)
+
+		Terraform.Source(`
+		  resource "rhcs_identity_provider" "my_idp" {
+		    cluster = "123"
+		    name    = "my-ip"
+		    github = {
+		      client_id                = "test-client"
+		      client_secret_wo         = "test-secret"
+		      client_secret_wo_version = 1
+		      organizations            = ["my-org"]
+		    }
+		  }
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).To(BeZero())
+	})
+
+	It("Doesn't save the secret in the state", func() {
+		resource := Terraform.Resource("rhcs_identity_provider", "my_idp")
+		Expect(resource).To(MatchJQ(".attributes.github.client_secret", nil))
+		Expect(resource).To(MatchJQ(".attributes.github.client_secret_wo", nil))
+		Expect(resource).To(MatchJQ(".attributes.github.client_secret_wo_version", 1.0))
+	})
+
+	It("Updates the secret when the version changes", func() {
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(
+					http.MethodGet,
+					"/api/clusters_mgmt/v1/clusters/123/identity_providers/456",
+				),
+				RespondWithJSON(http.StatusOK, githubIDP),
+			),
+			CombineHandlers(
+				VerifyRequest(
+					http.MethodPatch,
+					"/api/clusters_mgmt/v1/clusters/123/identity_providers/456",
+				),
+				VerifyJSON(`{
+				  "kind": "IdentityProvider",
+				  "type": "GithubIdentityProvider",
+				  "mapping_method": "claim",
+				  "name": "my-ip",
+				  "github": {
+				    "client_id": "test-client",
+				    "client_secret": "new-secret",
+				    "organizations": ["my-org"]
+				  }
+				}`),
+				RespondWithJSON(http.StatusOK, githubIDP),
+			),
+		)
+
+		Terraform.Source(`
+		  resource "rhcs_identity_provider" "my_idp" {
+		    cluster = "123"
+		    name    = "my-ip"
+		    github = {
+		      client_id                = "test-client"
+		      client_secret_wo         = "new-secret"
+		      client_secret_wo_version = 2
+		      organizations            = ["my-org"]
+		    }
+		  }
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).To(BeZero())
+		resource := Terraform.Resource("rhcs_identity_provider", "my_idp")
+		Expect(resource).To(MatchJQ(".attributes.github.client_secret", nil))
+		Expect(resource).To(MatchJQ(".attributes.github.client_secret_wo_version", 2.0))
+	})
+
+	It("Rejects other changes sent together with the new secret", func() {
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(
+					http.MethodGet,
+					"/api/clusters_mgmt/v1/clusters/123/identity_providers/456",
+				),
+				RespondWithJSON(http.StatusOK, githubIDP),
+			),
+		)
+
+		Terraform.Source(`
+		  resource "rhcs_identity_provider" "my_idp" {
+		    cluster = "123"
+		    name    = "my-ip"
+		    github = {
+		      client_id                = "test-client"
+		      client_secret_wo         = "new-secret"
+		      client_secret_wo_version = 2
+		      organizations            = ["my-org", "your-org"]
+		    }
+		  }
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).ToNot(BeZero())
+		runOutput.VerifyErrorContainsSubstring("github.organizations")
+		resource := Terraform.Resource("rhcs_identity_provider", "my_idp")
+		Expect(resource).To(MatchJQ(".attributes.github.client_secret_wo_version", 1.0))
+	})
+
+	It("Ignores changes of the secret when the version doesn't change", func() {
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(
+					http.MethodGet,
+					"/api/clusters_mgmt/v1/clusters/123/identity_providers/456",
+				),
+				RespondWithJSON(http.StatusOK, githubIDP),
+			),
+		)
+
+		Terraform.Source(`
+		  resource "rhcs_identity_provider" "my_idp" {
+		    cluster = "123"
+		    name    = "my-ip"
+		    github = {
+		      client_id                = "test-client"
+		      client_secret_wo         = "new-secret"
+		      client_secret_wo_version = 1
+		      organizations            = ["my-org"]
+		    }
+		  }
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).To(BeZero())
+	})
+
+	It("Fails if both the secret and the write-only secret are set", func() {
+		Terraform.Source(`
+		  resource "rhcs_identity_provider" "my_idp" {
+		    cluster = "123"
+		    name    = "my-ip"
+		    github = {
+		      client_id        = "test-client"
+		      client_secret    = "test-secret"
+		      client_secret_wo = "test-secret"
+		      organizations    = ["my-org"]
+		    }
+		  }
+		`)
+		runOutput := Terraform.Validate()
+		Expect(runOutput.ExitCode).ToNot(BeZero())
 	})
 })
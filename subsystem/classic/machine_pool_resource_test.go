@@ -220,6 +220,23 @@ var _ = Describe("Classic Machine Pool", func() {
 			Expect(resource).To(MatchJQ(`.attributes.labels | length`, 2))
 		})
 
+		It("Can't create machine pool in read-only mode", func() {
+			// The cluster is read, but the request to create the pool should never be sent:
+			Terraform.ProviderSettings(`read_only = true`)
+			Terraform.Source(`
+			  resource "rhcs_machine_pool" "my_pool" {
+				cluster      = "123"
+				name         = "my-pool"
+				machine_type = "r5.xlarge"
+				replicas     = 12
+			  }
+			`)
+			runOutput := Terraform.Apply()
+			Expect(runOutput.ExitCode).ToNot(BeZero())
+			runOutput.VerifyErrorContainsSubstring("read-only mode")
+			runOutput.VerifyErrorContainsSubstring("create operation of resource")
+		})
+
 		It("Can create machine pool with compute nodes when 404 (not found)", func() {
 			// Prepare the server:
 			TestServer.AppendHandlers(
@@ -2503,6 +2520,8 @@ var _ = Describe("Classic Machine Pool", func() {
 			)
 		}
 		It("Can import a machine pool", func() {
+			// The first read is for resolving the cluster of the import identifier
+			prepareClusterRead("123")
 			prepareClusterRead("123")
 			// Prepare the server:
 			TestServer.AppendHandlers(
@@ -2792,8 +2811,46 @@ var _ = Describe("Classic Machine Pool", func() {
 		})
 	})
 
+	Context("State upgrade", func() {
+		It("Keeps the attributes saved by old versions", func() {
+			// Save the state as it was written by a version that didn't have a schema version:
+			Terraform.ResourceState("rhcs_machine_pool", "my_pool", 0, `{
+			  "id": "my-pool",
+			  "cluster": "123",
+			  "name": "my-pool",
+			  "machine_type": "r5.xlarge",
+			  "replicas": 3,
+			  "availability_zone": "",
+			  "availability_zones": ["us-east-1a", "us-east-1b", "us-east-1c"],
+			  "multi_availability_zone": true,
+			  "subnet_id": "",
+			  "subnet_ids": [],
+			  "ignore_deletion_error": false
+			}`)
+			Terraform.Source(`
+			  resource "rhcs_machine_pool" "my_pool" {
+			    cluster      = "123"
+			    name         = "my-pool"
+			    machine_type = "r5.xlarge"
+			    replicas     = 3
+			  }
+			`)
+
+			// The plan is computed from the upgraded state without reading the pool, so any
+			// attribute lost by the upgrade would appear as a change:
+			runOutput := Terraform.UpgradeState()
+			Expect(runOutput.ExitCode).To(BeZero())
+			runOutput.VerifyOutputContainsSubstring("No changes.")
+			resource := Terraform.Resource("rhcs_machine_pool", "my_pool")
+			Expect(resource).To(MatchJQ(".schema_version", 1.0))
+			Expect(resource).To(MatchJQ(".attributes.replicas", 3.0))
+			Expect(resource).To(MatchJQ(".attributes.availability_zones | length", 3))
+		})
+	})
+
 	Context("Machine pool delete", func() {
 		clusterId := "123"
+		deletionPolicy := ""
 
 		prepareClusterRead := func(clusterId string) {
 			TestServer.AppendHandlers(
@@ -2865,10 +2922,12 @@ var _ = Describe("Classic Machine Pool", func() {
 			  name         = "{{.PoolId}}"
 			  machine_type = "r5.xlarge"
 			  replicas     = 3
+			  {{if .DeletionPolicy}}deletion_policy = "{{.DeletionPolicy}}"{{end}}
 			}
 		  `,
 				"PoolId", poolId,
-				"ClusterId", clusterId))
+				"ClusterId", clusterId,
+				"DeletionPolicy", deletionPolicy))
 
 			// Run the apply command:
 			runOutput := Terraform.Apply()
@@ -2880,6 +2939,10 @@ var _ = Describe("Classic Machine Pool", func() {
 		}
 
 		BeforeEach(func() {
+			deletionPolicy = ""
+		})
+
+		JustBeforeEach(func() {
 			createPool(clusterId, "pool1")
 		})
 
@@ -3000,5 +3063,22 @@ var _ = Describe("Classic Machine Pool", func() {
 			runOutput := Terraform.Apply()
 			Expect(runOutput.ExitCode).To(BeZero())
 		})
+
+		Context("Retained machine pool", func() {
+			BeforeEach(func() {
+				deletionPolicy = "retain"
+			})
+
+			It("doesn't delete the machine pool", func() {
+				// Prepare for refresh (Read) of the pools prior to changes, there is no delete request:
+				preparePoolRead(clusterId, "pool1")
+
+				// Re-apply w/ empty source so that pool1 is removed from the state
+				Terraform.Source("")
+				runOutput := Terraform.Apply()
+				Expect(runOutput.ExitCode).To(BeZero())
+				Expect(Terraform.State()).To(MatchJQ(`.resources | length`, 0))
+			})
+		})
 	})
 })
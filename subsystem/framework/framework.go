@@ -57,11 +57,22 @@ func (ro *RunOutput) VerifyErrorContainsSubstring(sub string) {
 	Expect(ro.err).To(ContainSubstring(sub))
 }
 
+func (ro *RunOutput) VerifyOutputContainsSubstring(sub string) {
+	Expect(ro.out).To(ContainSubstring(sub))
+}
+
+func (ro *RunOutput) VerifyOutputDoesNotContainSubstring(sub string) {
+	Expect(ro.out).ToNot(ContainSubstring(sub))
+}
+
 // TerraformRunner contains the data and logic needed to run Terraform.
 type TerraformRunner struct {
 	binary string
 	dir    string
 	env    []string
+	url    string
+	token  string
+	ca     string
 }
 
 // NewTerraformRunner creates a new Terraform runner.
@@ -106,28 +117,7 @@ func (b *TerraformRunnerBuilder) Build() *TerraformRunner {
 	ExpectWithOffset(1, err).ToNot(HaveOccurred())
 
 	// Create the main file:
-	mainPath := filepath.Join(tmpDir, "main.tf")
-	mainContent := EvaluateTemplate(`
-		terraform {
-		  required_providers {
-		    rhcs = {
-                source = "terraform.local/local/rhcs"
-                version = ">= 0.0.1"
-		    }
-		  }
-		}
-
-		provider "rhcs" {
-		  url         = "{{ .URL }}"
-		  token       = "{{ .Token }}"
-		  trusted_cas = file("{{ .CA }}")
-		}
-		`,
-		"URL", b.url,
-		"Token", b.token,
-		"CA", strings.ReplaceAll(b.ca, "\\", "/"),
-	)
-	err = ioutil.WriteFile(mainPath, []byte(mainContent), 0600)
+	err = writeMainFile(tmpDir, b.url, b.token, b.ca, "")
 	ExpectWithOffset(1, err).ToNot(HaveOccurred())
 
 	// Parse the current environment into a map so that it is easy to update it:
@@ -178,9 +168,47 @@ func (b *TerraformRunnerBuilder) Build() *TerraformRunner {
 		binary: tfBinary,
 		dir:    tmpDir,
 		env:    envList,
+		url:    b.url,
+		token:  b.token,
+		ca:     b.ca,
 	}
 }
 
+// writeMainFile writes the file that contains the configuration of the provider, including the
+// given additional settings.
+func writeMainFile(dir, url, token, ca, settings string) error {
+	mainPath := filepath.Join(dir, "main.tf")
+	mainContent := EvaluateTemplate(`
+		terraform {
+		  required_providers {
+		    rhcs = {
+                source = "terraform.local/local/rhcs"
+                version = ">= 0.0.1"
+		    }
+		  }
+		}
+
+		provider "rhcs" {
+		  url         = "{{ .URL }}"
+		  token       = "{{ .Token }}"
+		  trusted_cas = file("{{ .CA }}")
+		  {{ .Settings }}
+		}
+		`,
+		"URL", url,
+		"Token", token,
+		"CA", strings.ReplaceAll(ca, "\\", "/"),
+		"Settings", settings,
+	)
+	return ioutil.WriteFile(mainPath, []byte(mainContent), 0600)
+}
+
+// ProviderSettings adds the given settings to the configuration of the provider.
+func (r *TerraformRunner) ProviderSettings(text string) {
+	err := writeMainFile(r.dir, r.url, r.token, r.ca, text)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+}
+
 // Source sets the Terraform source of the test.
 func (r *TerraformRunner) Source(text string) {
 	file := filepath.Join(r.dir, "test.tf")
@@ -242,6 +270,65 @@ func (r *TerraformRunner) Import(args ...string) RunOutput {
 	return r.Run(append([]string{"import"}, args...)...)
 }
 
+// Refresh runs the `apply -refresh-only` command, which upgrades the saved state and reads the
+// resources without changing them.
+func (r *TerraformRunner) Refresh() RunOutput {
+	return r.Run("apply", "-refresh-only", "-auto-approve")
+}
+
+// UpgradeState runs the `apply -refresh=false` command, which upgrades the saved state and plans
+// the configuration against it without reading the resources. Attributes that the upgrade fails
+// to preserve appear as changes in the output of the command.
+func (r *TerraformRunner) UpgradeState() RunOutput {
+	return r.Run("apply", "-refresh=false", "-auto-approve")
+}
+
+// ResourceState writes a state file that contains only the given resource, saved with the given
+// schema version and attributes. The optional sensitive names are the top level attributes that
+// Terraform marks as sensitive in the state. This is intended to check how the provider upgrades
+// the state saved by previous versions.
+func (r *TerraformRunner) ResourceState(typ, name string, schemaVersion int, attributes string,
+	sensitive ...string) {
+	var attributesValue interface{}
+	err := json.Unmarshal([]byte(attributes), &attributesValue)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	sensitiveValue := []interface{}{}
+	for _, attribute := range sensitive {
+		sensitiveValue = append(sensitiveValue, []interface{}{
+			map[string]interface{}{
+				"type":  "get_attr",
+				"value": attribute,
+			},
+		})
+	}
+	state := map[string]interface{}{
+		"version":           4,
+		"terraform_version": "1.5.0",
+		"serial":            1,
+		"lineage":           "00000000-0000-0000-0000-000000000000",
+		"outputs":           map[string]interface{}{},
+		"resources": []interface{}{
+			map[string]interface{}{
+				"mode":     "managed",
+				"type":     typ,
+				"name":     name,
+				"provider": `provider["terraform.local/local/rhcs"]`,
+				"instances": []interface{}{
+					map[string]interface{}{
+						"schema_version":       schemaVersion,
+						"attributes":           attributesValue,
+						"sensitive_attributes": sensitiveValue,
+					},
+				},
+			},
+		},
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	err = ioutil.WriteFile(filepath.Join(r.dir, "terraform.tfstate"), data, 0600)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+}
+
 // State returns the reads the Terraform state and returns the result of parsing
 // it as a JSON document.
 func (r *TerraformRunner) State() interface{} {
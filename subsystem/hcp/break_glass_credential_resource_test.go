@@ -0,0 +1,304 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hcp
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"                      // nolint
+	. "github.com/onsi/gomega"                         // nolint
+	. "github.com/onsi/gomega/ghttp"                   // nolint
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+	. "github.com/terraform-redhat/terraform-provider-rhcs/subsystem/framework"
+)
+
+var _ = Describe("Break glass credentials", func() {
+	const clusterReady = `{
+		"kind": "Cluster",
+		"id": "123",
+		"href": "/api/clusters_mgmt/v1/clusters/123",
+		"name": "cluster",
+		"state": "ready",
+		"hypershift": {
+			"enabled": true
+		},
+		"external_auth_config": {
+			"enabled": true
+		}
+	}`
+	const credentialCreated = `{
+		"kind": "BreakGlassCredential",
+		"id": "456",
+		"href": "/api/clusters_mgmt/v1/clusters/123/break_glass_credentials/456",
+		"username": "emergency",
+		"status": "created",
+		"expiration_timestamp": "2026-10-18T14:00:00Z"
+	}`
+	const credentialIssued = `{
+		"kind": "BreakGlassCredential",
+		"id": "456",
+		"href": "/api/clusters_mgmt/v1/clusters/123/break_glass_credentials/456",
+		"username": "emergency",
+		"status": "issued",
+		"expiration_timestamp": "2026-10-18T14:00:00Z",
+		"kubeconfig": "apiVersion: v1\nkind: Config\n"
+	}`
+
+	createCredential := func() {
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, clusterReady),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters/123/break_glass_credentials"),
+				VerifyJQ(".username", "emergency"),
+				VerifyJQ(`.expiration_timestamp | length > 0`, true),
+				RespondWithJSON(http.StatusCreated, credentialCreated),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/break_glass_credentials/456"),
+				RespondWithJSON(http.StatusOK, credentialIssued),
+			),
+		)
+		Terraform.Source(`
+			resource "rhcs_hcp_break_glass_credential" "emergency" {
+				cluster    = "123"
+				username   = "emergency"
+				expiration = "2h"
+			}
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).To(BeZero())
+	}
+
+	It("fails if the expiration is too long", func() {
+		Terraform.Source(`
+			resource "rhcs_hcp_break_glass_credential" "emergency" {
+				cluster    = "123"
+				expiration = "48h"
+			}
+		`)
+		runOutput := Terraform.Validate()
+		Expect(runOutput.ExitCode).ToNot(BeZero())
+		runOutput.VerifyErrorContainsSubstring("Expiration must be between 10m0s and 24h0m0s")
+	})
+
+	It("fails if the cluster doesn't have external authentication enabled", func() {
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithPatchedJSON(http.StatusOK, clusterReady, `[
+					{
+						"op": "remove",
+						"path": "/external_auth_config"
+					}
+				]`),
+			),
+		)
+		Terraform.Source(`
+			resource "rhcs_hcp_break_glass_credential" "emergency" {
+				cluster = "123"
+			}
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).ToNot(BeZero())
+		runOutput.VerifyErrorContainsSubstring("doesn't have external authentication providers enabled")
+	})
+
+	It("creates the credential and waits till it is issued", func() {
+		createCredential()
+
+		resource := Terraform.Resource("rhcs_hcp_break_glass_credential", "emergency")
+		Expect(resource).To(MatchJQ(".attributes.id", "456"))
+		Expect(resource).To(MatchJQ(".attributes.status", "issued"))
+		Expect(resource).To(MatchJQ(".attributes.expiration_timestamp", "2026-10-18T14:00:00Z"))
+		Expect(resource).To(MatchJQ(".attributes.kubeconfig", "apiVersion: v1\nkind: Config\n"))
+	})
+
+	It("removes the credential from the state when it has been revoked", func() {
+		createCredential()
+
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/break_glass_credentials/456"),
+				RespondWithPatchedJSON(http.StatusOK, credentialIssued, `[
+					{
+						"op": "replace",
+						"path": "/status",
+						"value": "revoked"
+					}
+				]`),
+			),
+		)
+		runOutput := Terraform.Refresh()
+		Expect(runOutput.ExitCode).To(BeZero())
+		Expect(Terraform.State()).To(MatchJQ(
+			`[.resources[] | select(.type == "rhcs_hcp_break_glass_credential")] | length`, 0,
+		))
+	})
+
+	It("keeps the credential in the state when it isn't issued", func() {
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, clusterReady),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters/123/break_glass_credentials"),
+				RespondWithJSON(http.StatusCreated, credentialCreated),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/break_glass_credentials/456"),
+				RespondWithPatchedJSON(http.StatusOK, credentialCreated, `[
+					{
+						"op": "replace",
+						"path": "/status",
+						"value": "failed"
+					}
+				]`),
+			),
+		)
+		Terraform.Source(`
+			resource "rhcs_hcp_break_glass_credential" "emergency" {
+				cluster    = "123"
+				username   = "emergency"
+				expiration = "2h"
+			}
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).ToNot(BeZero())
+		runOutput.VerifyErrorContainsSubstring("Break glass credential '456' for cluster '123' wasn't issued")
+
+		// The credential is tainted, so that the next apply revokes it:
+		resource := Terraform.Resource("rhcs_hcp_break_glass_credential", "emergency")
+		Expect(resource).To(MatchJQ(".attributes.id", "456"))
+		Expect(resource).To(MatchJQ(".status", "tainted"))
+	})
+
+	It("revokes the credentials when destroyed", func() {
+		createCredential()
+
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/break_glass_credentials/456"),
+				RespondWithJSON(http.StatusOK, credentialIssued),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/break_glass_credentials"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "BreakGlassCredentialList",
+					"page": 1,
+					"size": 1,
+					"total": 1,
+					"items": [`+credentialIssued+`]
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodDelete, "/api/clusters_mgmt/v1/clusters/123/break_glass_credentials"),
+				RespondWithJSON(http.StatusNoContent, "{}"),
+			),
+		)
+		runOutput := Terraform.Destroy()
+		Expect(runOutput.ExitCode).To(BeZero())
+		runOutput.VerifyOutputDoesNotContainSubstring("Other break glass credentials will be revoked")
+	})
+
+	It("warns when destroying the credential revokes other credentials", func() {
+		createCredential()
+
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/break_glass_credentials/456"),
+				RespondWithJSON(http.StatusOK, credentialIssued),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/break_glass_credentials"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "BreakGlassCredentialList",
+					"page": 1,
+					"size": 3,
+					"total": 3,
+					"items": [
+						`+credentialIssued+`,
+						{
+							"kind": "BreakGlassCredential",
+							"id": "789",
+							"username": "other",
+							"status": "issued",
+							"expiration_timestamp": "2026-10-18T14:00:00Z"
+						},
+						{
+							"kind": "BreakGlassCredential",
+							"id": "012",
+							"username": "old",
+							"status": "revoked",
+							"expiration_timestamp": "2026-10-17T14:00:00Z"
+						}
+					]
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodDelete, "/api/clusters_mgmt/v1/clusters/123/break_glass_credentials"),
+				RespondWithJSON(http.StatusNoContent, "{}"),
+			),
+		)
+		runOutput := Terraform.Destroy()
+		Expect(runOutput.ExitCode).To(BeZero())
+		runOutput.VerifyOutputContainsSubstring("Other break glass credentials will be revoked")
+		runOutput.VerifyOutputContainsSubstring("revoke credentials '789'")
+	})
+
+	It("lists the credentials of the cluster", func() {
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/break_glass_credentials"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "BreakGlassCredentialList",
+					"page": 1,
+					"size": 2,
+					"total": 2,
+					"items": [
+						`+credentialIssued+`,
+						{
+							"kind": "BreakGlassCredential",
+							"id": "789",
+							"username": "old",
+							"status": "revoked",
+							"expiration_timestamp": "2026-10-17T14:00:00Z",
+							"revocation_timestamp": "2026-10-17T12:00:00Z"
+						}
+					]
+				}`),
+			),
+		)
+		Terraform.Source(`
+			data "rhcs_hcp_break_glass_credentials" "all" {
+				cluster = "123"
+			}
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).To(BeZero())
+
+		dataSource := Terraform.Resource("rhcs_hcp_break_glass_credentials", "all")
+		Expect(dataSource).To(MatchJQ(".attributes.items | length", 2))
+		Expect(dataSource).To(MatchJQ(".attributes.items[0].status", "issued"))
+		Expect(dataSource).To(MatchJQ(".attributes.items[0].revocation_timestamp", nil))
+		Expect(dataSource).To(MatchJQ(".attributes.items[1].status", "revoked"))
+		Expect(dataSource).To(MatchJQ(".attributes.items[1].revocation_timestamp", "2026-10-17T12:00:00Z"))
+	})
+})
@@ -0,0 +1,92 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hcp
+
+import (
+	. "github.com/onsi/ginkgo/v2" // nolint
+	. "github.com/onsi/gomega"    // nolint
+	. "github.com/terraform-redhat/terraform-provider-rhcs/subsystem/framework"
+)
+
+var _ = Describe("HCP cluster network validation", func() {
+	const sts = `
+		sts = {
+			operator_role_prefix = "test"
+			role_arn             = ""
+			support_role_arn     = ""
+			instance_iam_roles = {
+				worker_role_arn = ""
+			}
+		}
+	`
+
+	It("rejects overlapping CIDRs", func() {
+		Terraform.Source(`
+			resource "rhcs_cluster_rosa_hcp" "my_cluster" {
+				name                   = "my-cluster"
+				cloud_region           = "us-west-1"
+				aws_account_id         = "123456789012"
+				aws_billing_account_id = "123456789012"
+				aws_subnet_ids         = ["id1", "id2"]
+				availability_zones     = ["us-west-1a"]
+				machine_cidr           = "10.0.0.0/16"
+				pod_cidr               = "10.0.0.0/14"
+				` + sts + `
+			}
+		`)
+		runOutput := Terraform.Validate()
+		Expect(runOutput.ExitCode).ToNot(BeZero())
+		runOutput.VerifyErrorContainsSubstring("'pod_cidr' 10.0.0.0/14 overlaps with 'machine_cidr'")
+	})
+
+	It("rejects a pod CIDR that is too small for the replicas", func() {
+		Terraform.Source(`
+			resource "rhcs_cluster_rosa_hcp" "my_cluster" {
+				name                   = "my-cluster"
+				cloud_region           = "us-west-1"
+				aws_account_id         = "123456789012"
+				aws_billing_account_id = "123456789012"
+				aws_subnet_ids         = ["id1", "id2"]
+				availability_zones     = ["us-west-1a"]
+				replicas               = 12
+				pod_cidr               = "10.128.0.0/20"
+				host_prefix            = 23
+				` + sts + `
+			}
+		`)
+		runOutput := Terraform.Validate()
+		Expect(runOutput.ExitCode).ToNot(BeZero())
+		runOutput.VerifyErrorContainsSubstring("only has room for 8 nodes, but the cluster can have up to 12 nodes")
+	})
+
+	It("rejects fewer subnets than availability zones", func() {
+		Terraform.Source(`
+			resource "rhcs_cluster_rosa_hcp" "my_cluster" {
+				name                   = "my-cluster"
+				cloud_region           = "us-west-1"
+				aws_account_id         = "123456789012"
+				aws_billing_account_id = "123456789012"
+				aws_subnet_ids         = ["id1"]
+				availability_zones     = ["us-west-1a", "us-west-1b"]
+				` + sts + `
+			}
+		`)
+		runOutput := Terraform.Validate()
+		Expect(runOutput.ExitCode).ToNot(BeZero())
+		runOutput.VerifyErrorContainsSubstring("at least the number of availability zones 2")
+	})
+})
@@ -1513,6 +1513,180 @@ var _ = Describe("HCP Cluster", func() {
 			Expect(resource).To(MatchJQ(`.attributes.aws_billing_account_id`, "123456799012"))
 		})
 
+		It("Creates basic cluster with audit log forwarding and removes it", func() {
+			// Prepare the server:
+			TestServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions"),
+					RespondWithJSON(http.StatusOK, versionListPage),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
+					VerifyJQ(`.aws.audit_log.role_arn`, "arn:aws:iam::123456789012:role/audit-log-role"),
+					RespondWithPatchedJSON(http.StatusCreated, template, fmt.Sprintf(`[
+					{
+					  "op": "add",
+					  "path": "/aws",
+					  "value": {
+						  "audit_log": {
+							  "role_arn": "arn:aws:iam::123456789012:role/audit-log-role"
+						  },
+						  "sts" : {
+							  "oidc_endpoint_url": "https://127.0.0.1",
+							  "thumbprint": "111111",
+							  "role_arn": "",
+							  "support_role_arn": "",
+							  "instance_iam_roles" : {
+								"worker_role_arn" : ""
+							  },
+							  "operator_role_prefix" : "test"
+						  }
+					  }
+					},
+					{
+						"op": "add",
+						"path": "/properties",
+						"value": {
+							"rosa_creator_arn": "arn:aws:iam::123456789012:user/dummy",
+							"rosa_tf_commit":"%s",
+							"rosa_tf_version":"%s"
+						}
+					}]`, build.Commit, build.Version)),
+				),
+			)
+
+			// Run the apply command:
+			Terraform.Source(`
+			resource "rhcs_cluster_rosa_hcp" "my_cluster" {
+				name           = "my-cluster"
+				cloud_region   = "us-west-1"
+				aws_account_id = "123456789012"
+				aws_billing_account_id = "123456789012"
+				audit_log_arn = "arn:aws:iam::123456789012:role/audit-log-role"
+				properties = {
+					rosa_creator_arn = "arn:aws:iam::123456789012:user/dummy",
+				}
+				sts = {
+					operator_role_prefix = "test"
+					role_arn = "",
+					support_role_arn = "",
+					instance_iam_roles = {
+						worker_role_arn = "",
+					}
+				}
+				aws_subnet_ids = [
+					"id1", "id2", "id3"
+				]
+				availability_zones = [
+					"us-west-1a",
+					"us-west-1b",
+					"us-west-1c",
+				]
+			}`)
+			runOutput := Terraform.Apply()
+			Expect(runOutput.ExitCode).To(BeZero())
+			resource := Terraform.Resource("rhcs_cluster_rosa_hcp", "my_cluster")
+			Expect(resource).To(MatchJQ(`.attributes.audit_log_arn`, "arn:aws:iam::123456789012:role/audit-log-role"))
+
+			// Prepare server for update
+			TestServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, cluster123Route),
+					RespondWithPatchedJSON(http.StatusOK, template, fmt.Sprintf(`[
+					{
+					  "op": "add",
+					  "path": "/aws",
+					  "value": {
+						  "audit_log": {
+							  "role_arn": "arn:aws:iam::123456789012:role/audit-log-role"
+						  },
+						  "sts" : {
+							  "oidc_endpoint_url": "https://127.0.0.1",
+							  "thumbprint": "111111",
+							  "role_arn": "",
+							  "support_role_arn": "",
+							  "instance_iam_roles" : {
+								"worker_role_arn" : ""
+							  },
+							  "operator_role_prefix" : "test"
+						  }
+					  }
+					},
+					{
+						"op": "add",
+						"path": "/properties",
+						"value": {
+							"rosa_creator_arn": "arn:aws:iam::123456789012:user/dummy",
+							"rosa_tf_commit":"%s",
+							"rosa_tf_version":"%s"
+						}
+					}]`, build.Commit, build.Version)),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodPatch, cluster123Route),
+					VerifyJQ(`.aws.audit_log.role_arn`, ""),
+					RespondWithPatchedJSON(http.StatusOK, template, fmt.Sprintf(`[
+					{
+					  "op": "add",
+					  "path": "/aws",
+					  "value": {
+						  "sts" : {
+							  "oidc_endpoint_url": "https://127.0.0.1",
+							  "thumbprint": "111111",
+							  "role_arn": "",
+							  "support_role_arn": "",
+							  "instance_iam_roles" : {
+								"worker_role_arn" : ""
+							  },
+							  "operator_role_prefix" : "test"
+						  }
+					  }
+					},
+					{
+						"op": "add",
+						"path": "/properties",
+						"value": {
+							"rosa_creator_arn": "arn:aws:iam::123456789012:user/dummy",
+							"rosa_tf_commit":"%s",
+							"rosa_tf_version":"%s"
+						}
+					}]`, build.Commit, build.Version)),
+				),
+			)
+
+			// Run the apply command:
+			Terraform.Source(`
+			resource "rhcs_cluster_rosa_hcp" "my_cluster" {
+				name           = "my-cluster"
+				cloud_region   = "us-west-1"
+				aws_account_id = "123456789012"
+				aws_billing_account_id = "123456789012"
+				properties = {
+					rosa_creator_arn = "arn:aws:iam::123456789012:user/dummy",
+				}
+				sts = {
+					operator_role_prefix = "test"
+					role_arn = "",
+					support_role_arn = "",
+					instance_iam_roles = {
+						worker_role_arn = "",
+					}
+				}
+				aws_subnet_ids = [
+					"id1", "id2", "id3"
+				]
+				availability_zones = [
+					"us-west-1a",
+					"us-west-1b",
+					"us-west-1c",
+				]
+			}`)
+			runOutput = Terraform.Apply()
+			Expect(runOutput.ExitCode).To(BeZero())
+			resource = Terraform.Resource("rhcs_cluster_rosa_hcp", "my_cluster")
+			Expect(resource).To(MatchJQ(`.attributes.audit_log_arn`, nil))
+		})
+
 		It("Creates basic cluster and update additional allowed principals", func() {
 			// Prepare the server:
 			TestServer.AppendHandlers(
@@ -3577,63 +3751,59 @@ var _ = Describe("HCP Cluster", func() {
 			})
 		})
 
-		Context("Test Proxy", func() {
-			It("Creates cluster with http proxy and update it", func() {
-				// Prepare the server:
-				TestServer.AppendHandlers(
-					CombineHandlers(
-						VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions"),
-						RespondWithJSON(http.StatusOK, versionListPage),
-					),
-					CombineHandlers(
-						VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
-						VerifyJQ(`.name`, "my-cluster"),
-						VerifyJQ(`.cloud_provider.id`, "aws"),
-						VerifyJQ(`.region.id`, "us-west-1"),
-						VerifyJQ(`.product.id`, "rosa"),
-						VerifyJQ(`.proxy.http_proxy`, "http://proxy.com"),
-						VerifyJQ(`.proxy.https_proxy`, "https://proxy.com"),
-						VerifyJQ(`.additional_trust_bundle`, "123"),
-						RespondWithPatchedJSON(http.StatusOK, templateWithTrustBundle, `[
-						{
-						"op": "add",
-						"path": "/aws",
-						"value": {
-							"sts" : {
-								"oidc_endpoint_url": "https://127.0.0.1",
-								"thumbprint": "111111",
-								"role_arn": "",
-								"support_role_arn": "",
-								"instance_iam_roles" : {
-									"worker_role_arn" : ""
-								},
-								"operator_role_prefix" : "test"
-							}
-						}
-						},
-						{
-						"op": "add",
-						"path": "/proxy",
-						"value": {
-							"http_proxy" : "http://proxy.com",
-							"https_proxy" : "https://proxy.com"
-						}
-						}]`),
-					),
-				)
+		const stsPatch = `[
+		{
+		  "op": "add",
+		  "path": "/aws",
+		  "value": {
+		    "sts" : {
+		      "oidc_endpoint_url": "https://127.0.0.1",
+		      "thumbprint": "111111",
+		      "role_arn": "",
+		      "support_role_arn": "",
+		      "instance_iam_roles" : {
+		        "worker_role_arn" : ""
+		      },
+		      "operator_role_prefix" : "test"
+		    }
+		  }
+		}]`
 
-				// Run the apply command:
-				Terraform.Source(`
+		Context("Delete protection", func() {
+			const protectedPatch = `[
+			{
+			  "op": "add",
+			  "path": "/aws",
+			  "value": {
+			    "sts" : {
+			      "oidc_endpoint_url": "https://127.0.0.1",
+			      "thumbprint": "111111",
+			      "role_arn": "",
+			      "support_role_arn": "",
+			      "instance_iam_roles" : {
+			        "worker_role_arn" : ""
+			      },
+			      "operator_role_prefix" : "test"
+			    }
+			  }
+			},
+			{
+			  "op": "add",
+			  "path": "/delete_protection",
+			  "value": {
+			    "enabled": true
+			  }
+			}]`
+
+			source := func(deleteProtection bool) string {
+				return fmt.Sprintf(`
 				resource "rhcs_cluster_rosa_hcp" "my_cluster" {
 					name           = "my-cluster"
 					cloud_region   = "us-west-1"
 					aws_account_id = "123456789012"
 					aws_billing_account_id = "123456789012"
-					proxy = {
-						http_proxy = "http://proxy.com",
-						https_proxy = "https://proxy.com",
-						additional_trust_bundle = "123",
-					}
+					delete_protection = %t
+					disable_waiting_in_destroy = true
 					sts = {
 						operator_role_prefix = "test"
 						role_arn = "",
@@ -3650,47 +3820,390 @@ var _ = Describe("HCP Cluster", func() {
 						"us-west-1b",
 						"us-west-1c",
 					]
-				}`)
-				runOutput := Terraform.Apply()
-				Expect(runOutput.ExitCode).To(BeZero())
+				}`, deleteProtection)
+			}
 
-				// apply for update the proxy's attributes
-				// Prepare the server:
+			BeforeEach(func() {
 				TestServer.AppendHandlers(
 					CombineHandlers(
-						VerifyRequest(http.MethodGet, cluster123Route),
-						RespondWithPatchedJSON(http.StatusOK, template, `[
-						{
-						"op": "add",
-						"path": "/aws",
-						"value": {
-							"sts" : {
-								"oidc_endpoint_url": "https://127.0.0.1",
-								"thumbprint": "111111",
-								"role_arn": "",
-								"support_role_arn": "",
-								"instance_iam_roles" : {
-									"worker_role_arn" : ""
-								},
-								"operator_role_prefix" : "test"
-							}
-						}
-						},
-						{
-						"op": "add",
-						"path": "/proxy",
-						"value": {
-							"http_proxy" : "http://proxy.com",
-							"https_proxy" : "https://proxy.com"
-						}
-						},
-						{
-						"op": "add",
-						"path": "/",
-						"value": {
-							"additional_trust_bundle" : "REDACTED"
-						}
-						}]`),
+						VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions"),
+						RespondWithJSON(http.StatusOK, versionListPage),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
+						VerifyJQ(`.name`, "my-cluster"),
+						RespondWithPatchedJSON(http.StatusCreated, template, stsPatch),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodPatch, cluster123Route+"/delete_protection"),
+						VerifyJQ(`.enabled`, true),
+						RespondWithJSON(http.StatusOK, "{}"),
+					),
+				)
+				Terraform.Source(source(true))
+				Expect(Terraform.Apply().ExitCode).To(BeZero())
+				resource := Terraform.Resource("rhcs_cluster_rosa_hcp", "my_cluster")
+				Expect(resource).To(MatchJQ(`.attributes.delete_protection`, true))
+			})
+
+			It("Refuses to destroy the cluster while protected", func() {
+				// The cluster is only read, there is no delete request:
+				TestServer.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, cluster123Route),
+						RespondWithPatchedJSON(http.StatusOK, template, protectedPatch),
+					),
+				)
+				runOutput := Terraform.Destroy()
+				Expect(runOutput.ExitCode).ToNot(BeZero())
+				runOutput.VerifyErrorContainsSubstring("has delete protection enabled")
+				runOutput.VerifyOutputContainsSubstring("Cluster has delete protection enabled")
+			})
+
+			It("Doesn't warn about the protection when an attribute that can't be updated changes", func() {
+				// The name can't be updated, but changing it doesn't replace the cluster, the
+				// update fails instead:
+				TestServer.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, cluster123Route),
+						RespondWithPatchedJSON(http.StatusOK, template, protectedPatch),
+					),
+				)
+				Terraform.Source(strings.Replace(source(true), "my-cluster", "your-cluster", 1))
+				runOutput := Terraform.Apply()
+				Expect(runOutput.ExitCode).ToNot(BeZero())
+				runOutput.VerifyErrorContainsSubstring("Attribute value cannot be changed")
+				runOutput.VerifyOutputDoesNotContainSubstring("Cluster has delete protection enabled")
+			})
+
+			It("Disables the delete protection and then destroys the cluster", func() {
+				// Disable the delete protection:
+				TestServer.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, cluster123Route),
+						RespondWithPatchedJSON(http.StatusOK, template, protectedPatch),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodPatch, cluster123Route+"/delete_protection"),
+						VerifyJQ(`.enabled`, false),
+						RespondWithJSON(http.StatusOK, "{}"),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodPatch, cluster123Route),
+						RespondWithPatchedJSON(http.StatusOK, template, stsPatch),
+					),
+				)
+				Terraform.Source(source(false))
+				runOutput := Terraform.Apply()
+				Expect(runOutput.ExitCode).To(BeZero())
+				runOutput.VerifyOutputDoesNotContainSubstring("Cluster has delete protection enabled")
+				resource := Terraform.Resource("rhcs_cluster_rosa_hcp", "my_cluster")
+				Expect(resource).To(MatchJQ(`.attributes.delete_protection`, false))
+
+				// Destroy the cluster:
+				TestServer.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, cluster123Route),
+						RespondWithPatchedJSON(http.StatusOK, template, stsPatch),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodDelete, cluster123Route),
+						RespondWithJSON(http.StatusOK, template),
+					),
+				)
+				Expect(Terraform.Destroy().ExitCode).To(BeZero())
+			})
+		})
+
+		Context("State upgrade", func() {
+			It("Keeps the attributes saved by old versions", func() {
+				// Save the state as it was written by a version that didn't have a schema
+				// version:
+				Terraform.ResourceState("rhcs_cluster_rosa_hcp", "my_cluster", 0, `{
+				  "id": "123",
+				  "external_id": "123",
+				  "name": "my-cluster",
+				  "cloud_region": "us-west-1",
+				  "aws_account_id": "123456789012",
+				  "aws_billing_account_id": "123456789012",
+				  "aws_subnet_ids": ["id1", "id2", "id3"],
+				  "availability_zones": ["us-west-1a", "us-west-1b", "us-west-1c"],
+				  "private": true,
+				  "etcd_encryption": false,
+				  "channel_group": "stable",
+				  "current_version": "4.14.0",
+				  "api_url": "https://my-api.example.com",
+				  "console_url": "https://my-console.example.com",
+				  "domain": ".mycluster-api.example.com",
+				  "domain_prefix": "",
+				  "base_dns_domain": "mycluster-api.example.com",
+				  "ec2_metadata_http_tokens": "optional",
+				  "host_prefix": 23,
+				  "machine_cidr": "10.0.0.0/16",
+				  "pod_cidr": "10.128.0.0/14",
+				  "service_cidr": "172.30.0.0/16",
+				  "delete_protection": false,
+				  "disable_waiting_in_destroy": true,
+				  "state": "ready",
+				  "properties": {
+				    "rosa_creator_arn:": "arn:aws:iam::123456789012:user/dummy"
+				  },
+				  "ocm_properties": {
+				    "rosa_creator_arn:": "arn:aws:iam::123456789012:user/dummy",
+				    "rosa_tf_commit": "",
+				    "rosa_tf_version": ""
+				  },
+				  "sts": {
+				    "instance_iam_roles": {
+				      "worker_role_arn": ""
+				    },
+				    "oidc_endpoint_url": "127.0.0.1",
+				    "operator_role_prefix": "test",
+				    "role_arn": "",
+				    "support_role_arn": "",
+				    "thumbprint": ""
+				  }
+				}`, "admin_credentials_password_wo")
+				Terraform.Source(`
+				resource "rhcs_cluster_rosa_hcp" "my_cluster" {
+					name           = "my-cluster"
+					cloud_region   = "us-west-1"
+					aws_account_id = "123456789012"
+					aws_billing_account_id = "123456789012"
+					delete_protection = false
+					disable_waiting_in_destroy = true
+					sts = {
+						operator_role_prefix = "test"
+						role_arn = "",
+						support_role_arn = "",
+						instance_iam_roles = {
+							worker_role_arn = "",
+						}
+					}
+					aws_subnet_ids = [
+						"id1", "id2", "id3"
+					]
+					availability_zones = [
+						"us-west-1a",
+						"us-west-1b",
+						"us-west-1c",
+					]
+				}`)
+
+				// The plan is computed from the upgraded state without reading the cluster, so
+				// any attribute lost by the upgrade would appear as a change:
+				runOutput := Terraform.UpgradeState()
+				Expect(runOutput.ExitCode).To(BeZero())
+				runOutput.VerifyOutputContainsSubstring("No changes.")
+				resource := Terraform.Resource("rhcs_cluster_rosa_hcp", "my_cluster")
+				Expect(resource).To(MatchJQ(".schema_version", 1.0))
+				Expect(resource).To(MatchJQ(".attributes.id", "123"))
+				Expect(resource).To(MatchJQ(".attributes.sts.operator_role_prefix", "test"))
+				Expect(resource).To(MatchJQ(".attributes.aws_subnet_ids | length", 3))
+			})
+		})
+
+		Context("Deletion policy", func() {
+			source := func(deletionPolicy string) string {
+				return fmt.Sprintf(`
+				resource "rhcs_cluster_rosa_hcp" "my_cluster" {
+					name           = "my-cluster"
+					cloud_region   = "us-west-1"
+					aws_account_id = "123456789012"
+					aws_billing_account_id = "123456789012"
+					deletion_policy = "%s"
+					sts = {
+						operator_role_prefix = "test"
+						role_arn = "",
+						support_role_arn = "",
+						instance_iam_roles = {
+							worker_role_arn = "",
+						}
+					}
+					aws_subnet_ids = [
+						"id1", "id2", "id3"
+					]
+					availability_zones = [
+						"us-west-1a",
+						"us-west-1b",
+						"us-west-1c",
+					]
+				}`, deletionPolicy)
+			}
+
+			BeforeEach(func() {
+				TestServer.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions"),
+						RespondWithJSON(http.StatusOK, versionListPage),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
+						VerifyJQ(`.name`, "my-cluster"),
+						RespondWithPatchedJSON(http.StatusCreated, template, stsPatch),
+					),
+				)
+			})
+
+			It("Keeps the cluster in OCM when it is retained", func() {
+				Terraform.Source(source("retain"))
+				Expect(Terraform.Apply().ExitCode).To(BeZero())
+				resource := Terraform.Resource("rhcs_cluster_rosa_hcp", "my_cluster")
+				Expect(resource).To(MatchJQ(`.attributes.deletion_policy`, "retain"))
+
+				// The cluster is only read, there is no delete request:
+				TestServer.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, cluster123Route),
+						RespondWithPatchedJSON(http.StatusOK, template, stsPatch),
+					),
+				)
+				Expect(Terraform.Destroy().ExitCode).To(BeZero())
+				Expect(Terraform.State()).To(MatchJQ(`.resources | length`, 0))
+			})
+
+			It("Changes the deletion policy in place", func() {
+				Terraform.Source(source("delete"))
+				Expect(Terraform.Apply().ExitCode).To(BeZero())
+
+				TestServer.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, cluster123Route),
+						RespondWithPatchedJSON(http.StatusOK, template, stsPatch),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodPatch, cluster123Route),
+						RespondWithPatchedJSON(http.StatusOK, template, stsPatch),
+					),
+				)
+				Terraform.Source(source("retain"))
+				Expect(Terraform.Apply().ExitCode).To(BeZero())
+				resource := Terraform.Resource("rhcs_cluster_rosa_hcp", "my_cluster")
+				Expect(resource).To(MatchJQ(`.attributes.deletion_policy`, "retain"))
+			})
+
+			It("Fails if the deletion policy isn't valid", func() {
+				Terraform.Source(source("orphan"))
+				runOutput := Terraform.Apply()
+				Expect(runOutput.ExitCode).ToNot(BeZero())
+				runOutput.VerifyErrorContainsSubstring(`Attribute deletion_policy value must be one of`)
+			})
+		})
+
+		Context("Test Proxy", func() {
+			It("Creates cluster with http proxy and update it", func() {
+				// Prepare the server:
+				TestServer.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions"),
+						RespondWithJSON(http.StatusOK, versionListPage),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
+						VerifyJQ(`.name`, "my-cluster"),
+						VerifyJQ(`.cloud_provider.id`, "aws"),
+						VerifyJQ(`.region.id`, "us-west-1"),
+						VerifyJQ(`.product.id`, "rosa"),
+						VerifyJQ(`.proxy.http_proxy`, "http://proxy.com"),
+						VerifyJQ(`.proxy.https_proxy`, "https://proxy.com"),
+						VerifyJQ(`.additional_trust_bundle`, "123"),
+						RespondWithPatchedJSON(http.StatusOK, templateWithTrustBundle, `[
+						{
+						"op": "add",
+						"path": "/aws",
+						"value": {
+							"sts" : {
+								"oidc_endpoint_url": "https://127.0.0.1",
+								"thumbprint": "111111",
+								"role_arn": "",
+								"support_role_arn": "",
+								"instance_iam_roles" : {
+									"worker_role_arn" : ""
+								},
+								"operator_role_prefix" : "test"
+							}
+						}
+						},
+						{
+						"op": "add",
+						"path": "/proxy",
+						"value": {
+							"http_proxy" : "http://proxy.com",
+							"https_proxy" : "https://proxy.com"
+						}
+						}]`),
+					),
+				)
+
+				// Run the apply command:
+				Terraform.Source(`
+				resource "rhcs_cluster_rosa_hcp" "my_cluster" {
+					name           = "my-cluster"
+					cloud_region   = "us-west-1"
+					aws_account_id = "123456789012"
+					aws_billing_account_id = "123456789012"
+					proxy = {
+						http_proxy = "http://proxy.com",
+						https_proxy = "https://proxy.com",
+						additional_trust_bundle = "123",
+					}
+					sts = {
+						operator_role_prefix = "test"
+						role_arn = "",
+						support_role_arn = "",
+						instance_iam_roles = {
+							worker_role_arn = "",
+						}
+					}
+					aws_subnet_ids = [
+						"id1", "id2", "id3"
+					]
+					availability_zones = [
+						"us-west-1a",
+						"us-west-1b",
+						"us-west-1c",
+					]
+				}`)
+				runOutput := Terraform.Apply()
+				Expect(runOutput.ExitCode).To(BeZero())
+
+				// apply for update the proxy's attributes
+				// Prepare the server:
+				TestServer.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, cluster123Route),
+						RespondWithPatchedJSON(http.StatusOK, template, `[
+						{
+						"op": "add",
+						"path": "/aws",
+						"value": {
+							"sts" : {
+								"oidc_endpoint_url": "https://127.0.0.1",
+								"thumbprint": "111111",
+								"role_arn": "",
+								"support_role_arn": "",
+								"instance_iam_roles" : {
+									"worker_role_arn" : ""
+								},
+								"operator_role_prefix" : "test"
+							}
+						}
+						},
+						{
+						"op": "add",
+						"path": "/proxy",
+						"value": {
+							"http_proxy" : "http://proxy.com",
+							"https_proxy" : "https://proxy.com"
+						}
+						},
+						{
+						"op": "add",
+						"path": "/",
+						"value": {
+							"additional_trust_bundle" : "REDACTED"
+						}
+						}]`),
 					),
 					CombineHandlers(
 						VerifyRequest(http.MethodPatch, cluster123Route),
@@ -4615,41 +5128,363 @@ var _ = Describe("HCP Cluster", func() {
 						"sts_only": true,
 						"creation_timestamp": "2023-04-03T06:39:57.057613Z"
 						},
-						"creation_timestamp": "2023-06-21T13:02:06.291443Z"
-					}`),
+						"creation_timestamp": "2023-06-21T13:02:06.291443Z"
+					}`),
+				),
+				// Create an upgrade policy
+				CombineHandlers(
+					VerifyRequest(http.MethodPost, cluster123Route+"/control_plane/upgrade_policies"),
+					VerifyJQ(".version", "4.14.1"),
+					RespondWithJSON(http.StatusCreated, `
+					{
+						"id": "123",
+						"schedule_type": "manual",
+						"upgrade_type": "OSD",
+						"version": "4.14.1",
+						"next_run": "2023-06-09T20:59:00Z",
+						"cluster_id": "123",
+						"enable_minor_version_upgrades": true
+					}`),
+				),
+				// Patch the cluster (w/ no changes)
+				CombineHandlers(
+					VerifyRequest(http.MethodPatch, cluster123Route),
+					RespondWithPatchedJSON(http.StatusCreated, template, `
+					[
+						{
+						  "op": "add",
+						  "path": "/properties",
+						  "value": {
+							"rosa_tf_commit": "",
+							"rosa_tf_version": ""
+						  }
+						}
+					]`),
+				),
+			)
+			// Perform upgrade w/ auto-ack of sts-only gate agreements
+			Terraform.Source(`
+			resource "rhcs_cluster_rosa_hcp" "my_cluster" {
+				name           = "my-cluster"
+				cloud_region   = "us-west-1"
+				aws_account_id = "123456789012"
+				aws_billing_account_id = "123456789012"
+				sts = {
+					operator_role_prefix = "test"
+					role_arn = ""
+					support_role_arn = ""
+					instance_iam_roles = {
+						worker_role_arn = ""
+					}
+				}
+				aws_subnet_ids = [
+					"id1", "id2", "id3"
+				]
+				availability_zones = [
+					"us-west-1a",
+					"us-west-1b",
+					"us-west-1c",
+				]
+				version = "4.14.1"
+			}`)
+			runOutput := Terraform.Apply()
+			Expect(runOutput.ExitCode).To(BeZero())
+		})
+
+		Context("Waiting for the upgrade", func() {
+			const stsPatch = `[
+				{
+					"op": "add",
+					"path": "/aws",
+					"value": {
+						"sts" : {
+							"oidc_endpoint_url": "https://127.0.0.1",
+							"thumbprint": "111111",
+							"role_arn": "",
+							"support_role_arn": "",
+							"instance_iam_roles" : {
+								"worker_role_arn" : ""
+							},
+							"operator_role_prefix" : "test"
+						}
+					}
+				},
+				{
+					"op": "add",
+					"path": "/properties",
+					"value": {
+						"rosa_tf_commit": "",
+						"rosa_tf_version": ""
+					}
+				}
+			]`
+			const upgradedPatch = `[
+				{
+					"op": "replace",
+					"path": "/version",
+					"value": {
+						"id": "openshift-v4.14.1",
+						"raw_id": "4.14.1",
+						"channel_group": "stable"
+					}
+				},
+				{
+					"op": "add",
+					"path": "/properties",
+					"value": {
+						"rosa_tf_commit": "",
+						"rosa_tf_version": ""
+					}
+				}
+			]`
+			const upgradedSource = `
+			resource "rhcs_cluster_rosa_hcp" "my_cluster" {
+				name           = "my-cluster"
+				cloud_region   = "us-west-1"
+				aws_account_id = "123456789012"
+				aws_billing_account_id = "123456789012"
+				sts = {
+					operator_role_prefix = "test"
+					role_arn = ""
+					support_role_arn = ""
+					instance_iam_roles = {
+						worker_role_arn = ""
+					}
+				}
+				aws_subnet_ids = [
+					"id1", "id2", "id3"
+				]
+				availability_zones = [
+					"us-west-1a",
+					"us-west-1b",
+					"us-west-1c",
+				]
+				version = "4.14.1"
+				wait_for_upgrade_complete = true
+			}`
+
+			// prepareUpgradeSchedule prepares the requests that refresh the cluster and schedule the upgrade
+			prepareUpgradeSchedule := func() {
+				TestServer.AppendHandlers(
+					// Refresh cluster state
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, cluster123Route),
+						RespondWithPatchedJSON(http.StatusOK, template, stsPatch),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, cluster123Route),
+						RespondWithPatchedJSON(http.StatusOK, template, stsPatch),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions/openshift-v4.14.1"),
+						RespondWithJSON(http.StatusOK, v4141Info),
+					),
+					// Look for existing upgrade policies
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, cluster123Route+"/control_plane/upgrade_policies"),
+						RespondWithJSON(http.StatusOK, emptyControlPlaneUpgradePolicies),
+					),
+					// Look for gate agreements by posting an upgrade policy w/ dryRun (no gates necessary)
+					CombineHandlers(
+						VerifyRequest(http.MethodPost, cluster123Route+"/control_plane/upgrade_policies", "dryRun=true"),
+						RespondWithJSON(http.StatusNoContent, ""),
+					),
+					// Create an upgrade policy
+					CombineHandlers(
+						VerifyRequest(http.MethodPost, cluster123Route+"/control_plane/upgrade_policies"),
+						VerifyJQ(".version", "4.14.1"),
+						RespondWithJSON(http.StatusCreated, `{
+							"id": "456",
+							"schedule_type": "manual",
+							"upgrade_type": "ControlPlane",
+							"version": "4.14.1",
+							"next_run": "2023-06-09T20:59:00Z",
+							"cluster_id": "123",
+							"enable_minor_version_upgrades": true
+						}`),
+					),
+				)
+			}
+
+			It("Waits till the cluster has the new version", func() {
+				prepareUpgradeSchedule()
+				TestServer.AppendHandlers(
+					// Check the version of the cluster while waiting
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, cluster123Route),
+						RespondWithPatchedJSON(http.StatusOK, template, upgradedPatch),
+					),
+					// Patch the cluster (w/ no changes)
+					CombineHandlers(
+						VerifyRequest(http.MethodPatch, cluster123Route),
+						RespondWithPatchedJSON(http.StatusOK, template, upgradedPatch),
+					),
+				)
+				Terraform.Source(upgradedSource)
+				runOutput := Terraform.Apply()
+				Expect(runOutput.ExitCode).To(BeZero())
+				resource := Terraform.Resource("rhcs_cluster_rosa_hcp", "my_cluster")
+				Expect(resource).To(MatchJQ(".attributes.version", "4.14.1"))
+				Expect(resource).To(MatchJQ(".attributes.current_version", "4.14.1"))
+			})
+
+			It("Fails with the description of the upgrade policy state if the upgrade fails", func() {
+				prepareUpgradeSchedule()
+				TestServer.AppendHandlers(
+					// Check the version of the cluster while waiting
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, cluster123Route),
+						RespondWithPatchedJSON(http.StatusOK, template, stsPatch),
+					),
+					// Check the state of the upgrade policy
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, cluster123Route+"/control_plane/upgrade_policies"),
+						RespondWithJSON(http.StatusOK, `{
+							"kind": "ControlPlaneUpgradePolicyList",
+							"page": 1,
+							"size": 1,
+							"total": 1,
+							"items": [
+								{
+									"kind": "ControlPlaneUpgradePolicy",
+									"id": "456",
+									"schedule_type": "manual",
+									"upgrade_type": "ControlPlane",
+									"version": "4.14.1",
+									"next_run": "2023-06-09T20:59:00Z",
+									"cluster_id": "123"
+								}
+							]
+						}`),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, cluster123Route+"/control_plane/upgrade_policies/456"),
+						RespondWithJSON(http.StatusOK, `{
+							"kind": "ControlPlaneUpgradePolicy",
+							"id": "456",
+							"schedule_type": "manual",
+							"upgrade_type": "ControlPlane",
+							"version": "4.14.1",
+							"next_run": "2023-06-09T20:59:00Z",
+							"cluster_id": "123",
+							"state": {
+								"value": "failed",
+								"description": "Upgrade failed: etcd is degraded"
+							}
+						}`),
+					),
+				)
+				Terraform.Source(upgradedSource)
+				runOutput := Terraform.Apply()
+				Expect(runOutput.ExitCode).ToNot(BeZero())
+				runOutput.VerifyErrorContainsSubstring("the upgrade to version 4.14.1 ended in state 'failed'")
+				runOutput.VerifyErrorContainsSubstring("Upgrade failed: etcd is degraded")
+				resource := Terraform.Resource("rhcs_cluster_rosa_hcp", "my_cluster")
+				Expect(resource).To(MatchJQ(".attributes.version", "4.14.0"))
+			})
+		})
+
+		It("Does nothing if upgrade is in progress to a different version than the desired", func() {
+			TestServer.AppendHandlers(
+				// Refresh cluster state
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, cluster123Route),
+					RespondWithPatchedJSON(http.StatusOK, template, `
+					[
+						{
+							"op": "add",
+							"path": "/aws",
+							"value": {
+								"sts" : {
+									"oidc_endpoint_url": "https://127.0.0.1",
+									"thumbprint": "111111",
+									"role_arn": "",
+									"support_role_arn": "",
+									"instance_iam_roles" : {
+										"worker_role_arn" : ""
+									},
+									"operator_role_prefix" : "test"
+								}
+							}
+						},
+						{
+							"op": "add",
+							"path": "/properties",
+							"value": {
+								"rosa_tf_commit": "",
+								"rosa_tf_version": ""
+							}
+						}
+					]`),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, cluster123Route),
+					RespondWithPatchedJSON(http.StatusOK, template, `
+					[
+						{
+							"op": "add",
+							"path": "/aws",
+							"value": {
+								"sts" : {
+									"oidc_endpoint_url": "https://127.0.0.1",
+									"thumbprint": "111111",
+									"role_arn": "",
+									"support_role_arn": "",
+									"instance_iam_roles" : {
+										"worker_role_arn" : ""
+									},
+									"operator_role_prefix" : "test"
+								}
+							}
+						},
+						{
+							"op": "add",
+							"path": "/properties",
+							"value": {
+								"rosa_tf_commit": "",
+								"rosa_tf_version": ""
+							}
+						}
+					]`),
 				),
-				// Create an upgrade policy
 				CombineHandlers(
-					VerifyRequest(http.MethodPost, cluster123Route+"/control_plane/upgrade_policies"),
-					VerifyJQ(".version", "4.14.1"),
-					RespondWithJSON(http.StatusCreated, `
-					{
-						"id": "123",
-						"schedule_type": "manual",
-						"upgrade_type": "OSD",
-						"version": "4.14.1",
-						"next_run": "2023-06-09T20:59:00Z",
-						"cluster_id": "123",
-						"enable_minor_version_upgrades": true
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions/openshift-v4.14.1"),
+					RespondWithJSON(http.StatusOK, v4141Info),
+				),
+				// Look for existing upgrade policies
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, cluster123Route+"/control_plane/upgrade_policies"),
+					RespondWithJSON(http.StatusOK, `{
+						"page": 1,
+						"size": 1,
+						"total": 1,
+						"items": [
+							{
+								"id": "456",
+								"schedule_type": "manual",
+								"upgrade_type": "ControlPlane",
+								"version": "4.14.0",
+								"next_run": "2023-06-09T20:59:00Z",
+								"cluster_id": "123",
+								"enable_minor_version_upgrades": true
+							}
+						]
 					}`),
 				),
-				// Patch the cluster (w/ no changes)
+				// Check it's state
 				CombineHandlers(
-					VerifyRequest(http.MethodPatch, cluster123Route),
-					RespondWithPatchedJSON(http.StatusCreated, template, `
-					[
-						{
-						  "op": "add",
-						  "path": "/properties",
-						  "value": {
-							"rosa_tf_commit": "",
-							"rosa_tf_version": ""
-						  }
+					VerifyRequest(http.MethodGet, cluster123Route+"/control_plane/upgrade_policies/456"),
+					RespondWithJSON(http.StatusOK, `
+					{
+						"id": "456",
+						"state": {
+							"description": "Upgrade in progress",
+							"value": "started"
 						}
-					]`),
+					}`),
 				),
 			)
-			// Perform upgrade w/ auto-ack of sts-only gate agreements
+			// Perform try the upgrade
 			Terraform.Source(`
 			resource "rhcs_cluster_rosa_hcp" "my_cluster" {
 				name           = "my-cluster"
@@ -4674,11 +5509,11 @@ var _ = Describe("HCP Cluster", func() {
 				]
 				version = "4.14.1"
 			}`)
-			runOutput := Terraform.Apply()
-			Expect(runOutput.ExitCode).To(BeZero())
+			// Will fail due to upgrade in progress
+			Expect(Terraform.Apply()).NotTo(BeZero())
 		})
 
-		It("Does nothing if upgrade is in progress to a different version than the desired", func() {
+		It("Cancels and upgrade for the wrong version & schedules new", func() {
 			TestServer.AppendHandlers(
 				// Refresh cluster state
 				CombineHandlers(
@@ -4749,9 +5584,10 @@ var _ = Describe("HCP Cluster", func() {
 				CombineHandlers(
 					VerifyRequest(http.MethodGet, cluster123Route+"/control_plane/upgrade_policies"),
 					RespondWithJSON(http.StatusOK, `{
+						"kind": "UpgradePolicyState",
 						"page": 1,
-						"size": 1,
-						"total": 1,
+						"size": 0,
+						"total": 0,
 						"items": [
 							{
 								"id": "456",
@@ -4768,15 +5604,44 @@ var _ = Describe("HCP Cluster", func() {
 				// Check it's state
 				CombineHandlers(
 					VerifyRequest(http.MethodGet, cluster123Route+"/control_plane/upgrade_policies/456"),
-					RespondWithJSON(http.StatusOK, `
-					{
+					RespondWithJSON(http.StatusOK, `{
 						"id": "456",
 						"state": {
-							"description": "Upgrade in progress",
-							"value": "started"
+							"description": "",
+							"value": "scheduled"
 						}
 					}`),
 				),
+				// Delete existing upgrade policy
+				CombineHandlers(
+					VerifyRequest(http.MethodDelete, cluster123Route+"/control_plane/upgrade_policies/456"),
+					RespondWithJSON(http.StatusOK, "{}"),
+				),
+				// Look for gate agreements by posting an upgrade policy w/ dryRun (no gates necessary)
+				CombineHandlers(
+					VerifyRequest(http.MethodPost, cluster123Route+"/control_plane/upgrade_policies", "dryRun=true"),
+					VerifyJQ(".version", "4.14.1"),
+					RespondWithJSON(http.StatusNoContent, ""),
+				),
+				// Create an upgrade policy
+				CombineHandlers(
+					VerifyRequest(http.MethodPost, cluster123Route+"/control_plane/upgrade_policies"),
+					VerifyJQ(".version", "4.14.1"),
+					RespondWithJSON(http.StatusCreated, `{
+						"id": "123",
+						"schedule_type": "manual",
+						"upgrade_type": "ControlPlane",
+						"version": "4.14.1",
+						"next_run": "2023-06-09T20:59:00Z",
+						"cluster_id": "123",
+						"enable_minor_version_upgrades": true
+					}`),
+				),
+				// Patch the cluster (w/ no changes)
+				CombineHandlers(
+					VerifyRequest(http.MethodPatch, cluster123Route),
+					RespondWithJSON(http.StatusOK, template),
+				),
 			)
 			// Perform try the upgrade
 			Terraform.Source(`
@@ -4803,11 +5668,11 @@ var _ = Describe("HCP Cluster", func() {
 				]
 				version = "4.14.1"
 			}`)
-			// Will fail due to upgrade in progress
-			Expect(Terraform.Apply()).NotTo(BeZero())
+			runOutput := Terraform.Apply()
+			Expect(runOutput.ExitCode).To(BeZero())
 		})
 
-		It("Cancels and upgrade for the wrong version & schedules new", func() {
+		It("Keeps the automatic upgrade policy of the cluster & schedules new", func() {
 			TestServer.AppendHandlers(
 				// Refresh cluster state
 				CombineHandlers(
@@ -4885,32 +5750,17 @@ var _ = Describe("HCP Cluster", func() {
 						"items": [
 							{
 								"id": "456",
-								"schedule_type": "manual",
+								"schedule_type": "automatic",
 								"upgrade_type": "ControlPlane",
-								"version": "4.14.0",
+								"schedule": "0 2 * * 1",
 								"next_run": "2023-06-09T20:59:00Z",
 								"cluster_id": "123",
-								"enable_minor_version_upgrades": true
+								"enable_minor_version_upgrades": false
 							}
 						]
 					}`),
 				),
-				// Check it's state
-				CombineHandlers(
-					VerifyRequest(http.MethodGet, cluster123Route+"/control_plane/upgrade_policies/456"),
-					RespondWithJSON(http.StatusOK, `{
-						"id": "456",
-						"state": {
-							"description": "",
-							"value": "scheduled"
-						}
-					}`),
-				),
-				// Delete existing upgrade policy
-				CombineHandlers(
-					VerifyRequest(http.MethodDelete, cluster123Route+"/control_plane/upgrade_policies/456"),
-					RespondWithJSON(http.StatusOK, "{}"),
-				),
+				// The automatic policy doesn't have a version, and it isn't checked or deleted
 				// Look for gate agreements by posting an upgrade policy w/ dryRun (no gates necessary)
 				CombineHandlers(
 					VerifyRequest(http.MethodPost, cluster123Route+"/control_plane/upgrade_policies", "dryRun=true"),
@@ -5370,12 +6220,7 @@ var _ = Describe("HCP Cluster", func() {
 	})
 
 	Context("Import", func() {
-		It("can import a cluster", func() {
-			// Prepare the server:
-			TestServer.AppendHandlers(
-				CombineHandlers(
-					VerifyRequest(http.MethodGet, cluster123Route),
-					RespondWithPatchedJSON(http.StatusOK, template, `[
+		const importPatch = `[
 						{
 						  "op": "add",
 						  "path": "/aws",
@@ -5407,7 +6252,20 @@ var _ = Describe("HCP Cluster", func() {
 								"id": "r5.xlarge"
 							}
 						  }
-						}]`),
+						}]`
+
+		It("can import a cluster", func() {
+			// Prepare the server:
+			TestServer.AppendHandlers(
+				// Get is for resolving the import identifier
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, cluster123Route),
+					RespondWithPatchedJSON(http.StatusOK, template, importPatch),
+				),
+				// Get is for the Read function
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, cluster123Route),
+					RespondWithPatchedJSON(http.StatusOK, template, importPatch),
 				),
 			)
 
@@ -5418,6 +6276,147 @@ var _ = Describe("HCP Cluster", func() {
 			resource := Terraform.Resource("rhcs_cluster_rosa_hcp", "my_cluster")
 			Expect(resource).To(MatchJQ(".attributes.current_version", "4.14.0"))
 		})
+
+		It("can import a cluster by name", func() {
+			// Prepare the server:
+			TestServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/my-cluster"),
+					RespondWithJSON(http.StatusNotFound, "{}"),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters"),
+					VerifyFormKV("search", "name = 'my-cluster' or external_id = 'my-cluster'"),
+					RespondWithJSON(http.StatusOK, `{
+						"kind": "ClusterList",
+						"page": 1,
+						"size": 1,
+						"total": 1,
+						"items": [
+							{
+								"kind": "Cluster",
+								"id": "123",
+								"name": "my-cluster"
+							}
+						]
+					}`),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, cluster123Route),
+					RespondWithPatchedJSON(http.StatusOK, template, importPatch),
+				),
+			)
+
+			// Run the import command:
+			Terraform.Source(`resource "rhcs_cluster_rosa_hcp" "my_cluster" {}`)
+			runOutput := Terraform.Import("rhcs_cluster_rosa_hcp.my_cluster", "my-cluster")
+			Expect(runOutput.ExitCode).To(BeZero())
+			resource := Terraform.Resource("rhcs_cluster_rosa_hcp", "my_cluster")
+			Expect(resource).To(MatchJQ(".attributes.id", "123"))
+			Expect(resource).To(MatchJQ(".attributes.name", "my-cluster"))
+		})
+
+		It("can import a cluster by external identifier", func() {
+			const externalID = "0a9c3b7e-5f4d-4c2b-9e1a-7d6f8b2c4e10"
+			// Prepare the server:
+			TestServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/"+externalID),
+					RespondWithJSON(http.StatusNotFound, "{}"),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters"),
+					VerifyFormKV("search", fmt.Sprintf("name = '%s' or external_id = '%s'", externalID, externalID)),
+					RespondWithJSON(http.StatusOK, `{
+						"kind": "ClusterList",
+						"page": 1,
+						"size": 1,
+						"total": 1,
+						"items": [
+							{
+								"kind": "Cluster",
+								"id": "123",
+								"external_id": "`+externalID+`"
+							}
+						]
+					}`),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, cluster123Route),
+					RespondWithPatchedJSON(http.StatusOK, template, importPatch),
+				),
+			)
+
+			// Run the import command:
+			Terraform.Source(`resource "rhcs_cluster_rosa_hcp" "my_cluster" {}`)
+			runOutput := Terraform.Import("rhcs_cluster_rosa_hcp.my_cluster", externalID)
+			Expect(runOutput.ExitCode).To(BeZero())
+			resource := Terraform.Resource("rhcs_cluster_rosa_hcp", "my_cluster")
+			Expect(resource).To(MatchJQ(".attributes.id", "123"))
+		})
+
+		It("fails to import a cluster when the name matches several clusters", func() {
+			// Prepare the server:
+			TestServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/my-cluster"),
+					RespondWithJSON(http.StatusNotFound, "{}"),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters"),
+					RespondWithJSON(http.StatusOK, `{
+						"kind": "ClusterList",
+						"page": 1,
+						"size": 2,
+						"total": 2,
+						"items": [
+							{
+								"kind": "Cluster",
+								"id": "123",
+								"name": "my-cluster"
+							},
+							{
+								"kind": "Cluster",
+								"id": "456",
+								"name": "my-cluster"
+							}
+						]
+					}`),
+				),
+			)
+
+			// Run the import command:
+			Terraform.Source(`resource "rhcs_cluster_rosa_hcp" "my_cluster" {}`)
+			runOutput := Terraform.Import("rhcs_cluster_rosa_hcp.my_cluster", "my-cluster")
+			Expect(runOutput.ExitCode).ToNot(BeZero())
+			runOutput.VerifyErrorContainsSubstring("there are 2 clusters with name or external identifier 'my-cluster' (123, 456)")
+		})
+
+		It("fails to import a cluster that doesn't exist", func() {
+			// Prepare the server:
+			TestServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/my-cluster"),
+					RespondWithJSON(http.StatusNotFound, "{}"),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters"),
+					RespondWithJSON(http.StatusOK, `{
+						"kind": "ClusterList",
+						"page": 1,
+						"size": 0,
+						"total": 0,
+						"items": []
+					}`),
+				),
+			)
+
+			// Run the import command:
+			Terraform.Source(`resource "rhcs_cluster_rosa_hcp" "my_cluster" {}`)
+			runOutput := Terraform.Import("rhcs_cluster_rosa_hcp.my_cluster", "my-cluster")
+			Expect(runOutput.ExitCode).ToNot(BeZero())
+			runOutput.VerifyErrorContainsSubstring("cluster 'my-cluster' not found")
+		})
 	})
 
 	Context("External Authentication", func() {
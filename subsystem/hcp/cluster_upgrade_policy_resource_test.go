@@ -0,0 +1,354 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hcp
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"                      // nolint
+	. "github.com/onsi/gomega"                         // nolint
+	. "github.com/onsi/gomega/ghttp"                   // nolint
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+	. "github.com/terraform-redhat/terraform-provider-rhcs/subsystem/framework"
+)
+
+var _ = Describe("Cluster upgrade policy", func() {
+	const clusterReady = `{
+		"kind": "Cluster",
+		"id": "123",
+		"href": "/api/clusters_mgmt/v1/clusters/123",
+		"name": "cluster",
+		"state": "ready",
+		"hypershift": {
+			"enabled": true
+		}
+	}`
+	const policy = `{
+		"kind": "ControlPlaneUpgradePolicy",
+		"id": "456",
+		"href": "/api/clusters_mgmt/v1/clusters/123/control_plane/upgrade_policies/456",
+		"schedule_type": "automatic",
+		"upgrade_type": "ControlPlane",
+		"schedule": "0 2 * * 1",
+		"enable_minor_version_upgrades": false,
+		"next_run": "2026-10-19T02:00:00Z"
+	}`
+	const policiesEmpty = `{
+		"kind": "ControlPlaneUpgradePolicyList",
+		"page": 1,
+		"size": 0,
+		"total": 0,
+		"items": []
+	}`
+
+	createPolicy := func() {
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, clusterReady),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/control_plane/upgrade_policies"),
+				RespondWithJSON(http.StatusOK, policiesEmpty),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters/123/control_plane/upgrade_policies"),
+				VerifyJQ(".schedule_type", "automatic"),
+				VerifyJQ(".upgrade_type", "ControlPlane"),
+				VerifyJQ(".schedule", "0 2 * * 1"),
+				VerifyJQ(".enable_minor_version_upgrades", false),
+				RespondWithJSON(http.StatusCreated, policy),
+			),
+		)
+		Terraform.Source(`
+			resource "rhcs_cluster_upgrade_policy" "policy" {
+				cluster  = "123"
+				schedule = "0 2 * * 1"
+			}
+		`)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).To(BeZero())
+	}
+
+	Context("creation", func() {
+		It("fails if the schedule isn't a cron expression", func() {
+			Terraform.Source(`
+				resource "rhcs_cluster_upgrade_policy" "policy" {
+					cluster  = "123"
+					schedule = "every monday"
+				}
+			`)
+			runOutput := Terraform.Validate()
+			Expect(runOutput.ExitCode).ToNot(BeZero())
+			runOutput.VerifyErrorContainsSubstring("schedule must be a cron expression with five fields")
+		})
+
+		It("fails if the maintenance window start isn't a timestamp", func() {
+			Terraform.Source(`
+				resource "rhcs_cluster_upgrade_policy" "policy" {
+					cluster                  = "123"
+					schedule                 = "0 2 * * 1"
+					maintenance_window_start = "tomorrow"
+				}
+			`)
+			runOutput := Terraform.Validate()
+			Expect(runOutput.ExitCode).ToNot(BeZero())
+			runOutput.VerifyErrorContainsSubstring("isn't a valid RFC3339 timestamp")
+		})
+
+		It("fails if the cluster already has an automatic upgrade policy", func() {
+			TestServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+					RespondWithJSON(http.StatusOK, clusterReady),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/control_plane/upgrade_policies"),
+					RespondWithJSON(http.StatusOK, `{
+						"page": 1,
+						"size": 1,
+						"total": 1,
+						"items": [`+policy+`]
+					}`),
+				),
+			)
+			Terraform.Source(`
+				resource "rhcs_cluster_upgrade_policy" "policy" {
+					cluster  = "123"
+					schedule = "0 2 * * 1"
+				}
+			`)
+			runOutput := Terraform.Apply()
+			Expect(runOutput.ExitCode).ToNot(BeZero())
+			runOutput.VerifyErrorContainsSubstring("already has automatic upgrade policy '456'")
+		})
+
+		It("fails if the upgrade policies of the cluster can't be listed", func() {
+			TestServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+					RespondWithJSON(http.StatusOK, clusterReady),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/control_plane/upgrade_policies"),
+					RespondWithJSON(http.StatusForbidden, `{
+						"kind": "Error",
+						"id": "403",
+						"href": "/api/clusters_mgmt/v1/errors/403",
+						"code": "CLUSTERS-MGMT-403",
+						"reason": "Forbidden"
+					}`),
+				),
+			)
+			Terraform.Source(`
+				resource "rhcs_cluster_upgrade_policy" "policy" {
+					cluster  = "123"
+					schedule = "0 2 * * 1"
+				}
+			`)
+			runOutput := Terraform.Apply()
+			Expect(runOutput.ExitCode).ToNot(BeZero())
+			runOutput.VerifyErrorContainsSubstring("Cannot list upgrade policies for cluster '123'")
+		})
+
+		It("creates a control plane upgrade policy with a maintenance window", func() {
+			TestServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+					RespondWithJSON(http.StatusOK, clusterReady),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/control_plane/upgrade_policies"),
+					RespondWithJSON(http.StatusOK, policiesEmpty),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters/123/control_plane/upgrade_policies"),
+					VerifyJQ(".schedule", "0 2 * * 1"),
+					VerifyJQ(".enable_minor_version_upgrades", true),
+					VerifyJQ(".next_run", "2026-11-02T02:00:00Z"),
+					RespondWithPatchedJSON(http.StatusCreated, policy, `[
+						{
+							"op": "replace",
+							"path": "/enable_minor_version_upgrades",
+							"value": true
+						},
+						{
+							"op": "replace",
+							"path": "/next_run",
+							"value": "2026-11-02T02:00:00Z"
+						}
+					]`),
+				),
+			)
+			Terraform.Source(`
+				resource "rhcs_cluster_upgrade_policy" "policy" {
+					cluster                       = "123"
+					schedule                      = "0 2 * * 1"
+					enable_minor_version_upgrades = true
+					maintenance_window_start      = "2026-11-02T02:00:00Z"
+				}
+			`)
+			runOutput := Terraform.Apply()
+			Expect(runOutput.ExitCode).To(BeZero())
+
+			resource := Terraform.Resource("rhcs_cluster_upgrade_policy", "policy")
+			Expect(resource).To(MatchJQ(".attributes.id", "456"))
+			Expect(resource).To(MatchJQ(".attributes.enable_minor_version_upgrades", true))
+			Expect(resource).To(MatchJQ(".attributes.maintenance_window_start", "2026-11-02T02:00:00Z"))
+			Expect(resource).To(MatchJQ(".attributes.next_run", "2026-11-02T02:00:00Z"))
+		})
+	})
+
+	Context("update and deletion", func() {
+		BeforeEach(createPolicy)
+
+		It("updates the schedule", func() {
+			TestServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+					RespondWithJSON(http.StatusOK, clusterReady),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/control_plane/upgrade_policies/456"),
+					RespondWithJSON(http.StatusOK, policy),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+					RespondWithJSON(http.StatusOK, clusterReady),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/123/control_plane/upgrade_policies/456"),
+					VerifyJQ(".schedule", "0 4 * * 6"),
+					VerifyJQ(".next_run", nil),
+					RespondWithPatchedJSON(http.StatusOK, policy, `[
+						{
+							"op": "replace",
+							"path": "/schedule",
+							"value": "0 4 * * 6"
+						},
+						{
+							"op": "replace",
+							"path": "/next_run",
+							"value": "2026-10-24T04:00:00Z"
+						}
+					]`),
+				),
+			)
+			Terraform.Source(`
+				resource "rhcs_cluster_upgrade_policy" "policy" {
+					cluster  = "123"
+					schedule = "0 4 * * 6"
+				}
+			`)
+			runOutput := Terraform.Apply()
+			Expect(runOutput.ExitCode).To(BeZero())
+
+			resource := Terraform.Resource("rhcs_cluster_upgrade_policy", "policy")
+			Expect(resource).To(MatchJQ(".attributes.schedule", "0 4 * * 6"))
+			Expect(resource).To(MatchJQ(".attributes.next_run", "2026-10-24T04:00:00Z"))
+		})
+
+		It("deletes the policy", func() {
+			TestServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+					RespondWithJSON(http.StatusOK, clusterReady),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/control_plane/upgrade_policies/456"),
+					RespondWithJSON(http.StatusOK, policy),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+					RespondWithJSON(http.StatusOK, clusterReady),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodDelete, "/api/clusters_mgmt/v1/clusters/123/control_plane/upgrade_policies/456"),
+					RespondWithJSON(http.StatusNoContent, "{}"),
+				),
+			)
+			runOutput := Terraform.Destroy()
+			Expect(runOutput.ExitCode).To(BeZero())
+		})
+
+		It("removes the policy from the state if it was deleted outside of Terraform", func() {
+			TestServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+					RespondWithJSON(http.StatusOK, clusterReady),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/control_plane/upgrade_policies/456"),
+					RespondWithJSON(http.StatusNotFound, `{
+						"kind": "Error",
+						"id": "404",
+						"href": "/api/clusters_mgmt/v1/errors/404",
+						"code": "CLUSTERS-MGMT-404",
+						"reason": "Upgrade policy '456' not found"
+					}`),
+				),
+			)
+			runOutput := Terraform.Refresh()
+			Expect(runOutput.ExitCode).To(BeZero())
+			Expect(Terraform.State()).To(MatchJQ(
+				`[.resources[] | select(.type == "rhcs_cluster_upgrade_policy")] | length`, 0,
+			))
+		})
+	})
+
+	Context("import", func() {
+		It("imports the automatic upgrade policy using the cluster identifier", func() {
+			TestServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+					RespondWithJSON(http.StatusOK, clusterReady),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/control_plane/upgrade_policies"),
+					RespondWithJSON(http.StatusOK, `{
+						"page": 1,
+						"size": 1,
+						"total": 1,
+						"items": [`+policy+`]
+					}`),
+				),
+			)
+			Terraform.Source(`
+				resource "rhcs_cluster_upgrade_policy" "policy" {
+				}
+			`)
+			runOutput := Terraform.Import("rhcs_cluster_upgrade_policy.policy", "123")
+			Expect(runOutput.ExitCode).To(BeZero())
+
+			resource := Terraform.Resource("rhcs_cluster_upgrade_policy", "policy")
+			Expect(resource).To(MatchJQ(".attributes.id", "456"))
+			Expect(resource).To(MatchJQ(".attributes.cluster", "123"))
+			Expect(resource).To(MatchJQ(".attributes.schedule", "0 2 * * 1"))
+			Expect(resource).To(MatchJQ(".attributes.next_run", "2026-10-19T02:00:00Z"))
+		})
+
+		It("fails if the import identifier is malformed", func() {
+			Terraform.Source(`
+				resource "rhcs_cluster_upgrade_policy" "policy" {
+				}
+			`)
+			runOutput := Terraform.Import("rhcs_cluster_upgrade_policy.policy", "123,456,789")
+			Expect(runOutput.ExitCode).ToNot(BeZero())
+			runOutput.VerifyErrorContainsSubstring("Expected import identifier with format")
+		})
+	})
+})
@@ -0,0 +1,278 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hcp
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"                      // nolint
+	. "github.com/onsi/gomega"                         // nolint
+	. "github.com/onsi/gomega/ghttp"                   // nolint
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+	. "github.com/terraform-redhat/terraform-provider-rhcs/subsystem/framework"
+)
+
+var _ = Describe("External authentication provider", func() {
+	const clusterReady = `{
+		"kind": "Cluster",
+		"id": "123",
+		"href": "/api/clusters_mgmt/v1/clusters/123",
+		"name": "cluster",
+		"state": "ready",
+		"hypershift": {
+			"enabled": true
+		},
+		"external_auth_config": {
+			"enabled": true
+		}
+	}`
+	const externalAuth = `{
+		"kind": "ExternalAuth",
+		"id": "my-idp",
+		"href": "/api/clusters_mgmt/v1/clusters/123/external_auth_config/external_auths/my-idp",
+		"issuer": {
+			"url": "https://idp.example.com",
+			"audiences": ["abc", "def"]
+		},
+		"claim": {
+			"mappings": {
+				"username": {
+					"claim": "email",
+					"prefix_policy": "NoPrefix"
+				},
+				"groups": {
+					"claim": "groups",
+					"prefix": "idp:"
+				}
+			}
+		},
+		"clients": [
+			{
+				"id": "console-client",
+				"component": {
+					"name": "console",
+					"namespace": "openshift-console"
+				}
+			}
+		]
+	}`
+	const template = `
+		resource "rhcs_hcp_external_auth_provider" "idp" {
+			cluster = "123"
+			name    = "my-idp"
+			issuer = {
+				url       = "https://idp.example.com"
+				audiences = ["abc", "def"]
+			}
+			claim = {
+				username = {
+					claim         = "email"
+					prefix_policy = "NoPrefix"
+				}
+				groups = {
+					claim  = "groups"
+					prefix = "idp:"
+				}
+			}
+			console_client = {
+				client_id     = "console-client"
+				client_secret = "my-secret"
+			}
+		}
+	`
+
+	createExternalAuth := func() {
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, clusterReady),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodPost,
+					"/api/clusters_mgmt/v1/clusters/123/external_auth_config/external_auths"),
+				VerifyJQ(".id", "my-idp"),
+				VerifyJQ(".issuer.url", "https://idp.example.com"),
+				VerifyJQ(".issuer.audiences", []interface{}{"abc", "def"}),
+				VerifyJQ(".claim.mappings.username.claim", "email"),
+				VerifyJQ(".claim.mappings.username.prefix_policy", "NoPrefix"),
+				VerifyJQ(".claim.mappings.groups.prefix", "idp:"),
+				VerifyJQ(".clients[0].id", "console-client"),
+				VerifyJQ(".clients[0].secret", "my-secret"),
+				VerifyJQ(".clients[0].component.name", "console"),
+				VerifyJQ(".clients[0].component.namespace", "openshift-console"),
+				RespondWithJSON(http.StatusCreated, externalAuth),
+			),
+		)
+		Terraform.Source(template)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).To(BeZero())
+	}
+
+	Context("creation", func() {
+		It("fails if the issuer URL doesn't use https", func() {
+			Terraform.Source(`
+				resource "rhcs_hcp_external_auth_provider" "idp" {
+					cluster = "123"
+					name    = "my-idp"
+					issuer = {
+						url       = "http://idp.example.com"
+						audiences = ["abc"]
+					}
+				}
+			`)
+			runOutput := Terraform.Validate()
+			Expect(runOutput.ExitCode).ToNot(BeZero())
+			runOutput.VerifyErrorContainsSubstring("issuer URL must use the https scheme")
+		})
+
+		It("fails if the cluster doesn't have external authentication enabled", func() {
+			TestServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+					RespondWithPatchedJSON(http.StatusOK, clusterReady, `[
+						{
+							"op": "replace",
+							"path": "/external_auth_config/enabled",
+							"value": false
+						}
+					]`),
+				),
+			)
+			Terraform.Source(template)
+			runOutput := Terraform.Apply()
+			Expect(runOutput.ExitCode).ToNot(BeZero())
+			runOutput.VerifyErrorContainsSubstring("doesn't have external authentication providers enabled")
+		})
+
+		It("creates the external authentication provider", func() {
+			createExternalAuth()
+
+			resource := Terraform.Resource("rhcs_hcp_external_auth_provider", "idp")
+			Expect(resource).To(MatchJQ(".attributes.id", "my-idp"))
+			Expect(resource).To(MatchJQ(".attributes.claim.groups.prefix", "idp:"))
+			Expect(resource).To(MatchJQ(".attributes.console_client.client_secret", "my-secret"))
+		})
+	})
+
+	Context("update and deletion", func() {
+		BeforeEach(createExternalAuth)
+
+		It("updates the audiences", func() {
+			TestServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet,
+						"/api/clusters_mgmt/v1/clusters/123/external_auth_config/external_auths/my-idp"),
+					RespondWithJSON(http.StatusOK, externalAuth),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodPatch,
+						"/api/clusters_mgmt/v1/clusters/123/external_auth_config/external_auths/my-idp"),
+					VerifyJQ(".issuer.audiences", []interface{}{"abc"}),
+					RespondWithPatchedJSON(http.StatusOK, externalAuth, `[
+						{
+							"op": "replace",
+							"path": "/issuer/audiences",
+							"value": ["abc"]
+						}
+					]`),
+				),
+			)
+			Terraform.Source(`
+				resource "rhcs_hcp_external_auth_provider" "idp" {
+					cluster = "123"
+					name    = "my-idp"
+					issuer = {
+						url       = "https://idp.example.com"
+						audiences = ["abc"]
+					}
+					claim = {
+						username = {
+							claim         = "email"
+							prefix_policy = "NoPrefix"
+						}
+						groups = {
+							claim  = "groups"
+							prefix = "idp:"
+						}
+					}
+					console_client = {
+						client_id     = "console-client"
+						client_secret = "my-secret"
+					}
+				}
+			`)
+			runOutput := Terraform.Apply()
+			Expect(runOutput.ExitCode).To(BeZero())
+
+			resource := Terraform.Resource("rhcs_hcp_external_auth_provider", "idp")
+			Expect(resource).To(MatchJQ(".attributes.issuer.audiences", []interface{}{"abc"}))
+		})
+
+		It("deletes the external authentication provider", func() {
+			TestServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet,
+						"/api/clusters_mgmt/v1/clusters/123/external_auth_config/external_auths/my-idp"),
+					RespondWithJSON(http.StatusOK, externalAuth),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodDelete,
+						"/api/clusters_mgmt/v1/clusters/123/external_auth_config/external_auths/my-idp"),
+					RespondWithJSON(http.StatusNoContent, "{}"),
+				),
+			)
+			runOutput := Terraform.Destroy()
+			Expect(runOutput.ExitCode).To(BeZero())
+		})
+	})
+
+	Context("import", func() {
+		It("imports the external authentication provider by cluster and name", func() {
+			TestServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet,
+						"/api/clusters_mgmt/v1/clusters/123/external_auth_config/external_auths/my-idp"),
+					RespondWithJSON(http.StatusOK, externalAuth),
+				),
+			)
+			Terraform.Source(`
+				resource "rhcs_hcp_external_auth_provider" "idp" {
+				}
+			`)
+			runOutput := Terraform.Import("rhcs_hcp_external_auth_provider.idp", "123,my-idp")
+			Expect(runOutput.ExitCode).To(BeZero())
+
+			resource := Terraform.Resource("rhcs_hcp_external_auth_provider", "idp")
+			Expect(resource).To(MatchJQ(".attributes.cluster", "123"))
+			Expect(resource).To(MatchJQ(".attributes.name", "my-idp"))
+			Expect(resource).To(MatchJQ(".attributes.issuer.url", "https://idp.example.com"))
+			Expect(resource).To(MatchJQ(".attributes.claim.username.claim", "email"))
+			Expect(resource).To(MatchJQ(".attributes.console_client.client_id", "console-client"))
+			Expect(resource).To(MatchJQ(".attributes.console_client.client_secret", nil))
+		})
+
+		It("fails if the import identifier doesn't contain the name", func() {
+			Terraform.Source(`
+				resource "rhcs_hcp_external_auth_provider" "idp" {
+				}
+			`)
+			runOutput := Terraform.Import("rhcs_hcp_external_auth_provider.idp", "123")
+			Expect(runOutput.ExitCode).ToNot(BeZero())
+			runOutput.VerifyErrorContainsSubstring("should be specified as <cluster_id>,<name>")
+		})
+	})
+})
@@ -1511,6 +1511,73 @@ var _ = Describe("Hcp Machine pool", func() {
 			Expect(runOutput.ExitCode).To(BeZero())
 		})
 
+		It("Can create machine pool with provider default tags", func() {
+			// Prepare the server:
+			TestServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(
+						http.MethodPost,
+						"/api/clusters_mgmt/v1/clusters/123/node_pools",
+					),
+					VerifyJQ(".aws_node_pool.tags.\"cost-center\"", "123"),
+					VerifyJQ(".aws_node_pool.tags.\"test-label\"", "test-value"),
+					RespondWithJSON(http.StatusOK, `{
+				  "id": "my-pool",
+				  "availability_zone": "us-east-1a",
+				  "replicas": 12,
+				  "aws_node_pool": {
+					"instance_type": "r5.xlarge",
+					"instance_profile": "bla",
+					"tags": {
+						"cost-center":"123",
+						"test-label":"test-value"
+					}
+				  },
+				  "auto_repair": true,
+				  "version": {
+					  "raw_id": "4.14.10"
+				  },
+				  "subnet": "subnet-123"
+				}`),
+				),
+			)
+
+			// Run the apply command:
+			Terraform.ProviderSettings(`
+			default_tags {
+				tags = {
+					"cost-center" = "123"
+				}
+			}
+			`)
+			Terraform.Source(`
+		  resource "rhcs_hcp_machine_pool" "my_pool" {
+		    cluster      = "123"
+		    name         = "my-pool"
+		    aws_node_pool = {
+				instance_type = "r5.xlarge"
+				tags = {
+					"test-label" = "test-value"
+				}
+			}
+			autoscaling = {
+				enabled = false
+			}
+		    replicas     = 12
+			version = "4.14.10"
+			subnet_id = "subnet-123"
+			auto_repair = true
+		  }
+		`)
+			runOutput := Terraform.Apply()
+			Expect(runOutput.ExitCode).To(BeZero())
+
+			// Check the state only contains the configured tags:
+			resource := Terraform.Resource("rhcs_hcp_machine_pool", "my_pool")
+			Expect(resource).To(MatchJQ(`.attributes.aws_node_pool.tags | length`, 1))
+			Expect(resource).To(MatchJQ(`.attributes.aws_node_pool.tags."test-label"`, "test-value"))
+		})
+
 		It("Can create machine pool with aws tags, but cannot edit", func() {
 			// Prepare the server:
 			TestServer.AppendHandlers(
@@ -3308,6 +3375,8 @@ var _ = Describe("Hcp Machine pool", func() {
 
 	Context("Import", func() {
 		It("Can import a machine pool", func() {
+			// The first read is for resolving the cluster of the import identifier
+			prepareClusterRead("123")
 			prepareClusterRead("123")
 			// Prepare the server:
 			TestServer.AppendHandlers(
@@ -3345,10 +3414,124 @@ var _ = Describe("Hcp Machine pool", func() {
 			Expect(resource).To(MatchJQ(".attributes.name", "my-pool"))
 			Expect(resource).To(MatchJQ(".attributes.id", "my-pool"))
 		})
+
+		It("Can import a machine pool using the name of the cluster", func() {
+			// Prepare the server:
+			TestServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, clusterUri+"my-cluster"),
+					RespondWithJSON(http.StatusNotFound, "{}"),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters"),
+					VerifyFormKV("search", "name = 'my-cluster' or external_id = 'my-cluster'"),
+					RespondWithJSON(http.StatusOK, `{
+						"kind": "ClusterList",
+						"page": 1,
+						"size": 1,
+						"total": 1,
+						"items": [
+							{
+								"kind": "Cluster",
+								"id": "123",
+								"name": "my-cluster"
+							}
+						]
+					}`),
+				),
+			)
+			prepareClusterRead("123")
+			TestServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/node_pools/my-pool"),
+					RespondWithJSON(http.StatusOK, `
+						{
+						  "id": "my-pool",
+						  "kind": "MachinePool",
+						  "href": "/api/clusters_mgmt/v1/clusters/123/node_pools/my-pool",
+						  "replicas": 12,
+						  "aws_node_pool": {
+							"instance_type": "r5.xlarge",
+							"instance_profile": "bla"
+						  },
+						  "auto_repair": true,
+						  "version": {
+							  "raw_id": "4.14.10"
+						  }
+						}`),
+				),
+			)
+
+			// Run the import command:
+			Terraform.Source(`resource "rhcs_hcp_machine_pool" "my_pool" {}`)
+			runOutput := Terraform.Import("rhcs_hcp_machine_pool.my_pool", "my-cluster,my-pool")
+			Expect(runOutput.ExitCode).To(BeZero())
+			resource := Terraform.Resource("rhcs_hcp_machine_pool", "my_pool")
+			Expect(resource).To(MatchJQ(".attributes.cluster", "123"))
+			Expect(resource).To(MatchJQ(".attributes.id", "my-pool"))
+		})
+	})
+
+	Context("State upgrade", func() {
+		It("Moves the autoscaling attributes saved by old versions", func() {
+			// Save the state as it was written by a version that had the autoscaling
+			// attributes at the top level:
+			Terraform.ResourceState("rhcs_hcp_machine_pool", "my_pool", 0, `{
+			  "id": "my-pool",
+			  "cluster": "123",
+			  "name": "my-pool",
+			  "autoscaling_enabled": true,
+			  "min_replicas": 2,
+			  "max_replicas": 4,
+			  "aws_node_pool": {
+			    "instance_type": "r5.xlarge",
+			    "instance_profile": "bla",
+			    "ec2_metadata_http_tokens": "optional",
+			    "disk_size": 300
+			  },
+			  "availability_zone": "us-east-1a",
+			  "subnet_id": "subnet-123",
+			  "auto_repair": true,
+			  "version": "4.14.10",
+			  "current_version": "4.14.10",
+			  "ignore_deletion_error": false,
+			  "status": {
+			    "current_replicas": 2,
+			    "message": ""
+			  }
+			}`)
+			Terraform.Source(`
+			  resource "rhcs_hcp_machine_pool" "my_pool" {
+			    cluster = "123"
+			    name    = "my-pool"
+			    aws_node_pool = {
+			      instance_type = "r5.xlarge"
+			    }
+			    autoscaling = {
+			      enabled      = true
+			      min_replicas = 2
+			      max_replicas = 4
+			    }
+			    subnet_id   = "subnet-123"
+			    auto_repair = true
+			    version     = "4.14.10"
+			  }
+			`)
+			runOutput := Terraform.UpgradeState()
+			Expect(runOutput.ExitCode).To(BeZero())
+			runOutput.VerifyOutputContainsSubstring("No changes.")
+			resource := Terraform.Resource("rhcs_hcp_machine_pool", "my_pool")
+			Expect(resource).To(MatchJQ(".schema_version", 1.0))
+			Expect(resource).To(MatchJQ(".attributes.autoscaling.enabled", true))
+			Expect(resource).To(MatchJQ(".attributes.autoscaling.min_replicas", 2.0))
+			Expect(resource).To(MatchJQ(".attributes.autoscaling.max_replicas", 4.0))
+			Expect(resource).To(MatchJQ(".attributes.autoscaling_enabled", nil))
+		})
 	})
 
 	Context("Machine pool delete", func() {
 		clusterId := "123"
+		deletionPolicy := ""
 
 		preparePoolRead := func(clusterId string, poolId string) {
 			TestServer.AppendHandlers(
@@ -3414,7 +3597,8 @@ var _ = Describe("Hcp Machine pool", func() {
 				}
 				version = "4.14.10"
 				auto_repair = true
-			}`, "PoolId", poolId, "ClusterId", clusterId))
+				{{if .DeletionPolicy}}deletion_policy = "{{.DeletionPolicy}}"{{end}}
+			}`, "PoolId", poolId, "ClusterId", clusterId, "DeletionPolicy", deletionPolicy))
 
 			// Run the apply command:
 			runOutput := Terraform.Apply()
@@ -3426,6 +3610,10 @@ var _ = Describe("Hcp Machine pool", func() {
 		}
 
 		BeforeEach(func() {
+			deletionPolicy = ""
+		})
+
+		JustBeforeEach(func() {
 			createPool(clusterId, "pool1")
 		})
 
@@ -3552,6 +3740,24 @@ var _ = Describe("Hcp Machine pool", func() {
 			runOutput := Terraform.Apply()
 			Expect(runOutput.ExitCode).To(BeZero())
 		})
+
+		Context("Retained machine pool", func() {
+			BeforeEach(func() {
+				deletionPolicy = "retain"
+			})
+
+			It("doesn't delete the machine pool", func() {
+				// Prepare for refresh (Read) of the pools prior to changes, there is no delete request:
+				prepareClusterRead(clusterId)
+				preparePoolRead(clusterId, "pool1")
+
+				// Re-apply w/ empty source so that pool1 is removed from the state
+				Terraform.Source("")
+				runOutput := Terraform.Apply()
+				Expect(runOutput.ExitCode).To(BeZero())
+				Expect(Terraform.State()).To(MatchJQ(`.resources | length`, 0))
+			})
+		})
 	})
 
 	Context("Upgrade", func() {
@@ -0,0 +1,189 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hcp
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"                      // nolint
+	. "github.com/onsi/gomega"                         // nolint
+	. "github.com/onsi/gomega/ghttp"                   // nolint
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+	. "github.com/terraform-redhat/terraform-provider-rhcs/subsystem/framework"
+)
+
+var _ = Describe("Network verification", func() {
+	const source = `
+		resource "rhcs_network_verification" "verification" {
+			cloud_region   = "us-west-1"
+			role_arn       = "arn:aws:iam::123456789012:role/installer"
+			aws_subnet_ids = ["subnet-1", "subnet-2"]
+			aws_additional_compute_security_group_ids = ["sg-1"]
+			tags = {
+				owner = "me"
+			}
+			hosted_cp = true
+		}
+	`
+
+	It("verifies the subnets and waits till the verification has finished", func() {
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/network_verifications"),
+				VerifyJQ(".platform", "aws-hosted-cp"),
+				VerifyJQ(".cloud_provider_data.region.id", "us-west-1"),
+				VerifyJQ(".cloud_provider_data.subnets", []interface{}{"subnet-1", "subnet-2"}),
+				VerifyJQ(".cloud_provider_data.aws.sts.role_arn", "arn:aws:iam::123456789012:role/installer"),
+				VerifyJQ(".cloud_provider_data.aws.additional_compute_security_group_ids",
+					[]interface{}{"sg-1"}),
+				VerifyJQ(".cloud_provider_data.aws.tags.owner", "me"),
+				RespondWithJSON(http.StatusCreated, `{
+					"items": [
+						{
+							"id": "subnet-1",
+							"state": "pending"
+						},
+						{
+							"id": "subnet-2",
+							"state": "passed"
+						}
+					]
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/network_verifications/subnet-1"),
+				RespondWithJSON(http.StatusOK, `{
+					"id": "subnet-1",
+					"state": "passed"
+				}`),
+			),
+		)
+		Terraform.Source(source)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).To(BeZero())
+
+		resource := Terraform.Resource("rhcs_network_verification", "verification")
+		Expect(resource).To(MatchJQ(".attributes.id", "subnet-1,subnet-2"))
+		Expect(resource).To(MatchJQ(".attributes.subnets | length", 2))
+		Expect(resource).To(MatchJQ(".attributes.subnets[0].state", "passed"))
+		Expect(resource).To(MatchJQ(".attributes.subnets[1].id", "subnet-2"))
+	})
+
+	It("fails with the details of the subnets that didn't pass", func() {
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/network_verifications"),
+				RespondWithJSON(http.StatusCreated, `{
+					"items": [
+						{
+							"id": "subnet-1",
+							"state": "running"
+						},
+						{
+							"id": "subnet-2",
+							"state": "passed"
+						}
+					]
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/network_verifications/subnet-1"),
+				RespondWithJSON(http.StatusOK, `{
+					"id": "subnet-1",
+					"state": "failed",
+					"details": [
+						"egressURL error: https://quay.io:443",
+						"egressURL error: https://api.openshift.com:443"
+					]
+				}`),
+			),
+		)
+		Terraform.Source(source)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).ToNot(BeZero())
+		runOutput.VerifyErrorContainsSubstring("subnet-1 (failed)")
+		runOutput.VerifyErrorContainsSubstring("https://quay.io:443")
+
+		// Nothing is saved, so that the next apply runs the verification again:
+		Expect(Terraform.State()).To(MatchJQ(
+			`[.resources[] | select(.type == "rhcs_network_verification")] | length`, 0))
+	})
+
+	It("runs the verification again when the subnets change", func() {
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/network_verifications"),
+				RespondWithJSON(http.StatusCreated, `{
+					"items": [
+						{
+							"id": "subnet-1",
+							"state": "passed"
+						},
+						{
+							"id": "subnet-2",
+							"state": "passed"
+						}
+					]
+				}`),
+			),
+		)
+		Terraform.Source(source)
+		runOutput := Terraform.Apply()
+		Expect(runOutput.ExitCode).To(BeZero())
+
+		TestServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/network_verifications"),
+				VerifyJQ(".cloud_provider_data.subnets", []interface{}{"subnet-3"}),
+				VerifyJQ(".platform", "aws-classic"),
+				RespondWithJSON(http.StatusCreated, `{
+					"items": [
+						{
+							"id": "subnet-3",
+							"state": "passed"
+						}
+					]
+				}`),
+			),
+		)
+		Terraform.Source(`
+			resource "rhcs_network_verification" "verification" {
+				cloud_region   = "us-west-1"
+				role_arn       = "arn:aws:iam::123456789012:role/installer"
+				aws_subnet_ids = ["subnet-3"]
+			}
+		`)
+		runOutput = Terraform.Apply()
+		Expect(runOutput.ExitCode).To(BeZero())
+
+		resource := Terraform.Resource("rhcs_network_verification", "verification")
+		Expect(resource).To(MatchJQ(".attributes.id", "subnet-3"))
+	})
+
+	It("rejects role ARNs that aren't valid", func() {
+		Terraform.Source(`
+			resource "rhcs_network_verification" "verification" {
+				cloud_region   = "us-west-1"
+				role_arn       = "installer"
+				aws_subnet_ids = ["subnet-1"]
+			}
+		`)
+		runOutput := Terraform.Validate()
+		Expect(runOutput.ExitCode).ToNot(BeZero())
+		runOutput.VerifyErrorContainsSubstring("role ARN must be a valid AWS IAM role ARN")
+	})
+})
@@ -0,0 +1,202 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/build"
+)
+
+const (
+	// serviceName is the name of the service reported in the traces, unless it is overridden with
+	// the `OTEL_SERVICE_NAME` environment variable.
+	serviceName = "terraform-provider-rhcs"
+
+	// instrumentationName is the name of the tracer used to create the spans.
+	instrumentationName = "github.com/terraform-redhat/terraform-provider-rhcs"
+)
+
+// Attributes added to the spans:
+const (
+	ClusterIDKey         = attribute.Key("cluster.id")
+	ClusterStateKey      = attribute.Key("cluster.state")
+	ResourceTypeKey      = attribute.Key("resource.type")
+	ResourceOperationKey = attribute.Key("resource.operation")
+	PollIterationKey     = attribute.Key("poll.iteration")
+	StatusCodeKey        = attribute.Key("http.response.status_code")
+)
+
+// Enabled returns true if the traces should be exported. Following the OpenTelemetry conventions
+// they are exported when an OTLP endpoint is configured with the `OTEL_EXPORTER_OTLP_ENDPOINT` or
+// `OTEL_EXPORTER_OTLP_TRACES_ENDPOINT` environment variables, or when `OTEL_TRACES_EXPORTER` is set
+// to `otlp`. Setting `OTEL_SDK_DISABLED` to `true` or `OTEL_TRACES_EXPORTER` to `none` disables them.
+func Enabled() bool {
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") {
+		return false
+	}
+	switch strings.ToLower(os.Getenv("OTEL_TRACES_EXPORTER")) {
+	case "none":
+		return false
+	case "otlp":
+		return true
+	}
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" ||
+		os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Setup configures the global tracer provider with an OTLP exporter, using the standard `OTEL_*`
+// environment variables. The protocol is selected with `OTEL_EXPORTER_OTLP_TRACES_PROTOCOL` or
+// `OTEL_EXPORTER_OTLP_PROTOCOL`, and it is `http/protobuf` by default. If the `TRACEPARENT`
+// environment variable is set, the spans of the provider become part of that trace. The returned
+// function flushes the pending spans and must be called before the process exits. When tracing
+// isn't enabled nothing is configured, so the spans created by the provider are no-ops.
+func Setup(ctx context.Context) (func(context.Context) error, error) {
+	if !Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch protocol() {
+	case "grpc":
+		exporter, err = otlptracegrpc.New(ctx)
+	default:
+		exporter, err = otlptracehttp.New(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The attributes from the `OTEL_SERVICE_NAME` and `OTEL_RESOURCE_ATTRIBUTES` environment
+	// variables are detected last, so that they take precedence:
+	resource, err := sdkresource.New(
+		ctx,
+		sdkresource.WithTelemetrySDK(),
+		sdkresource.WithAttributes(
+			semconv.ServiceName(serviceName),
+			semconv.ServiceVersion(build.Version),
+		),
+		sdkresource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return provider.Shutdown, nil
+}
+
+// protocol returns the OTLP protocol configured in the environment.
+func protocol() string {
+	if value := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL"); value != "" {
+		return value
+	}
+	return os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL")
+}
+
+// ParentContext returns a copy of the context that contains the parent span given in the
+// `TRACEPARENT` and `TRACESTATE` environment variables, if any.
+func ParentContext(ctx context.Context) context.Context {
+	carrier := propagation.MapCarrier{}
+	if value := os.Getenv("TRACEPARENT"); value != "" {
+		carrier["traceparent"] = value
+	}
+	if value := os.Getenv("TRACESTATE"); value != "" {
+		carrier["tracestate"] = value
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
+
+// StartSpan starts a span with the given name and attributes, as a child of the span contained in
+// the context.
+func StartSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context,
+	trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// StartClientSpan starts a span for a request sent to a remote server.
+func StartClientSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context,
+	trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attributes...),
+		trace.WithSpanKind(trace.SpanKindClient))
+}
+
+// EndSpan ends the span, recording the error if it isn't nil.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// PollSpans creates a span for each iteration of a polling loop. Each span covers the time since
+// the previous iteration, so that the time spent waiting between requests is part of it.
+type PollSpans struct {
+	ctx        context.Context
+	name       string
+	attributes []attribute.KeyValue
+	start      time.Time
+	iteration  int
+}
+
+// NewPollSpans creates the object that creates the spans of the iterations of a polling loop. The
+// given attributes are added to all the spans.
+func NewPollSpans(ctx context.Context, name string, attributes ...attribute.KeyValue) *PollSpans {
+	return &PollSpans{
+		ctx:        ctx,
+		name:       name,
+		attributes: attributes,
+		start:      time.Now(),
+	}
+}
+
+// Iteration records the span of an iteration that has just finished, with the given additional
+// attributes.
+func (p *PollSpans) Iteration(attributes ...attribute.KeyValue) {
+	p.iteration++
+	now := time.Now()
+	_, span := otel.Tracer(instrumentationName).Start(
+		p.ctx, p.name,
+		trace.WithTimestamp(p.start),
+		trace.WithAttributes(p.attributes...),
+		trace.WithAttributes(attributes...),
+		trace.WithAttributes(PollIterationKey.Int(p.iteration)),
+	)
+	span.End(trace.WithTimestamp(now))
+	p.start = now
+}
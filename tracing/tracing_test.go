@@ -0,0 +1,124 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+var _ = Describe("Tracing", func() {
+	var recorder *tracetest.SpanRecorder
+
+	BeforeEach(func() {
+		recorder = tracetest.NewSpanRecorder()
+		previous := otel.GetTracerProvider()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+		DeferCleanup(otel.SetTracerProvider, previous)
+	})
+
+	Context("Enabled", func() {
+		BeforeEach(func() {
+			for _, name := range []string{
+				"OTEL_SDK_DISABLED",
+				"OTEL_TRACES_EXPORTER",
+				"OTEL_EXPORTER_OTLP_ENDPOINT",
+				"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT",
+			} {
+				if value, ok := os.LookupEnv(name); ok {
+					DeferCleanup(os.Setenv, name, value)
+				} else {
+					DeferCleanup(os.Unsetenv, name)
+				}
+				Expect(os.Unsetenv(name)).To(Succeed())
+			}
+		})
+
+		It("Should be disabled by default", func() {
+			Expect(Enabled()).To(BeFalse())
+		})
+
+		It("Should be enabled when an endpoint is configured", func() {
+			Expect(os.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318")).To(Succeed())
+			Expect(Enabled()).To(BeTrue())
+		})
+
+		It("Should be enabled when the OTLP exporter is selected", func() {
+			Expect(os.Setenv("OTEL_TRACES_EXPORTER", "otlp")).To(Succeed())
+			Expect(Enabled()).To(BeTrue())
+		})
+
+		It("Should be disabled when the SDK is disabled", func() {
+			Expect(os.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "http://localhost:4318")).To(Succeed())
+			Expect(os.Setenv("OTEL_SDK_DISABLED", "true")).To(Succeed())
+			Expect(Enabled()).To(BeFalse())
+		})
+
+		It("Should be disabled when the exporter is none", func() {
+			Expect(os.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318")).To(Succeed())
+			Expect(os.Setenv("OTEL_TRACES_EXPORTER", "none")).To(Succeed())
+			Expect(Enabled()).To(BeFalse())
+		})
+	})
+
+	It("Should record the error of a span", func() {
+		_, span := StartSpan(context.Background(), "my-span", ClusterIDKey.String("123"))
+		EndSpan(span, errors.New("my-error"))
+
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].Name()).To(Equal("my-span"))
+		Expect(spans[0].Attributes()).To(ContainElement(ClusterIDKey.String("123")))
+		Expect(spans[0].Status().Code).To(Equal(codes.Error))
+		Expect(spans[0].Status().Description).To(Equal("my-error"))
+	})
+
+	It("Should create a span for each poll iteration", func() {
+		ctx, parent := StartSpan(context.Background(), "wait")
+		iterations := NewPollSpans(ctx, "poll", ClusterIDKey.String("123"))
+		iterations.Iteration(ClusterStateKey.String("installing"))
+		iterations.Iteration(ClusterStateKey.String("ready"))
+		EndSpan(parent, nil)
+
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(3))
+		for i, state := range []string{"installing", "ready"} {
+			Expect(spans[i].Name()).To(Equal("poll"))
+			Expect(spans[i].Parent().SpanID()).To(Equal(parent.SpanContext().SpanID()))
+			Expect(spans[i].Attributes()).To(ContainElements(
+				ClusterIDKey.String("123"),
+				ClusterStateKey.String(state),
+				PollIterationKey.Int(i+1),
+			))
+		}
+		Expect(spans[1].StartTime()).To(Equal(spans[0].EndTime()))
+	})
+
+	It("Should use the parent span from the environment", func() {
+		previous := otel.GetTextMapPropagator()
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+		DeferCleanup(otel.SetTextMapPropagator, previous)
+		value, ok := os.LookupEnv("TRACEPARENT")
+		if ok {
+			DeferCleanup(os.Setenv, "TRACEPARENT", value)
+		} else {
+			DeferCleanup(os.Unsetenv, "TRACEPARENT")
+		}
+		Expect(os.Setenv("TRACEPARENT", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")).To(Succeed())
+
+		_, span := StartSpan(ParentContext(context.Background()), "my-span")
+		EndSpan(span, nil)
+
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].SpanContext().TraceID().String()).To(Equal("0af7651916cd43dd8448eb211c80319c"))
+		Expect(spans[0].Parent().SpanID().String()).To(Equal("b7ad6b7169203331"))
+	})
+})